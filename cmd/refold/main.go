@@ -0,0 +1,27 @@
+// Comando refold recalcula as colunas de busca normalizadas (title_search,
+// name_search) com a mesma função usada pela API. Rode depois das migrações.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	db := database.Connection()
+	defer db.Close()
+
+	for _, c := range textsearch.SearchColumns {
+		n, err := textsearch.Refold(context.Background(), db, c)
+		if err != nil {
+			log.Fatalf("Erro ao recalcular %s.%s: %v", c.Table, c.Target, err)
+		}
+		log.Printf("%s.%s: %d linhas atualizadas", c.Table, c.Target, n)
+	}
+}
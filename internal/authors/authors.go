@@ -3,6 +3,8 @@ package authors
 import (
 	"context"
 	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 type Authors struct {
@@ -18,7 +20,7 @@ type AuthorsCreator interface {
 }
 
 type AuthorsRead interface {
-	GetAll(ctx context.Context) ([]Authors, error)
+	GetAll(ctx context.Context, p pagination.Params) ([]Authors, pagination.Meta, error)
 	GetByID(ctx context.Context, id int64) (*Authors, error)
 }
 
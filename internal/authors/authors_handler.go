@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -62,13 +63,26 @@ func (h *AuthorHandler) CreateAuthor(c *gin.Context) {
 // @Tags authors
 // @Accept json
 // @Produce json
-// @Success 200 {array} AuthorResponse
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 10, máximo 100)"
+// @Param sort query string false "Campo de ordenação" Enums(id, name)
+// @Param order query string false "Direção da ordenação" Enums(asc, desc)
+// @Param cursor query string false "Cursor de next_cursor ou prev_cursor; substitui page, sort e order"
+// @Success 200 {object} pagination.Page[AuthorResponse]
+// @Failure 400 {object} middleware.APIError "Paginação ou ordenação inválida"
 // @Failure 500 {object} middleware.APIError "Erro interno"
 // @Router /public/api/authors [get]
 func (h *AuthorHandler) ReadAuthors(c *gin.Context) {
 	h.logApp.Info("Rode de obter autores")
 
-	authors, err := h.svc.GetAll(c.Request.Context())
+	params, err := pagination.Parse(c.Request.URL.Query(), AuthorSorts)
+	if err != nil {
+		h.logApp.Error("falha ao ler paginação", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	authors, meta, err := h.svc.GetAll(c.Request.Context(), params)
 	if err != nil {
 		h.logApp.Error("falha ao obter autores", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
@@ -80,7 +94,7 @@ func (h *AuthorHandler) ReadAuthors(c *gin.Context) {
 		response = append(response, ToResponse(&a))
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, pagination.NewPage(response, meta, params))
 }
 
 // @Summary Obter autor
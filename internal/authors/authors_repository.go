@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 )
 
 type AuthorsRepository struct {
@@ -15,8 +18,8 @@ func NewAuthorsRepository(db *sql.DB) *AuthorsRepository {
 }
 
 func (r *AuthorsRepository) Create(ctx context.Context, a *Authors) error {
-	query := "INSERT INTO authors (name, description) VALUES (?, ?)"
-	result, err := r.db.ExecContext(ctx, query, a.Name, a.Description)
+	query := "INSERT INTO authors (name, description, name_search) VALUES (?, ?, ?)"
+	result, err := r.db.ExecContext(ctx, query, a.Name, a.Description, textsearch.Fold(a.Name))
 	if err != nil {
 		return err
 	}
@@ -30,29 +33,57 @@ func (r *AuthorsRepository) Create(ctx context.Context, a *Authors) error {
 	return nil
 }
 
-func (r *AuthorsRepository) GetAll(ctx context.Context) ([]Authors, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT * FROM authors`)
+// AuthorSorts são os campos aceitos em sort na listagem de autores.
+var AuthorSorts = pagination.Sorts{
+	Fields:  map[string]string{"id": "id", "name": "name_search"},
+	Default: "id",
+	Key:     "id",
+}
+
+func (r *AuthorsRepository) GetAll(ctx context.Context, p pagination.Params) ([]Authors, pagination.Meta, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM authors").Scan(&total); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	seek, args := p.Seek(AuthorSorts)
+	if seek != "" {
+		seek = " WHERE " + seek
+	}
+
+	query := "SELECT id, name, description, " + AuthorSorts.Column(p) + " FROM authors" + seek + p.OrderBy(AuthorSorts) + " LIMIT ? OFFSET ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, p.Limit(), p.Offset())...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 	defer rows.Close()
 
 	var authorsAll []Authors
+	var positions []pagination.Row
 
 	for rows.Next() {
 		var a Authors
-		if err := rows.Scan(&a.ID, &a.Name, &a.Description); err != nil {
-			return nil, err
+		var position pagination.Row
+		if err := rows.Scan(&a.ID, &a.Name, &a.Description, &position.Value); err != nil {
+			return nil, pagination.Meta{}, err
 		}
+		position.Key = a.ID
+
 		authorsAll = append(authorsAll, a)
+		positions = append(positions, position)
 	}
-	return authorsAll, nil
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	authorsAll, meta := pagination.Window(p, total, authorsAll, positions)
+	return authorsAll, meta, nil
 }
 
 func (r *AuthorsRepository) GetByID(ctx context.Context, id int64) (*Authors, error) {
 	var author Authors
 
-	err := r.db.QueryRowContext(ctx, "SELECT * FROM authors WHERE id = ?", id).
+	err := r.db.QueryRowContext(ctx, "SELECT id, name, description FROM authors WHERE id = ?", id).
 		Scan(&author.ID, &author.Name, &author.Description)
 	if err != nil {
 		return nil, errors.New("erro ao realizar busca")
@@ -61,8 +92,8 @@ func (r *AuthorsRepository) GetByID(ctx context.Context, id int64) (*Authors, er
 }
 
 func (r *AuthorsRepository) Update(ctx context.Context, author *Authors) error {
-	result, err := r.db.ExecContext(ctx, "UPDATE authors SET name = ?, description = ? WHERE id = ?",
-		author.Name, author.Description, author.ID)
+	result, err := r.db.ExecContext(ctx, "UPDATE authors SET name = ?, description = ?, name_search = ? WHERE id = ?",
+		author.Name, author.Description, textsearch.Fold(author.Name), author.ID)
 	if err != nil {
 		return nil
 	}
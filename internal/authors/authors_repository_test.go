@@ -2,10 +2,12 @@ package authors_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 func TestAuthorsRepository_Create(t *testing.T) {
@@ -65,3 +67,122 @@ func TestAuthorsRepository_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorsRepository_GetAll(t *testing.T) {
+	db := database.SetupTestDB()
+	r := authors.NewAuthorsRepository(db)
+	ctx := context.Background()
+
+	for _, name := range []string{"Machado de Assis", "Álvares de Azevedo", "Clarice Lispector"} {
+		if err := r.Create(ctx, &authors.Authors{Name: name, Description: "Escritor"}); err != nil {
+			t.Fatalf("author: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		params    pagination.Params
+		wantNames []string
+	}{
+		{
+			name:      "padrão por id",
+			params:    pagination.Params{},
+			wantNames: []string{"Machado de Assis", "Álvares de Azevedo", "Clarice Lispector"},
+		},
+		{
+			name:      "nome ignora acentos",
+			params:    pagination.Params{Sort: "name"},
+			wantNames: []string{"Álvares de Azevedo", "Clarice Lispector", "Machado de Assis"},
+		},
+		{
+			name:      "segunda página",
+			params:    pagination.Params{Page: 2, PageSize: 2, Sort: "name", Order: pagination.Desc},
+			wantNames: []string{"Álvares de Azevedo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, meta, err := r.GetAll(ctx, tt.params)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if meta.Total != 3 {
+				t.Errorf("esperava total 3, recebeu %d", meta.Total)
+			}
+
+			names := make([]string, 0, len(got))
+			for _, a := range got {
+				names = append(names, a.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("esperava %v, recebeu %v", tt.wantNames, names)
+			}
+		})
+	}
+}
+
+func TestAuthorsRepository_GetAll_Cursor(t *testing.T) {
+	db := database.SetupTestDB()
+	r := authors.NewAuthorsRepository(db)
+	ctx := context.Background()
+
+	for _, name := range []string{"Cecília Meireles", "Jorge Amado", "Rachel de Queiroz", "Érico Veríssimo", "Graciliano Ramos"} {
+		if err := r.Create(ctx, &authors.Authors{Name: name, Description: "Escritor"}); err != nil {
+			t.Fatalf("author: %v", err)
+		}
+	}
+
+	names := func(all []authors.Authors) []string {
+		out := make([]string, 0, len(all))
+		for _, a := range all {
+			out = append(out, a.Name)
+		}
+		return out
+	}
+
+	params := pagination.Params{PageSize: 2, Sort: "name"}
+	first, meta, err := r.GetAll(ctx, params)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if want := []string{"Cecília Meireles", "Érico Veríssimo"}; !reflect.DeepEqual(names(first), want) {
+		t.Fatalf("primeira página: esperava %v, recebeu %v", want, names(first))
+	}
+
+	// um autor inserido antes do cursor não desloca a página seguinte
+	if err := r.Create(ctx, &authors.Authors{Name: "Adélia Prado", Description: "Poeta"}); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+
+	cursor, err := pagination.DecodeCursor(meta.NextCursor)
+	if err != nil {
+		t.Fatalf("next_cursor: %v", err)
+	}
+	params.Cursor = cursor
+
+	second, meta, err := r.GetAll(ctx, params)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if want := []string{"Graciliano Ramos", "Jorge Amado"}; !reflect.DeepEqual(names(second), want) {
+		t.Fatalf("segunda página: esperava %v, recebeu %v", want, names(second))
+	}
+	if meta.Total != 6 || meta.NextCursor == "" || meta.PrevCursor == "" {
+		t.Errorf("meta inesperada: %+v", meta)
+	}
+
+	if params.Cursor, err = pagination.DecodeCursor(meta.PrevCursor); err != nil {
+		t.Fatalf("prev_cursor: %v", err)
+	}
+
+	back, meta, err := r.GetAll(ctx, params)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if want := []string{"Cecília Meireles", "Érico Veríssimo"}; !reflect.DeepEqual(names(back), want) {
+		t.Errorf("voltando: esperava %v, recebeu %v", want, names(back))
+	}
+	if meta.PrevCursor == "" {
+		t.Errorf("esperava prev_cursor para Adélia Prado")
+	}
+}
@@ -2,6 +2,8 @@ package authors
 
 import (
 	"context"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 type AuthorsService interface {
@@ -25,8 +27,8 @@ func (u *serviceAuthors) Create(ctx context.Context, a *Authors) error {
 	return u.repo.Create(ctx, a)
 }
 
-func (u *serviceAuthors) GetAll(ctx context.Context) ([]Authors, error) {
-	return u.repo.GetAll(ctx)
+func (u *serviceAuthors) GetAll(ctx context.Context, p pagination.Params) ([]Authors, pagination.Meta, error) {
+	return u.repo.GetAll(ctx, p)
 }
 
 func (u *serviceAuthors) GetByID(ctx context.Context, id int64) (*Authors, error) {
@@ -0,0 +1,99 @@
+package books
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+var (
+	ErrCategoryNotFound  = errors.New("categoria não encontrada")
+	ErrCategoryLinked    = errors.New("categoria já associada ao livro")
+	ErrCategoryNotLinked = errors.New("categoria não associada ao livro")
+)
+
+func (r *BookRepository) RelationBookCategory(ctx context.Context, bookID, categoryID int64) error {
+	var linked int
+	query := "SELECT COUNT(*) FROM book_category WHERE book_id = ? AND category_id = ?"
+	if err := r.db.QueryRowContext(ctx, query, bookID, categoryID).Scan(&linked); err != nil {
+		return err
+	}
+	if linked > 0 {
+		return ErrCategoryLinked
+	}
+
+	// outro pedido pode gravar a mesma relação entre a consulta e o INSERT;
+	// a chave única barra a segunda e o erro dela vira o mesmo conflito
+	_, err := r.db.ExecContext(ctx, "INSERT INTO book_category (book_id, category_id) VALUES (?, ?)", bookID, categoryID)
+	if database.IsDuplicateKey(err) {
+		return ErrCategoryLinked
+	}
+	return err
+}
+
+func (r *BookRepository) RemoveCategory(ctx context.Context, bookID, categoryID int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM book_category WHERE book_id = ? AND category_id = ?", bookID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrCategoryNotLinked
+	}
+	return nil
+}
+
+// ReplaceCategories troca todas as categorias do livro pelas informadas, sem
+// repetições, em uma transação: ou o conjunto inteiro é gravado, ou nada muda.
+func (r *BookRepository) ReplaceCategories(ctx context.Context, bookID int64, categoryIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var exists int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM books WHERE id = ?", bookID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists == 0 {
+		return ErrBookNotFound
+	}
+
+	if len(categoryIDs) > 0 {
+		params := make([]any, len(categoryIDs))
+		for i, id := range categoryIDs {
+			params[i] = id
+		}
+
+		var found int
+		query := "SELECT COUNT(*) FROM categories WHERE id IN (?" + strings.Repeat(", ?", len(categoryIDs)-1) + ")"
+		if err := tx.QueryRowContext(ctx, query, params...).Scan(&found); err != nil {
+			return err
+		}
+		if found != len(categoryIDs) {
+			return ErrCategoryNotFound
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM book_category WHERE book_id = ?", bookID); err != nil {
+		return err
+	}
+
+	for _, id := range categoryIDs {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO book_category (book_id, category_id) VALUES (?, ?)", bookID, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
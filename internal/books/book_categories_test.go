@@ -0,0 +1,99 @@
+package books_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+func TestBookRepository_Categories(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	categoryRepo := categories.NewCategoryRepository(db)
+	for _, name := range []string{"Backend", "Dados"} {
+		if err := categoryRepo.Create(ctx, &categories.Category{Name: name}); err != nil {
+			t.Fatalf("category: %v", err)
+		}
+	}
+
+	categoryIDs := func(bookID int64) []int64 {
+		t.Helper()
+
+		book, err := repo.GetById(ctx, bookID)
+		if err != nil {
+			t.Fatalf("erro ao buscar livro: %v", err)
+		}
+
+		ids := make([]int64, 0, len(book.Categories))
+		for _, c := range book.Categories {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+
+	if err := repo.RelationBookCategory(ctx, 1, 1); !errors.Is(err, books.ErrCategoryLinked) {
+		t.Errorf("relação repetida: esperava ErrCategoryLinked, recebeu %v", err)
+	}
+
+	// o INSERT de um pedido concorrente que passou pela verificação esbarra
+	// na chave única, e esse erro também é reconhecido
+	_, err := db.Exec("INSERT INTO book_category (book_id, category_id) VALUES (1, 1)")
+	if !database.IsDuplicateKey(err) {
+		t.Errorf("esperava violação de chave única, recebeu %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		bookID  int64
+		ids     []int64
+		want    []int64
+		wantErr error
+	}{
+		{name: "substitui o conjunto", bookID: 1, ids: []int64{2, 3}, want: []int64{2, 3}},
+		{name: "categoria inexistente não altera nada", bookID: 1, ids: []int64{1, 99}, want: []int64{2, 3}, wantErr: books.ErrCategoryNotFound},
+		{name: "lista vazia remove todas", bookID: 1, ids: []int64{}, want: []int64{}},
+		{name: "livro inexistente", bookID: 99, ids: []int64{1}, wantErr: books.ErrBookNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.ReplaceCategories(ctx, tt.bookID, tt.ids)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+			if tt.want == nil {
+				return
+			}
+
+			got := categoryIDs(tt.bookID)
+			if len(got) != len(tt.want) {
+				t.Fatalf("esperava %v, recebeu %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("esperava %v, recebeu %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	if err := repo.RemoveCategory(ctx, 2, 1); err != nil {
+		t.Fatalf("erro ao remover categoria: %v", err)
+	}
+	if got := categoryIDs(2); len(got) != 0 {
+		t.Errorf("esperava livro sem categorias, recebeu %v", got)
+	}
+	if err := repo.RemoveCategory(ctx, 2, 1); !errors.Is(err, books.ErrCategoryNotLinked) {
+		t.Errorf("esperava ErrCategoryNotLinked, recebeu %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO book_category (book_id, category_id) VALUES (1, 2), (1, 2)"); err == nil {
+		t.Errorf("esperava a restrição única no par livro e categoria")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/publishers"
 )
 
 // @Description Dados necessários para criar um livro
@@ -13,7 +15,26 @@ type BookRequest struct {
 	Title       string `json:"title" binding:"required" example:"A menina e o porquinho"`
 	Description string `json:"description" binding:"required" example:"Esse livro é sobre conteudo infantil"`
 	Content     string `json:"content" binding:"required" example:"A menina é o porquinho"`
-	AuthorID    int64  `json:"author_id" binding:"required" example:"1"`
+	AuthorID    int64  `json:"author_id" binding:"required_without=Contributors" example:"1"`
+	ISBN10      string `json:"isbn_10" example:"0-306-40615-2"`
+	ISBN13      string `json:"isbn_13" example:"978-0-306-40615-7"`
+
+	PublisherID     int64  `json:"publisher_id" example:"1"`
+	Edition         int    `json:"edition" binding:"min=0" example:"2"`
+	Language        string `json:"language" example:"pt"`
+	PublicationYear int    `json:"publication_year" binding:"min=0" example:"1998"`
+	Pages           int    `json:"pages" binding:"min=0" example:"320"`
+	Format          Format `json:"format" binding:"omitempty,oneof=hardcover paperback ebook audiobook other" example:"paperback"`
+
+	CallNumber string `json:"call_number" example:"869.3 L716m"`
+
+	Contributors []ContributorRequest `json:"contributors" binding:"omitempty,dive"`
+}
+
+// @Description Colaborador do livro. A ordem da lista define a posição de exibição.
+type ContributorRequest struct {
+	AuthorID int64           `json:"author_id" binding:"required" example:"1"`
+	Role     ContributorRole `json:"role" binding:"omitempty,oneof=author editor translator illustrator" example:"translator"`
 }
 
 // @Description Dados necessários pra fazer o relacionamento
@@ -22,6 +43,11 @@ type BookCategoryRequest struct {
 	CategoryID int64 `json:"category_id" binding:"required" example:"1"`
 }
 
+// @Description Conjunto completo de categorias do livro
+type BookCategoriesRequest struct {
+	CategoryIDs []int64 `json:"category_ids" binding:"required" example:"1,2"`
+}
+
 type BookResponse struct {
 	ID          int64                         `json:"id"`
 	Title       string                        `json:"title"`
@@ -32,6 +58,112 @@ type BookResponse struct {
 	Categories  []categories.CategoryResponse `json:"categories"`
 	AuthorID    int64                         `json:"author_id"`
 	Authors     authors.AuthorResponse        `json:"author"`
+	ISBN10      string                        `json:"isbn_10"`
+	ISBN13      string                        `json:"isbn_13"`
+
+	PublisherID     int64                        `json:"publisher_id"`
+	Publisher       publishers.PublisherResponse `json:"publisher"`
+	Edition         int                          `json:"edition"`
+	Language        string                       `json:"language"`
+	PublicationYear int                          `json:"publication_year"`
+	Pages           int                          `json:"pages"`
+	Format          Format                       `json:"format"`
+
+	CallNumber string `json:"call_number"`
+
+	SeriesID         int64  `json:"series_id"`
+	SeriesName       string `json:"series_name"`
+	SeriesVolume     int    `json:"series_volume"`
+	PreviousVolumeID int64  `json:"previous_volume_id"`
+	NextVolumeID     int64  `json:"next_volume_id"`
+
+	CoverURL          string `json:"cover_url"`
+	CoverThumbnailURL string `json:"cover_thumbnail_url"`
+
+	Contributors []ContributorResponse `json:"contributors"`
+
+	TotalCopies     int `json:"total_copies"`
+	AvailableCopies int `json:"available_copies"`
+}
+
+// BookListResponse é o envelope de paginação acrescido das facetas.
+type BookListResponse struct {
+	pagination.Page[BookResponse]
+	Facets FacetsResponse `json:"facets"`
+}
+
+type FacetValueResponse struct {
+	ID    int64  `json:"id,omitempty"`
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type FacetsResponse struct {
+	Categories []FacetValueResponse `json:"categories"`
+	Authors    []FacetValueResponse `json:"authors"`
+	Publishers []FacetValueResponse `json:"publishers"`
+	Languages  []FacetValueResponse `json:"languages"`
+	Formats    []FacetValueResponse `json:"formats"`
+	Years      []FacetValueResponse `json:"years"`
+}
+
+func toFacetValues(values []FacetValue) []FacetValueResponse {
+	resp := make([]FacetValueResponse, 0, len(values))
+	for _, v := range values {
+		resp = append(resp, FacetValueResponse(v))
+	}
+	return resp
+}
+
+func ToFacetsResponse(f *Facets) FacetsResponse {
+	return FacetsResponse{
+		Categories: toFacetValues(f.Categories),
+		Authors:    toFacetValues(f.Authors),
+		Publishers: toFacetValues(f.Publishers),
+		Languages:  toFacetValues(f.Languages),
+		Formats:    toFacetValues(f.Formats),
+		Years:      toFacetValues(f.Years),
+	}
+}
+
+type BookSearchResponse struct {
+	Query          string         `json:"query"`
+	CorrectedQuery string         `json:"corrected_query,omitempty"`
+	Suggestions    []string       `json:"suggestions"`
+	Total          int            `json:"total"`
+	Results        []BookResponse `json:"results"`
+}
+
+type ContributorResponse struct {
+	AuthorID int64           `json:"author_id"`
+	Name     string          `json:"name"`
+	Role     ContributorRole `json:"role"`
+	Position int             `json:"position"`
+}
+
+func toContributorResponse(contributors []Contributor) []ContributorResponse {
+	resp := make([]ContributorResponse, 0, len(contributors))
+	for _, c := range contributors {
+		resp = append(resp, ContributorResponse{
+			AuthorID: c.AuthorID,
+			Name:     c.Name,
+			Role:     c.Role,
+			Position: c.Position,
+		})
+	}
+	return resp
+}
+
+func toContributors(req []ContributorRequest) []Contributor {
+	if len(req) == 0 {
+		return nil
+	}
+
+	contributors := make([]Contributor, 0, len(req))
+	for _, c := range req {
+		contributors = append(contributors, Contributor{AuthorID: c.AuthorID, Role: c.Role})
+	}
+	return contributors
 }
 
 func toCategoryResponse(cats []categories.Category) []categories.CategoryResponse {
@@ -61,5 +193,31 @@ func ToResponse(b *Books) BookResponse {
 		UpdatedAt:   formatTime(b.UpdatedAt),
 		Authors:     authors.ToResponse(&b.Authors),
 		Categories:  toCategoryResponse(b.Categories),
+		ISBN10:      b.ISBN10,
+		ISBN13:      b.ISBN13,
+
+		PublisherID:     b.PublisherID,
+		Publisher:       publishers.ToResponse(&b.Publisher),
+		Edition:         b.Edition,
+		Language:        b.Language,
+		PublicationYear: b.PublicationYear,
+		Pages:           b.Pages,
+		Format:          b.Format,
+
+		CallNumber: b.CallNumber,
+
+		SeriesID:         b.SeriesID,
+		SeriesName:       b.SeriesName,
+		SeriesVolume:     b.SeriesVolume,
+		PreviousVolumeID: b.PreviousVolumeID,
+		NextVolumeID:     b.NextVolumeID,
+
+		CoverURL:          b.CoverURL,
+		CoverThumbnailURL: b.CoverThumbnailURL,
+
+		Contributors: toContributorResponse(b.Contributors),
+
+		TotalCopies:     b.TotalCopies,
+		AvailableCopies: b.AvailableCopies,
 	}
 }
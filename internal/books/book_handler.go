@@ -2,11 +2,14 @@ package books
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/callnumber"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -20,14 +23,43 @@ func NewBookHandler(svc BookServcie, logApp *zap.Logger) *BookHandler {
 	return &BookHandler{service: svc, logApp: logApp}
 }
 
+// queryInt lê um parâmetro numérico opcional da query string.
+func queryInt(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func bookError(err error) error {
+	switch {
+	case errors.Is(err, ErrBookNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrCategoryNotLinked):
+		return middleware.NotFound.Messager(err.Error())
+	case errors.Is(err, ErrInvalidISBN), errors.Is(err, ErrISBNMismatch),
+		errors.Is(err, ErrInvalidContributor), errors.Is(err, ErrDuplicateContributor),
+		errors.Is(err, ErrInvalidLanguage), errors.Is(err, ErrInvalidFormat), errors.Is(err, ErrInvalidEdition),
+		errors.Is(err, ErrEmptyQuery), errors.Is(err, callnumber.ErrInvalid):
+		return middleware.BadRequest.Messager(err.Error())
+	case errors.Is(err, ErrDuplicateISBN), errors.Is(err, ErrCategoryLinked):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
 // @Summary Cria um novo livro
 // @Description Recebe um objeto JSON BookRequest e salva o livro no banco de dados.
+// @Description Sem contributors, o author_id é gravado como único autor do livro.
 // @Tags books
 // @Accept  json
 // @Produce json
 // @Param   book body BookRequest true "Dados do Novo Livro a ser criado"
 // @Success 201 {object} BookRequest "Livro criado com sucesso"
-// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado, campo obrigatório ausente ou ISBN inválido)"
+// @Failure 409 {object} middleware.APIError "ISBN já cadastrado"
 // @Failure 500 {object} middleware.APIError "Erro interno do servidor"
 // @Security ApiKeyAuth
 // @Router /api/books [post]
@@ -49,11 +81,24 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 		Description: bDtoReq.Description,
 		Content:     bDtoReq.Content,
 		AuthorID:    bDtoReq.AuthorID,
+		ISBN10:      bDtoReq.ISBN10,
+		ISBN13:      bDtoReq.ISBN13,
+
+		PublisherID:     bDtoReq.PublisherID,
+		Edition:         bDtoReq.Edition,
+		Language:        bDtoReq.Language,
+		PublicationYear: bDtoReq.PublicationYear,
+		Pages:           bDtoReq.Pages,
+		Format:          bDtoReq.Format,
+
+		CallNumber: bDtoReq.CallNumber,
+
+		Contributors: toContributors(bDtoReq.Contributors),
 	}
 
 	if err := h.service.Create(ctx, newBook); err != nil {
 		h.logApp.Error("falha ao criar livro", zap.Error(err))
-		_ = c.Error(middleware.InternalErr)
+		_ = c.Error(bookError(err))
 		return
 	}
 
@@ -62,14 +107,32 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 
 // @Summary Listar livros
 // @Description Retorna uma lista de livros filtrando por título, autor e categoria.
+// @Description As facetas contam os livros por categoria, autor, editora, idioma, formato e ano sob os mesmos filtros, sem paginação.
 // @Tags books
 // @Accept json
 // @Produce json
-// @Param page query int true "Página"
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 10, máximo 100)"
+// @Param sort query string false "Campo de ordenação" Enums(id, title, publication_year, pages, created_at, call_number)
+// @Param order query string false "Direção da ordenação" Enums(asc, desc)
+// @Param cursor query string false "Cursor de next_cursor ou prev_cursor; substitui page, sort e order"
 // @Param title query string false "Filtrar por título"
 // @Param author query string false "Filtrar por autor"
 // @Param category query string false "Filtrar por categoria"
-// @Success 200 {array} BookResponse
+// @Param include_subcategories query bool false "Inclui no filtro de categoria as subcategorias"
+// @Param contributor query string false "Filtrar pelo nome de qualquer colaborador"
+// @Param contributor_role query string false "Restringe o filtro de colaborador ao papel (author, editor, translator, illustrator)"
+// @Param publisher query string false "Filtrar por editora"
+// @Param edition query int false "Filtrar por número da edição"
+// @Param language query string false "Filtrar por idioma (ISO 639-1)"
+// @Param format query string false "Filtrar por formato (hardcover, paperback, ebook, audiobook, other)"
+// @Param year_from query int false "Ano de publicação mínimo"
+// @Param year_to query int false "Ano de publicação máximo"
+// @Param pages_min query int false "Número mínimo de páginas"
+// @Param pages_max query int false "Número máximo de páginas"
+// @Param call_number_from query string false "Início do intervalo de números de chamada, inclusive (ex.: 500)"
+// @Param call_number_to query string false "Fim do intervalo de números de chamada, inclusive, com as subdivisões (ex.: 599)"
+// @Success 200 {object} BookListResponse
 // @Failure 400 {object} middleware.APIError "Parâmetros inválidos"
 // @Failure 500 {object} middleware.APIError "Erro interno"
 // @Router /public/api/books [get]
@@ -79,39 +142,105 @@ func (h *BookHandler) ReadAllBooks(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*3)
 	defer cancel()
 
-	pagePar := c.Query("page")
 	title := c.Query("title")
 	author := c.Query("author")
 	category := c.Query("category")
+	contributor := c.Query("contributor")
+	contributorRole := ContributorRole(c.Query("contributor_role"))
 
-	page := 0
-	if pagePar != "" {
-		var err error
-		page, err = strconv.Atoi(pagePar)
+	if contributorRole != "" && !contributorRole.Valid() {
+		h.logApp.Error("papel de colaborador invalido", zap.String("role", string(contributorRole)))
+		_ = c.Error(middleware.BadRequest.Messager(ErrInvalidContributor.Error()))
+		return
+	}
+
+	page, err := pagination.Parse(c.Request.URL.Query(), BookSorts)
+	if err != nil {
+		h.logApp.Error("falha ao ler paginação", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	numbers := make(map[string]int)
+	for _, name := range []string{"edition", "year_from", "year_to", "pages_min", "pages_max"} {
+		value, err := queryInt(c, name)
 		if err != nil {
-			h.logApp.Error("falha ao converter page", zap.Error(err))
+			h.logApp.Error("falha ao converter "+name, zap.Error(err))
+			_ = c.Error(middleware.BadRequest)
+			return
+		}
+		numbers[name] = value
+	}
+
+	includeSubcategories := false
+	if value := c.Query("include_subcategories"); value != "" {
+		if includeSubcategories, err = strconv.ParseBool(value); err != nil {
+			h.logApp.Error("falha ao converter include_subcategories", zap.Error(err))
 			_ = c.Error(middleware.BadRequest)
 			return
 		}
 	}
 
+	callNumbers, err := callnumber.NewRange(c.Query("call_number_from"), c.Query("call_number_to"))
+	if err != nil {
+		h.logApp.Error("intervalo de números de chamada invalido", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	format := Format(c.Query("format"))
+	if format != "" && !format.Valid() {
+		h.logApp.Error("formato invalido", zap.String("format", string(format)))
+		_ = c.Error(middleware.BadRequest.Messager(ErrInvalidFormat.Error()))
+		return
+	}
+
 	filter := &Filters{
-		Page:     int(page),
 		Title:    title,
 		Authors:  author,
 		Category: category,
+
+		IncludeSubcategories: includeSubcategories,
+
+		Pagination: page,
+
+		Contributor:     contributor,
+		ContributorRole: contributorRole,
+
+		Publisher: c.Query("publisher"),
+		Edition:   numbers["edition"],
+		Language:  c.Query("language"),
+		Format:    format,
+		YearFrom:  numbers["year_from"],
+		YearTo:    numbers["year_to"],
+		PagesMin:  numbers["pages_min"],
+		PagesMax:  numbers["pages_max"],
+
+		CallNumbers: callNumbers,
 	}
 
-	books, err := h.service.GetAll(ctx, filter)
+	books, meta, err := h.service.GetAll(ctx, filter)
 	if err != nil {
 		h.logApp.Error("falha ao obter livros", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
 		return
 	}
 
-	response := make([]BookResponse, 0, len(books))
+	facets, err := h.service.Facets(ctx, filter)
+	if err != nil {
+		h.logApp.Error("falha ao obter facetas", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	results := make([]BookResponse, 0, len(books))
 	for _, book := range books {
-		response = append(response, ToResponse(&book))
+		results = append(results, ToResponse(&book))
+	}
+
+	response := BookListResponse{
+		Page:   pagination.NewPage(results, meta, page),
+		Facets: ToFacetsResponse(facets),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -144,13 +273,91 @@ func (h *BookHandler) ReadBook(c *gin.Context) {
 	book, err := h.service.GetById(ctx, id)
 	if err != nil {
 		h.logApp.Error("falha ao obter livro", zap.Error(err))
-		_ = c.Error(middleware.NotFound)
+		_ = c.Error(bookError(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, ToResponse(book))
 }
 
+// @Summary Obter livro pelo ISBN
+// @Description Retorna o livro com o ISBN informado. Aceita ISBN-10 ou ISBN-13, com ou sem hífens.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param isbn path string true "ISBN-10 ou ISBN-13"
+// @Success 200 {object} BookResponse
+// @Failure 400 {object} middleware.APIError "ISBN inválido"
+// @Failure 404 {object} middleware.APIError "Livro não encontrado"
+// @Failure 500 {object}	middleware.APIError "Erro interno"
+// @Router /public/api/books/isbn/{isbn} [get]
+func (h *BookHandler) ReadBookByISBN(c *gin.Context) {
+	h.logApp.Info("Rota de livro por isbn")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*3)
+	defer cancel()
+
+	book, err := h.service.GetByISBN(ctx, c.Param("isbn"))
+	if err != nil {
+		h.logApp.Error("falha ao obter livro por isbn", zap.Error(err))
+		_ = c.Error(bookError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(book))
+}
+
+// @Summary Busca livros
+// @Description Busca textual no título, descrição, conteúdo e nome dos autores, em ordem de relevância, sem diferenciar acentos nem maiúsculas.
+// @Description Sem resultados, sugere correções ("você quis dizer") e traz os livros da primeira sugestão em corrected_query.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param q query string true "Termos da busca"
+// @Param limit query int false "Máximo de resultados (padrão 20, máximo 50)"
+// @Success 200 {object} BookSearchResponse
+// @Failure 400 {object} middleware.APIError "Busca sem termos"
+// @Failure 500 {object}	middleware.APIError "Erro interno"
+// @Router /public/api/books/search [get]
+func (h *BookHandler) SearchBooks(c *gin.Context) {
+	h.logApp.Info("Rota de busca de livros")
+
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		h.logApp.Error("falha ao converter limit", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*3)
+	defer cancel()
+
+	query := c.Query("q")
+
+	result, err := h.service.Search(ctx, query, limit)
+	if err != nil {
+		h.logApp.Error("falha ao buscar livros", zap.Error(err))
+		_ = c.Error(bookError(err))
+		return
+	}
+
+	response := BookSearchResponse{
+		Query:          query,
+		CorrectedQuery: result.CorrectedQuery,
+		Suggestions:    result.Suggestions,
+		Total:          result.Total,
+		Results:        make([]BookResponse, 0, len(result.Books)),
+	}
+	if response.Suggestions == nil {
+		response.Suggestions = []string{}
+	}
+	for _, b := range result.Books {
+		response.Results = append(response.Results, ToResponse(&b))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // @Summary Atualiza um livro
 // @Description Recebe um objeto JSON BookRequest e atualiza o livro no banco de dados.
 // @Tags books
@@ -159,7 +366,9 @@ func (h *BookHandler) ReadBook(c *gin.Context) {
 // @Param id path int true "Recebe o id do livro"
 // @Param   book body BookRequest true "Dados do Novo Livro a ser atualizado"
 // @Success 200 {object} BookRequest "Livro atualizado com sucesso"
-// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado, campo obrigatório ausente ou ISBN inválido)"
+// @Failure 404 {object} middleware.APIError "Livro não encontrado"
+// @Failure 409 {object} middleware.APIError "ISBN já cadastrado"
 // @Failure 500 {object} middleware.APIError "Erro interno do servidor"
 // @Security ApiKeyAuth
 // @Router /api/books/{id} [put]
@@ -187,11 +396,24 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 		Description: dtoReq.Description,
 		Content:     dtoReq.Content,
 		AuthorID:    dtoReq.AuthorID,
+		ISBN10:      dtoReq.ISBN10,
+		ISBN13:      dtoReq.ISBN13,
+
+		PublisherID:     dtoReq.PublisherID,
+		Edition:         dtoReq.Edition,
+		Language:        dtoReq.Language,
+		PublicationYear: dtoReq.PublicationYear,
+		Pages:           dtoReq.Pages,
+		Format:          dtoReq.Format,
+
+		CallNumber: dtoReq.CallNumber,
+
+		Contributors: toContributors(dtoReq.Contributors),
 	}
 
 	if err := h.service.Update(c.Request.Context(), updateBook); err != nil {
 		h.logApp.Error("falha ao atualizar livro", zap.Error(err))
-		_ = c.Error(middleware.InternalErr)
+		_ = c.Error(bookError(err))
 		return
 	}
 
@@ -237,6 +459,7 @@ func (h *BookHandler) DeleteBook(c *gin.Context) {
 // @Param   data body BookCategoryRequest true "IDs do Livro e da Categoria a serem relacionados"
 // @Success 200 "OK, Relação criada com sucesso"
 // @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado, campo ausente ou tipo errado)"
+// @Failure 409 {object} middleware.APIError "Categoria já associada ao livro"
 // @Failure 500 {object} middleware.APIError "Erro interno do servidor (Erro na criação da relação)"
 // @Router /api/books/relation [post]
 func (h *BookHandler) RelationBookCategory(c *gin.Context) {
@@ -252,9 +475,95 @@ func (h *BookHandler) RelationBookCategory(c *gin.Context) {
 
 	if err := h.service.RelationBookCategory(ctx, bcDtoReq.BookID, bcDtoReq.CategoryID); err != nil {
 		h.logApp.Error("falha ao fazer relacionamento", zap.Error(err))
-		_ = c.Error(middleware.InternalErr)
+		_ = c.Error(bookError(err))
 		return
 	}
 
 	c.Status(http.StatusOK)
 }
+
+// @Summary Substitui as categorias de um livro
+// @Description Troca todas as categorias do livro pelas informadas, em uma única transação. Uma lista vazia remove todas.
+// @Tags books
+// @Accept  json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do livro"
+// @Param data body BookCategoriesRequest true "IDs das categorias"
+// @Success 200 {object} BookResponse
+// @Failure 400 {object} middleware.APIError "Requisição inválida"
+// @Failure 404 {object} middleware.APIError "Livro ou categoria não encontrados"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /api/books/{id}/categories [put]
+func (h *BookHandler) ReplaceCategories(c *gin.Context) {
+	h.logApp.Info("Rota de substituir categorias do livro")
+	ctx := c.Request.Context()
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	var dtoReq BookCategoriesRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	if err := h.service.ReplaceCategories(ctx, id, dtoReq.CategoryIDs); err != nil {
+		h.logApp.Error("falha ao substituir categorias", zap.Error(err))
+		_ = c.Error(bookError(err))
+		return
+	}
+
+	book, err := h.service.GetById(ctx, id)
+	if err != nil {
+		h.logApp.Error("falha ao obter livro", zap.Error(err))
+		_ = c.Error(bookError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(book))
+}
+
+// @Summary Remove uma categoria do livro
+// @Description Desfaz a associação entre o livro e a categoria.
+// @Tags books
+// @Accept  json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do livro"
+// @Param categoryId path int true "ID da categoria"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Categoria não associada ao livro"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /api/books/{id}/categories/{categoryId} [delete]
+func (h *BookHandler) RemoveCategory(c *gin.Context) {
+	h.logApp.Info("Rota de remover categoria do livro")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	categoryID, err := middleware.GetIdParamByName(c, "categoryId")
+	if err != nil {
+		h.logApp.Error("falha ao verificar id da categoria", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.service.RemoveCategory(c.Request.Context(), id, categoryID); err != nil {
+		h.logApp.Error("falha ao remover categoria do livro", zap.Error(err))
+		_ = c.Error(bookError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -43,6 +44,16 @@ func TestBookHandler_ReadAllBooks(t *testing.T) {
 			mockErr:        nil,
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "sort_fora_da_lista",
+			queryParams:    "?sort=content",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "order_invalido",
+			queryParams:    "?sort=title&order=up",
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -53,7 +64,10 @@ func TestBookHandler_ReadAllBooks(t *testing.T) {
 			if tt.expectedStatus != http.StatusInternalServerError || tt.name != "page_invalida" {
 				mockSvc.
 					On("GetAll", mock.Anything, mock.Anything).
-					Return(tt.mockReturn, tt.mockErr)
+					Return(tt.mockReturn, pagination.Meta{Total: len(tt.mockReturn)}, tt.mockErr)
+				mockSvc.
+					On("Facets", mock.Anything, mock.Anything).
+					Return(&Facets{}, nil)
 			}
 
 			router, rec := setupTest(mockSvc)
@@ -86,14 +100,23 @@ func TestBookHandler_GetBookByID(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name:    "erro_do_service_retorna_not_found",
+			name:    "livro_inexistente_retorna_not_found",
 			idParam: "2",
 			mockSetup: func(m *MockBookRepo) {
 				m.On("GetById", mock.Anything, int64(2)).
-					Return(nil, errors.New("qualquer erro"))
+					Return(nil, ErrBookNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:    "erro_do_service_retorna_erro_interno",
+			idParam: "3",
+			mockSetup: func(m *MockBookRepo) {
+				m.On("GetById", mock.Anything, int64(3)).
+					Return(nil, errors.New("qualquer erro"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
 		{
 			name:           "id_invalido",
 			idParam:        "abc",
@@ -148,6 +171,15 @@ func TestBookHandler_RelationBookCategory(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name: "relacao_repetida",
+			body: `{"book_id":1, "category_id":2}`,
+			mockSetup: func(m *MockBookRepo) {
+				m.On("RelationBookCategory", mock.Anything, int64(1), int64(2)).
+					Return(ErrCategoryLinked)
+			},
+			expectedStatus: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -166,3 +198,147 @@ func TestBookHandler_RelationBookCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestBookHandler_ReadBookByISBN(t *testing.T) {
+	tests := []struct {
+		name           string
+		isbn           string
+		mockSetup      func(m *MockBookRepo)
+		expectedStatus int
+	}{
+		{
+			name: "sucesso_buscar_por_isbn",
+			isbn: "978-0-306-40615-7",
+			mockSetup: func(m *MockBookRepo) {
+				m.On("GetByISBN", mock.Anything, "978-0-306-40615-7").
+					Return(&Books{ID: 1, Title: "Go", ISBN13: "9780306406157"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "isbn_invalido",
+			isbn: "123",
+			mockSetup: func(m *MockBookRepo) {
+				m.On("GetByISBN", mock.Anything, "123").Return(nil, ErrInvalidISBN)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "livro_nao_encontrado",
+			isbn: "0306406152",
+			mockSetup: func(m *MockBookRepo) {
+				m.On("GetByISBN", mock.Anything, "0306406152").Return(nil, ErrBookNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockBookRepo)
+			router, w := setupTest(mockSvc)
+
+			tt.mockSetup(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/books/isbn/"+tt.isbn, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookHandler_BookCategories(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		mockSetup      func(m *MockBookRepo)
+		expectedStatus int
+	}{
+		{
+			name:   "substituir_categorias",
+			method: http.MethodPut,
+			path:   "/api/books/1/categories",
+			body:   `{"category_ids":[2,3]}`,
+			mockSetup: func(m *MockBookRepo) {
+				m.On("ReplaceCategories", mock.Anything, int64(1), []int64{2, 3}).Return(nil)
+				m.On("GetById", mock.Anything, int64(1)).Return(&Books{ID: 1, Title: "Go"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "limpar_categorias",
+			method: http.MethodPut,
+			path:   "/api/books/1/categories",
+			body:   `{"category_ids":[]}`,
+			mockSetup: func(m *MockBookRepo) {
+				m.On("ReplaceCategories", mock.Anything, int64(1), []int64{}).Return(nil)
+				m.On("GetById", mock.Anything, int64(1)).Return(&Books{ID: 1, Title: "Go"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "lista_ausente",
+			method:         http.MethodPut,
+			path:           "/api/books/1/categories",
+			body:           `{}`,
+			mockSetup:      func(m *MockBookRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "categoria_inexistente",
+			method: http.MethodPut,
+			path:   "/api/books/1/categories",
+			body:   `{"category_ids":[9]}`,
+			mockSetup: func(m *MockBookRepo) {
+				m.On("ReplaceCategories", mock.Anything, int64(1), []int64{9}).Return(ErrCategoryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "remover_categoria",
+			method: http.MethodDelete,
+			path:   "/api/books/1/categories/2",
+			mockSetup: func(m *MockBookRepo) {
+				m.On("RemoveCategory", mock.Anything, int64(1), int64(2)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "remover_categoria_nao_associada",
+			method: http.MethodDelete,
+			path:   "/api/books/1/categories/5",
+			mockSetup: func(m *MockBookRepo) {
+				m.On("RemoveCategory", mock.Anything, int64(1), int64(5)).Return(ErrCategoryNotLinked)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "id_da_categoria_invalido",
+			method:         http.MethodDelete,
+			path:           "/api/books/1/categories/abc",
+			mockSetup:      func(m *MockBookRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockBookRepo)
+			tt.mockSetup(mockSvc)
+
+			router, rec := setupTest(mockSvc)
+
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
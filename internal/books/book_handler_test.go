@@ -107,6 +107,16 @@ func TestBookHandler_UpdateBook(t *testing.T) {
 			status: http.StatusInternalServerError,
 			body:   `{"code":"INTERNAL_ERROR","message":"Internal error occurred.","path":"/api/books/1","status":500}`,
 		},
+		{
+			name:    "livro inexistente Return 404 NotFound",
+			bookID:  "99",
+			reqBody: updateBookRequest,
+			setupMock: func(b *MockBookRepo) {
+				b.On("Update", mock.Anything, mock.Anything).Return(ErrBookNotFound).Once()
+			},
+			status: http.StatusNotFound,
+			body:   `{"code":"NOT_FOUND","message":"Resource not found.","path":"/api/books/99","status":404}`,
+		},
 		{
 			name:    "InvalidJson Return 400 BadRequest",
 			bookID:  "1",
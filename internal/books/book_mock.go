@@ -3,6 +3,8 @@ package books
 import (
 	"context"
 
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -21,9 +23,27 @@ func (m *MockBookRepo) RelationBookCategory(ctx context.Context, bookID, catID i
 	return args.Error(0)
 }
 
-func (m *MockBookRepo) GetAll(ctx context.Context, filter *Filters) ([]Books, error) {
+func (m *MockBookRepo) RemoveCategory(ctx context.Context, bookID, catID int64) error {
+	args := m.Called(ctx, bookID, catID)
+	return args.Error(0)
+}
+
+func (m *MockBookRepo) ReplaceCategories(ctx context.Context, bookID int64, categoryIDs []int64) error {
+	args := m.Called(ctx, bookID, categoryIDs)
+	return args.Error(0)
+}
+
+func (m *MockBookRepo) GetAll(ctx context.Context, filter *Filters) ([]Books, pagination.Meta, error) {
 	args := m.Called(ctx, filter)
-	return args.Get(0).([]Books), args.Error(1)
+	return args.Get(0).([]Books), args.Get(1).(pagination.Meta), args.Error(2)
+}
+
+func (m *MockBookRepo) Facets(ctx context.Context, filter *Filters) (*Facets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Facets), args.Error(1)
 }
 
 func (m *MockBookRepo) GetById(ctx context.Context, id int64) (*Books, error) {
@@ -40,6 +60,46 @@ func (m *MockBookRepo) GetById(ctx context.Context, id int64) (*Books, error) {
 	return book, args.Error(1)
 }
 
+func (m *MockBookRepo) GetByIds(ctx context.Context, ids []int64) ([]Books, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Books), args.Error(1)
+}
+
+func (m *MockBookRepo) GetIdByISBN(ctx context.Context, isbn13 string) (int64, error) {
+	args := m.Called(ctx, isbn13)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBookRepo) GetByISBN(ctx context.Context, isbn string) (*Books, error) {
+	args := m.Called(ctx, isbn)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Books), args.Error(1)
+}
+
+func (m *MockBookRepo) SearchIDs(ctx context.Context, query string, limit int) ([]int64, int, error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]int64), args.Int(1), args.Error(2)
+}
+
+func (m *MockBookRepo) Search(ctx context.Context, query string, limit int) (*SearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SearchResult), args.Error(1)
+}
+
+func (m *MockBookRepo) Vocabulary(ctx context.Context) (textsearch.Vocabulary, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(textsearch.Vocabulary), args.Error(1)
+}
+
 func (m *MockBookRepo) Update(ctx context.Context, b *Books) error {
 	args := m.Called(ctx, b)
 	return args.Error(0)
@@ -4,20 +4,38 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 )
 
 type BookRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	mysql bool
 }
 
 func NewBookRepository(db *sql.DB) *BookRepository {
-	return &BookRepository{db: db}
+	return &BookRepository{db: db, mysql: isMySQL(db)}
 }
 
 func (r *BookRepository) Create(ctx context.Context, b *Books) error {
-	query := "INSERT INTO books (title, description, content, author_id) VALUES (?, ?, ?, ?)"
-
-	result, err := r.db.ExecContext(ctx, query, b.Title, b.Description, b.Content, b.AuthorID)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `INSERT INTO books (title, description, content, author_id, isbn_10, isbn_13,
+		publisher_id, edition, language, publication_year, pages, format, title_search,
+		call_number, call_number_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := tx.ExecContext(ctx, query, b.Title, b.Description, b.Content, b.AuthorID,
+		nullString(b.ISBN10), nullString(b.ISBN13),
+		nullInt(b.PublisherID), nullInt(int64(b.Edition)), nullString(b.Language),
+		nullInt(int64(b.PublicationYear)), nullInt(int64(b.Pages)), nullString(string(b.Format)),
+		textsearch.Fold(b.Title), nullString(b.CallNumber), callNumberKey(b.CallNumber))
 	if err != nil {
 		return err
 	}
@@ -28,13 +46,33 @@ func (r *BookRepository) Create(ctx context.Context, b *Books) error {
 	}
 
 	b.ID = id
-	return nil
+
+	if err := saveContributors(ctx, tx, b); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *BookRepository) Update(ctx context.Context, b *Books) error {
-	query := "UPDATE books SET title = ?, description = ?, content = ?, author_id = ? WHERE id = ?"
-
-	result, err := r.db.ExecContext(ctx, query, b.Title, b.Description, b.Content, b.AuthorID, b.ID)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `UPDATE books SET title = ?, description = ?, content = ?, author_id = ?, isbn_10 = ?, isbn_13 = ?,
+		publisher_id = ?, edition = ?, language = ?, publication_year = ?, pages = ?, format = ?, title_search = ?,
+		call_number = ?, call_number_key = ?
+		WHERE id = ?`
+
+	result, err := tx.ExecContext(ctx, query, b.Title, b.Description, b.Content, b.AuthorID,
+		nullString(b.ISBN10), nullString(b.ISBN13),
+		nullInt(b.PublisherID), nullInt(int64(b.Edition)), nullString(b.Language),
+		nullInt(int64(b.PublicationYear)), nullInt(int64(b.Pages)), nullString(string(b.Format)),
+		textsearch.Fold(b.Title), nullString(b.CallNumber), callNumberKey(b.CallNumber), b.ID)
 	if err != nil {
 		return err
 	}
@@ -45,9 +83,14 @@ func (r *BookRepository) Update(ctx context.Context, b *Books) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("erro ao atualizar livro")
+		return ErrBookNotFound
 	}
-	return nil
+
+	if err := saveContributors(ctx, tx, b); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *BookRepository) Delete(ctx context.Context, id int64) error {
@@ -67,13 +110,22 @@ func (r *BookRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (r *BookRepository) RelationBookCategory(ctx context.Context, book_id, cat_id int64) error {
-	query := "INSERT INTO book_category (book_id, category_id) VALUES (?, ?)"
+func (r *BookRepository) GetIdByISBN(ctx context.Context, isbn13 string) (int64, error) {
+	var id int64
 
-	_, err := r.db.ExecContext(ctx, query, book_id, cat_id)
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM books WHERE isbn_13 = ?", isbn13).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrBookNotFound
+	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return id, nil
+}
+
+// nullString grava campos opcionais como NULL, permitindo vários livros sem
+// ISBN apesar do índice único.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
@@ -158,8 +158,8 @@ func TestBookRepository_Update(t *testing.T) {
 			err := r.Update(ctx, tt.update)
 
 			if tt.wantErr {
-				if err == nil {
-					t.Fatalf("esperava erro, mas não ocorreu: %v", err)
+				if !errors.Is(err, books.ErrBookNotFound) {
+					t.Fatalf("esperava ErrBookNotFound, recebeu: %v", err)
 				}
 				return
 			}
@@ -256,3 +256,38 @@ func TestBookRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestBookRepository_GetIdByISBN(t *testing.T) {
+	db := database.SetupTestDB()
+	r := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	if err := authors.NewAuthorsRepository(db).Create(ctx, &authors.Authors{Name: "Teste"}); err != nil {
+		t.Fatalf("erro ao criar author: %v", err)
+	}
+
+	withISBN := &books.Books{Title: "Com ISBN", Description: "D", Content: "C", AuthorID: 1, ISBN10: "0306406152", ISBN13: "9780306406157"}
+	if err := r.Create(ctx, withISBN); err != nil {
+		t.Fatalf("erro ao criar livro: %v", err)
+	}
+
+	for _, title := range []string{"Sem ISBN 1", "Sem ISBN 2"} {
+		if err := r.Create(ctx, &books.Books{Title: title, Description: "D", Content: "C", AuthorID: 1}); err != nil {
+			t.Fatalf("livros sem isbn não devem conflitar: %v", err)
+		}
+	}
+
+	duplicate := &books.Books{Title: "Duplicado", Description: "D", Content: "C", AuthorID: 1, ISBN13: "9780306406157"}
+	if err := r.Create(ctx, duplicate); err == nil {
+		t.Errorf("esperava erro de isbn duplicado")
+	}
+
+	id, err := r.GetIdByISBN(ctx, "9780306406157")
+	if err != nil || id != withISBN.ID {
+		t.Errorf("esperava id %d, recebeu %d (%v)", withISBN.ID, id, err)
+	}
+
+	if _, err := r.GetIdByISBN(ctx, "9791090636071"); !errors.Is(err, books.ErrBookNotFound) {
+		t.Errorf("esperava ErrBookNotFound, recebeu %v", err)
+	}
+}
@@ -2,19 +2,27 @@ package books
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 )
 
 type BookServcie interface {
 	BookCreator
 	BookRead
+	GetByISBN(ctx context.Context, isbn string) (*Books, error)
+	Search(ctx context.Context, query string, limit int) (*SearchResult, error)
 }
 
 type serviceBook struct {
-	book IBookRepository
+	book       IBookRepository
+	vocabulary *vocabularyCache
 }
 
 func NewBookService(b IBookRepository) *serviceBook {
-	return &serviceBook{book: b}
+	return &serviceBook{book: b, vocabulary: &vocabularyCache{now: time.Now}}
 }
 
 func (s *serviceBook) Create(ctx context.Context, b *Books) error {
@@ -23,22 +31,141 @@ func (s *serviceBook) Create(ctx context.Context, b *Books) error {
 		return err
 	}
 
+	if err := s.checkISBN(ctx, b); err != nil {
+		return err
+	}
+
 	return s.book.Create(ctx, b)
 }
 
-func (s *serviceBook) GetAll(ctx context.Context, filter *Filters) ([]Books, error) {
+func (s *serviceBook) GetAll(ctx context.Context, filter *Filters) ([]Books, pagination.Meta, error) {
 	return s.book.GetAll(ctx, filter)
 }
 
+func (s *serviceBook) Facets(ctx context.Context, filter *Filters) (*Facets, error) {
+	return s.book.Facets(ctx, filter)
+}
+
 func (s *serviceBook) GetById(ctx context.Context, id int64) (*Books, error) {
 	return s.book.GetById(ctx, id)
 }
 
+func (s *serviceBook) GetIdByISBN(ctx context.Context, isbn13 string) (int64, error) {
+	return s.book.GetIdByISBN(ctx, isbn13)
+}
+
+// GetByISBN aceita o ISBN em qualquer das formas, com ou sem hífens.
+func (s *serviceBook) GetByISBN(ctx context.Context, isbn string) (*Books, error) {
+	_, isbn13, err := NormalizeISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.book.GetIdByISBN(ctx, isbn13)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.book.GetById(ctx, id)
+}
+
+func (s *serviceBook) GetByIds(ctx context.Context, ids []int64) ([]Books, error) {
+	return s.book.GetByIds(ctx, ids)
+}
+
+func (s *serviceBook) SearchIDs(ctx context.Context, query string, limit int) ([]int64, int, error) {
+	return s.book.SearchIDs(ctx, query, limit)
+}
+
+// Vocabulary devolve o vocabulário em memória, recarregado do banco a cada
+// vocabularyTTL.
+func (s *serviceBook) Vocabulary(ctx context.Context) (textsearch.Vocabulary, error) {
+	return s.vocabulary.get(ctx, s.book.Vocabulary)
+}
+
+// Search devolve os livros em ordem de relevância e o total encontrado. Sem
+// resultados, sugere correções pelo vocabulário do acervo e busca pela
+// primeira delas.
+func (s *serviceBook) Search(ctx context.Context, query string, limit int) (*SearchResult, error) {
+	if len(searchTerms(query)) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	ids, total, err := s.book.SearchIDs(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{}
+
+	if total == 0 {
+		vocabulary, err := s.Vocabulary(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Suggestions = vocabulary.Suggest(query)
+
+		if len(result.Suggestions) > 0 {
+			ids, total, err = s.book.SearchIDs(ctx, result.Suggestions[0], limit)
+			if err != nil {
+				return nil, err
+			}
+
+			if total > 0 {
+				result.CorrectedQuery = result.Suggestions[0]
+			}
+		}
+	}
+
+	// os livros vêm na ordem de relevância; os apagados depois da busca no
+	// índice ficam de fora
+	books, err := s.book.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Total = total
+	result.Books = books
+
+	return result, nil
+}
+
+// checkISBN impede que dois livros usem o mesmo ISBN antes de chegar à
+// restrição única do banco.
+func (s *serviceBook) checkISBN(ctx context.Context, b *Books) error {
+	if b.ISBN13 == "" {
+		return nil
+	}
+
+	id, err := s.book.GetIdByISBN(ctx, b.ISBN13)
+	if errors.Is(err, ErrBookNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if id != b.ID {
+		return ErrDuplicateISBN
+	}
+	return nil
+}
+
 func (s *serviceBook) Update(ctx context.Context, b *Books) error {
 	if err := b.Validate(); err != nil {
 		return err
 	}
 
+	if err := s.checkISBN(ctx, b); err != nil {
+		return err
+	}
+
 	return s.book.Update(ctx, b)
 }
 
@@ -49,3 +176,25 @@ func (s *serviceBook) Delete(ctx context.Context, id int64) error {
 func (s *serviceBook) RelationBookCategory(ctx context.Context, bookID, catID int64) error {
 	return s.book.RelationBookCategory(ctx, bookID, catID)
 }
+
+func (s *serviceBook) RemoveCategory(ctx context.Context, bookID, catID int64) error {
+	return s.book.RemoveCategory(ctx, bookID, catID)
+}
+
+// ReplaceCategories descarta IDs repetidos, mantendo a ordem em que vieram.
+func (s *serviceBook) ReplaceCategories(ctx context.Context, bookID int64, categoryIDs []int64) error {
+	seen := make(map[int64]bool, len(categoryIDs))
+	unique := make([]int64, 0, len(categoryIDs))
+
+	for _, id := range categoryIDs {
+		if id <= 0 {
+			return ErrCategoryNotFound
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return s.book.ReplaceCategories(ctx, bookID, unique)
+}
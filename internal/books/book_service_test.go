@@ -7,6 +7,8 @@ import (
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -102,7 +104,8 @@ func TestBookService_GetAll(t *testing.T) {
 				Title:    "",
 				Authors:  "",
 				Category: "",
-				Page:     10,
+
+				Pagination: pagination.Params{Page: 10},
 			},
 			books:    []books.Books{{ID: 1, Title: "T1"}, {ID: 2, Title: "T2"}},
 			wantCont: 2,
@@ -118,10 +121,10 @@ func TestBookService_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBook := new(books.MockBookRepo)
 
-			mockBook.On("GetAll", mock.Anything, tt.filter).Return(tt.books, nil)
+			mockBook.On("GetAll", mock.Anything, tt.filter).Return(tt.books, pagination.Meta{Total: len(tt.books)}, nil)
 
 			svc := books.NewBookService(mockBook)
-			result, err := svc.GetAll(context.Background(), tt.filter)
+			result, meta, err := svc.GetAll(context.Background(), tt.filter)
 
 			if tt.wantErr {
 				t.Fatalf("erro ao buscar dados")
@@ -129,7 +132,207 @@ func TestBookService_GetAll(t *testing.T) {
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.books, result)
+			assert.Equal(t, tt.wantCont, meta.Total)
+
+			mockBook.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookService_CreateISBN(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  int64
+		lookupErr error
+		wantErr   error
+	}{
+		{
+			name:      "isbn livre",
+			lookupErr: books.ErrBookNotFound,
+		},
+		{
+			name:     "isbn duplicado",
+			existing: 7,
+			wantErr:  books.ErrDuplicateISBN,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &books.Books{Title: "Titulo", Description: "Descrição", ISBN10: "0-306-40615-2"}
+
+			mockBook := new(books.MockBookRepo)
+			mockBook.On("GetIdByISBN", mock.Anything, "9780306406157").Return(tt.existing, tt.lookupErr)
+
+			if tt.wantErr == nil {
+				mockBook.On("Create", mock.Anything, input).Return(nil)
+			}
+
+			svc := books.NewBookService(mockBook)
+			err := svc.Create(context.Background(), input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "0306406152", input.ISBN10)
+				assert.Equal(t, "9780306406157", input.ISBN13)
+			}
+
+			mockBook.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookService_Search(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		limit     int
+		wantLimit int
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "limite padrão",
+			query:     "porquinho",
+			wantLimit: books.DefaultSearchLimit,
+			wantCount: 2,
+		},
+		{
+			name:      "limite acima do máximo",
+			query:     "porquinho",
+			limit:     500,
+			wantLimit: books.MaxSearchLimit,
+			wantCount: 2,
+		},
+		{
+			name:    "sem termos",
+			query:   "  ?! ",
+			wantErr: books.ErrEmptyQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBook := new(books.MockBookRepo)
+
+			if tt.wantErr == nil {
+				mockBook.On("SearchIDs", mock.Anything, tt.query, tt.wantLimit).Return([]int64{2, 1}, 2, nil)
+				mockBook.On("GetByIds", mock.Anything, []int64{2, 1}).Return([]books.Books{{ID: 2}, {ID: 1}}, nil)
+			}
+
+			svc := books.NewBookService(mockBook)
+			result, err := svc.Search(context.Background(), tt.query, tt.limit)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, result.Total)
+			assert.Len(t, result.Books, tt.wantCount)
+			assert.Equal(t, int64(2), result.Books[0].ID, "a ordem de relevância deve ser mantida")
+			assert.Empty(t, result.Suggestions)
+
+			mockBook.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookService_SearchSuggestions(t *testing.T) {
+	vocabulary := textsearch.Vocabulary{}
+	vocabulary.Add("Memórias póstumas de Brás Cubas")
+	vocabulary.Add("Machado de Assis")
+
+	tests := []struct {
+		name          string
+		query         string
+		correctedHits int
+		wantCorrected string
+		wantCount     int
+	}{
+		{
+			name:          "usa a primeira sugestão",
+			query:         "machdo",
+			correctedHits: 1,
+			wantCorrected: "machado",
+			wantCount:     1,
+		},
+		{
+			name:      "sugestão sem resultados",
+			query:     "machdo",
+			wantCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBook := new(books.MockBookRepo)
+			mockBook.On("SearchIDs", mock.Anything, tt.query, books.DefaultSearchLimit).Return([]int64{}, 0, nil)
+			mockBook.On("Vocabulary", mock.Anything).Return(vocabulary, nil)
+
+			ids := make([]int64, tt.correctedHits)
+			found := make([]books.Books, tt.correctedHits)
+			for i := range ids {
+				ids[i] = int64(i + 1)
+				found[i] = books.Books{ID: ids[i]}
+			}
+			mockBook.On("SearchIDs", mock.Anything, "machado", books.DefaultSearchLimit).Return(ids, tt.correctedHits, nil)
+			mockBook.On("GetByIds", mock.Anything, ids).Return(found, nil)
+
+			svc := books.NewBookService(mockBook)
+			result, err := svc.Search(context.Background(), tt.query, 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"machado"}, result.Suggestions)
+			assert.Equal(t, tt.wantCorrected, result.CorrectedQuery)
+			assert.Len(t, result.Books, tt.wantCount)
+
+			mockBook.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookService_SearchVocabularyCache(t *testing.T) {
+	vocabulary := textsearch.Vocabulary{}
+	vocabulary.Add("Machado de Assis")
+
+	mockBook := new(books.MockBookRepo)
+	mockBook.On("SearchIDs", mock.Anything, mock.Anything, books.DefaultSearchLimit).Return([]int64{}, 0, nil)
+	mockBook.On("Vocabulary", mock.Anything).Return(vocabulary, nil).Once()
+	mockBook.On("GetByIds", mock.Anything, []int64{}).Return([]books.Books{}, nil)
+
+	svc := books.NewBookService(mockBook)
+
+	// o vocabulário é lido do banco só na primeira busca sem resultado
+	for _, query := range []string{"machdo", "asis"} {
+		_, err := svc.Search(context.Background(), query, 0)
+		assert.NoError(t, err)
+	}
+
+	mockBook.AssertNumberOfCalls(t, "Vocabulary", 1)
+}
+
+func TestBookService_ReplaceCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []int64
+		want    []int64
+		wantErr error
+	}{
+		{name: "sem repetições", input: []int64{3, 1, 3, 2, 1}, want: []int64{3, 1, 2}},
+		{name: "lista vazia", input: []int64{}, want: []int64{}},
+		{name: "id inválido", input: []int64{1, 0}, wantErr: books.ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBook := new(books.MockBookRepo)
+			if tt.wantErr == nil {
+				mockBook.On("ReplaceCategories", mock.Anything, int64(7), tt.want).Return(nil)
+			}
+
+			err := books.NewBookService(mockBook).ReplaceCategories(context.Background(), 7, tt.input)
 
+			assert.ErrorIs(t, err, tt.wantErr)
 			mockBook.AssertExpectations(t)
 		})
 	}
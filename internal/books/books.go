@@ -7,6 +7,9 @@ import (
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/publishers"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 )
 
 type Books struct {
@@ -19,6 +22,38 @@ type Books struct {
 	Categories  []categories.Category
 	AuthorID    int64
 	Authors     authors.Authors
+	ISBN10      string
+	ISBN13      string
+
+	PublisherID     int64
+	Publisher       publishers.Publishers
+	Edition         int
+	Language        string
+	PublicationYear int
+	Pages           int
+	Format          Format
+
+	// CallNumber é o número de chamada na estante, em Dewey ou LCC.
+	CallNumber string
+
+	// Série e volumes vizinhos são somente leitura aqui; o vínculo é
+	// mantido pelas rotas de séries.
+	SeriesID         int64
+	SeriesName       string
+	SeriesVolume     int
+	PreviousVolumeID int64
+	NextVolumeID     int64
+
+	// Capa gravada pelas rotas de capas; vazia quando o livro não tem capa.
+	CoverURL          string
+	CoverThumbnailURL string
+
+	// Contributors lista autores, editores, tradutores e ilustradores na
+	// ordem de exibição. AuthorID e Authors seguem como o autor principal.
+	Contributors []Contributor
+
+	TotalCopies     int
+	AvailableCopies int
 }
 
 type BookCreator interface {
@@ -26,11 +61,21 @@ type BookCreator interface {
 	Update(ctx context.Context, b *Books) error
 	Delete(ctx context.Context, id int64) error
 	RelationBookCategory(ctx context.Context, bookID, categoryID int64) error
+	RemoveCategory(ctx context.Context, bookID, categoryID int64) error
+	ReplaceCategories(ctx context.Context, bookID int64, categoryIDs []int64) error
 }
 
 type BookRead interface {
-	GetAll(ctx context.Context, filter *Filters) ([]Books, error)
+	GetAll(ctx context.Context, filter *Filters) ([]Books, pagination.Meta, error)
+	Facets(ctx context.Context, filter *Filters) (*Facets, error)
 	GetById(ctx context.Context, id int64) (*Books, error)
+	// GetByIds carrega os livros na ordem dos IDs informados; os que não
+	// existem mais ficam de fora.
+	GetByIds(ctx context.Context, ids []int64) ([]Books, error)
+	// GetIdByISBN procura o livro pelo ISBN-13 já normalizado.
+	GetIdByISBN(ctx context.Context, isbn13 string) (int64, error)
+	SearchIDs(ctx context.Context, query string, limit int) ([]int64, int, error)
+	Vocabulary(ctx context.Context) (textsearch.Vocabulary, error)
 }
 
 type IBookRepository interface {
@@ -47,5 +92,48 @@ func (b *Books) Validate() error {
 		return errors.New("descrição invalida")
 	}
 
+	if err := b.normalizeEdition(); err != nil {
+		return err
+	}
+
+	if err := b.normalizeContributors(); err != nil {
+		return err
+	}
+
+	if err := b.normalizeCallNumber(); err != nil {
+		return err
+	}
+
+	return b.normalizeISBN()
+}
+
+// normalizeISBN valida os ISBNs informados e preenche a forma que faltar, de
+// modo que o livro sempre seja gravado com as duas representações.
+func (b *Books) normalizeISBN() error {
+	if b.ISBN10 == "" && b.ISBN13 == "" {
+		return nil
+	}
+
+	var isbn10, isbn13 string
+
+	for _, raw := range []string{b.ISBN10, b.ISBN13} {
+		if raw == "" {
+			continue
+		}
+
+		i10, i13, err := NormalizeISBN(raw)
+		if err != nil {
+			return err
+		}
+
+		if isbn13 != "" && isbn13 != i13 {
+			return ErrISBNMismatch
+		}
+
+		isbn10, isbn13 = i10, i13
+	}
+
+	b.ISBN10 = isbn10
+	b.ISBN13 = isbn13
 	return nil
 }
@@ -0,0 +1,33 @@
+package books
+
+import (
+	"database/sql"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/callnumber"
+)
+
+// normalizeCallNumber valida o número de chamada e tira os espaços que
+// sobrarem. Vazio significa que o livro ainda não foi classificado.
+func (b *Books) normalizeCallNumber() error {
+	if b.CallNumber == "" {
+		return nil
+	}
+
+	n, err := callnumber.Parse(b.CallNumber)
+	if err != nil {
+		return err
+	}
+
+	b.CallNumber = n.Text
+	return nil
+}
+
+// callNumberKey é a chave de ordenação gravada junto com o número de chamada,
+// usada na ordenação por estante e nos filtros por intervalo.
+func callNumberKey(text string) sql.NullString {
+	n, err := callnumber.Parse(text)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: n.Key, Valid: true}
+}
@@ -0,0 +1,35 @@
+package books
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/callnumber"
+)
+
+func TestBooks_ValidateCallNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr error
+	}{
+		{name: "sem classificação"},
+		{name: "dewey", in: " 869.3   L716m ", want: "869.3 L716m"},
+		{name: "lcc", in: "QA76.73 .G63 2015", want: "QA76.73 .G63 2015"},
+		{name: "invalido", in: "romance", wantErr: callnumber.ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Books{Title: "Titulo", Description: "Descrição", CallNumber: tt.in}
+
+			if err := b.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil && b.CallNumber != tt.want {
+				t.Errorf("esperava %q, recebeu %q", tt.want, b.CallNumber)
+			}
+		})
+	}
+}
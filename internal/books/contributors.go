@@ -0,0 +1,158 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ContributorRole string
+
+const (
+	RoleAuthor      ContributorRole = "author"
+	RoleEditor      ContributorRole = "editor"
+	RoleTranslator  ContributorRole = "translator"
+	RoleIllustrator ContributorRole = "illustrator"
+)
+
+var (
+	ErrInvalidContributor   = errors.New("colaborador invalido")
+	ErrDuplicateContributor = errors.New("colaborador repetido com o mesmo papel")
+)
+
+// Contributor é um autor ligado ao livro por um papel. Position define a
+// ordem de exibição, começando em 1.
+type Contributor struct {
+	AuthorID int64
+	Name     string
+	Role     ContributorRole
+	Position int
+}
+
+func (r ContributorRole) Valid() bool {
+	switch r {
+	case RoleAuthor, RoleEditor, RoleTranslator, RoleIllustrator:
+		return true
+	}
+	return false
+}
+
+// normalizeContributors numera os colaboradores na ordem recebida e mantém
+// AuthorID apontando para o autor principal: o primeiro de papel "author".
+func (b *Books) normalizeContributors() error {
+	if len(b.Contributors) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(b.Contributors))
+
+	for i := range b.Contributors {
+		c := &b.Contributors[i]
+
+		if c.Role == "" {
+			c.Role = RoleAuthor
+		}
+
+		if c.AuthorID <= 0 || !c.Role.Valid() {
+			return ErrInvalidContributor
+		}
+
+		key := fmt.Sprintf("%d:%s", c.AuthorID, c.Role)
+		if seen[key] {
+			return ErrDuplicateContributor
+		}
+		seen[key] = true
+
+		c.Position = i + 1
+	}
+
+	b.AuthorID = 0
+	for _, c := range b.Contributors {
+		if c.Role == RoleAuthor {
+			b.AuthorID = c.AuthorID
+			break
+		}
+	}
+
+	if b.AuthorID == 0 {
+		b.AuthorID = b.Contributors[0].AuthorID
+	}
+
+	return nil
+}
+
+// contributorsOrDefault devolve os colaboradores a gravar; livros cadastrados
+// apenas com author_id recebem o autor principal como único colaborador.
+func (b *Books) contributorsOrDefault() []Contributor {
+	if len(b.Contributors) > 0 {
+		return b.Contributors
+	}
+
+	if b.AuthorID > 0 {
+		return []Contributor{{AuthorID: b.AuthorID, Role: RoleAuthor, Position: 1}}
+	}
+
+	return nil
+}
+
+func saveContributors(ctx context.Context, tx *sql.Tx, b *Books) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM book_author WHERE book_id = ?", b.ID); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO book_author (book_id, author_id, role, position) VALUES (?, ?, ?, ?)"
+
+	for _, c := range b.contributorsOrDefault() {
+		if _, err := tx.ExecContext(ctx, query, b.ID, c.AuthorID, c.Role, c.Position); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadContributors busca em uma única consulta os colaboradores de todos os
+// livros carregados, evitando multiplicar as linhas do join com categorias.
+func (r *BookRepository) loadContributors(ctx context.Context, booksMap map[int64]*Books) error {
+	if len(booksMap) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(booksMap))
+	params := make([]any, 0, len(booksMap))
+
+	for id, b := range booksMap {
+		placeholders = append(placeholders, "?")
+		params = append(params, id)
+		b.Contributors = []Contributor{}
+	}
+
+	query := `SELECT ba.book_id, ba.author_id, a.name, ba.role, ba.position
+		FROM book_author ba
+		JOIN authors a ON ba.author_id = a.id
+		WHERE ba.book_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY ba.book_id, ba.position`
+
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			bookID int64
+			c      Contributor
+		)
+
+		if err := rows.Scan(&bookID, &c.AuthorID, &c.Name, &c.Role, &c.Position); err != nil {
+			return err
+		}
+
+		booksMap[bookID].Contributors = append(booksMap[bookID].Contributors, c)
+	}
+
+	return rows.Err()
+}
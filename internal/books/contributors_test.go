@@ -0,0 +1,61 @@
+package books
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBooks_ValidateContributors(t *testing.T) {
+	tests := []struct {
+		name         string
+		contributors []Contributor
+		wantAuthorID int64
+		wantErr      error
+	}{
+		{
+			name:         "autor principal é o primeiro de papel author",
+			contributors: []Contributor{{AuthorID: 3, Role: RoleEditor}, {AuthorID: 5}},
+			wantAuthorID: 5,
+		},
+		{
+			name:         "sem autor usa o primeiro colaborador",
+			contributors: []Contributor{{AuthorID: 3, Role: RoleEditor}, {AuthorID: 4, Role: RoleIllustrator}},
+			wantAuthorID: 3,
+		},
+		{
+			name:         "papel invalido",
+			contributors: []Contributor{{AuthorID: 3, Role: "revisor"}},
+			wantErr:      ErrInvalidContributor,
+		},
+		{
+			name:         "colaborador repetido",
+			contributors: []Contributor{{AuthorID: 3}, {AuthorID: 3, Role: RoleAuthor}},
+			wantErr:      ErrDuplicateContributor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Books{Title: "Titulo", Description: "Descrição", AuthorID: 9, Contributors: tt.contributors}
+
+			err := b.Validate()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if b.AuthorID != tt.wantAuthorID {
+				t.Errorf("esperava autor principal %d, recebeu %d", tt.wantAuthorID, b.AuthorID)
+			}
+
+			for i, c := range b.Contributors {
+				if c.Position != i+1 {
+					t.Errorf("posição errada para %d: %d", c.AuthorID, c.Position)
+				}
+			}
+		})
+	}
+}
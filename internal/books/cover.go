@@ -0,0 +1,24 @@
+package books
+
+import "database/sql"
+
+// coverSelect lista as URLs públicas da capa; a miniatura cai para o original
+// quando não foi gerada (WebP). Depende dos LEFT JOIN book_covers co e ct.
+const coverSelect = "co.url, COALESCE(ct.url, co.url)"
+
+const coverJoins = `LEFT JOIN book_covers co ON co.book_id = b.id AND co.size = 'original'
+	LEFT JOIN book_covers ct ON ct.book_id = b.id AND ct.size = 'small'`
+
+// coverColumns recebe as colunas opcionais de coverSelect.
+type coverColumns struct {
+	url, thumbnailURL sql.NullString
+}
+
+func (c *coverColumns) targets() []any {
+	return []any{&c.url, &c.thumbnailURL}
+}
+
+func (c *coverColumns) apply(b *Books) {
+	b.CoverURL = c.url.String
+	b.CoverThumbnailURL = c.thumbnailURL.String
+}
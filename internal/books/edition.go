@@ -0,0 +1,122 @@
+package books
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/publishers"
+)
+
+type Format string
+
+const (
+	Hardcover Format = "hardcover"
+	Paperback Format = "paperback"
+	Ebook     Format = "ebook"
+	Audiobook Format = "audiobook"
+	Other     Format = "other"
+)
+
+var (
+	ErrInvalidLanguage = errors.New("idioma deve ser um código ISO 639-1")
+	ErrInvalidFormat   = errors.New("formato invalido")
+	ErrInvalidEdition  = errors.New("edição, ano de publicação ou número de páginas invalido")
+)
+
+// iso6391 lista os códigos de idioma de duas letras da ISO 639-1.
+var iso6391 = func() map[string]bool {
+	codes := strings.Fields(`aa ab ae af ak am an ar as av ay az ba be bg bh bi bm bn bo br bs ca ce ch co
+		cr cs cu cv cy da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho
+		hr ht hu hy hz ia id ie ig ii ik io is it iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la
+		lb lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny oc oj om or
+		os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq sr ss st su sv sw ta te tg
+		th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo za zh zu`)
+
+	set := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		set[c] = true
+	}
+	return set
+}()
+
+func (f Format) Valid() bool {
+	switch f {
+	case Hardcover, Paperback, Ebook, Audiobook, Other:
+		return true
+	}
+	return false
+}
+
+// ValidLanguage informa se o código pertence à ISO 639-1, sem diferenciar
+// maiúsculas de minúsculas.
+func ValidLanguage(code string) bool {
+	return iso6391[strings.ToLower(code)]
+}
+
+// normalizeEdition valida os metadados da edição. Campos zerados significam
+// "não informado".
+func (b *Books) normalizeEdition() error {
+	if b.Language != "" {
+		b.Language = strings.ToLower(b.Language)
+		if !ValidLanguage(b.Language) {
+			return ErrInvalidLanguage
+		}
+	}
+
+	if b.Format != "" && !b.Format.Valid() {
+		return ErrInvalidFormat
+	}
+
+	if b.Edition < 0 || b.Pages < 0 {
+		return ErrInvalidEdition
+	}
+
+	if b.PublicationYear != 0 && (b.PublicationYear < 1450 || b.PublicationYear > time.Now().Year()+1) {
+		return ErrInvalidEdition
+	}
+
+	return nil
+}
+
+// editionSelect lista as colunas de edição e editora lidas junto com o livro;
+// depende do LEFT JOIN publishers p.
+const editionSelect = "b.publisher_id, p.name, p.country, b.edition, b.language, b.publication_year, b.pages, b.format"
+
+// editionColumns recebe as colunas opcionais de editionSelect.
+type editionColumns struct {
+	publisherID                     sql.NullInt64
+	publisherName, publisherCountry sql.NullString
+	edition, year, pages            sql.NullInt64
+	language, format                sql.NullString
+}
+
+func (e *editionColumns) targets() []any {
+	return []any{
+		&e.publisherID, &e.publisherName, &e.publisherCountry,
+		&e.edition, &e.language, &e.year, &e.pages, &e.format,
+	}
+}
+
+func (e *editionColumns) apply(b *Books) {
+	b.PublisherID = e.publisherID.Int64
+	b.Edition = int(e.edition.Int64)
+	b.Language = e.language.String
+	b.PublicationYear = int(e.year.Int64)
+	b.Pages = int(e.pages.Int64)
+	b.Format = Format(e.format.String)
+
+	if e.publisherID.Valid {
+		b.Publisher = publishers.Publishers{
+			ID:      e.publisherID.Int64,
+			Name:    e.publisherName.String,
+			Country: e.publisherCountry.String,
+		}
+	}
+}
+
+// nullInt grava números opcionais como NULL quando não informados.
+func nullInt(n int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: n, Valid: n != 0}
+}
@@ -0,0 +1,37 @@
+package books
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBooks_ValidateEdition(t *testing.T) {
+	tests := []struct {
+		name    string
+		book    Books
+		wantErr error
+	}{
+		{name: "sem metadados"},
+		{name: "metadados completos", book: Books{Language: "PT", Format: Paperback, Edition: 3, PublicationYear: 1998, Pages: 200}},
+		{name: "idioma fora da ISO 639-1", book: Books{Language: "xx"}, wantErr: ErrInvalidLanguage},
+		{name: "idioma com três letras", book: Books{Language: "por"}, wantErr: ErrInvalidLanguage},
+		{name: "formato invalido", book: Books{Format: "pdf"}, wantErr: ErrInvalidFormat},
+		{name: "ano antes da imprensa", book: Books{PublicationYear: 1200}, wantErr: ErrInvalidEdition},
+		{name: "páginas negativas", book: Books{Pages: -1}, wantErr: ErrInvalidEdition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.book
+			b.Title = "Titulo"
+			b.Description = "Descrição"
+
+			if err := b.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil && b.Language != "" && b.Language != "pt" {
+				t.Errorf("esperava idioma normalizado, recebeu %q", b.Language)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+)
+
+// facetLimit limita quantos valores cada faceta devolve, dos mais frequentes
+// para os menos frequentes.
+const facetLimit = 20
+
+// FacetValue é um valor de faceta e quantos livros da busca o têm. ID só é
+// preenchido para facetas de entidades (categorias, autores, editoras).
+type FacetValue struct {
+	ID    int64
+	Value string
+	Count int
+}
+
+type Facets struct {
+	Categories []FacetValue
+	Authors    []FacetValue
+	Publishers []FacetValue
+	Languages  []FacetValue
+	Formats    []FacetValue
+	Years      []FacetValue
+}
+
+// Facets conta os livros por valor de cada campo filtrável, sob os mesmos
+// filtros da listagem e sem paginação.
+func (r *BookRepository) Facets(ctx context.Context, filter *Filters) (*Facets, error) {
+	facets := &Facets{}
+
+	// um livro conta para o autor principal e para cada coautor ligado por
+	// book_author; os demais papéis (tradução, ilustração) ficam de fora
+	authorsJoin := `
+	LEFT JOIN authors fa ON fa.id = b.author_id
+		OR fa.id IN (SELECT ba.author_id FROM book_author ba WHERE ba.book_id = b.id AND ba.role = 'author') `
+
+	fields := []struct {
+		target    *[]FacetValue
+		id, value string
+		join      string
+	}{
+		{&facets.Categories, "c.id", "c.name", ""},
+		{&facets.Authors, "fa.id", "fa.name", authorsJoin},
+		{&facets.Publishers, "p.id", "p.name", ""},
+		{&facets.Languages, "NULL", "b.language", ""},
+		{&facets.Formats, "NULL", "b.format", ""},
+		{&facets.Years, "NULL", "b.publication_year", ""},
+	}
+
+	where, params := filter.where()
+	if where == "" {
+		where = " WHERE "
+	} else {
+		where += " AND "
+	}
+	params = append(params, facetLimit)
+
+	for _, field := range fields {
+		query := "SELECT " + field.id + ", " + field.value + ", COUNT(DISTINCT b.id) " + filterFrom + field.join +
+			where + field.value + " IS NOT NULL GROUP BY 1, 2 ORDER BY 3 DESC, 2 ASC LIMIT ?"
+
+		values, err := r.scanFacet(ctx, query, params...)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = values
+	}
+
+	return facets, nil
+}
+
+func (r *BookRepository) scanFacet(ctx context.Context, query string, params ...any) ([]FacetValue, error) {
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []FacetValue{}
+	for rows.Next() {
+		var (
+			v  FacetValue
+			id sql.NullInt64
+		)
+		if err := rows.Scan(&id, &v.Value, &v.Count); err != nil {
+			return nil, err
+		}
+
+		v.ID = id.Int64
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
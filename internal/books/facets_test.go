@@ -0,0 +1,99 @@
+package books_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+func TestBookRepository_Facets(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	ctx := context.Background()
+	repo := books.NewBookRepository(db)
+
+	// um segundo rótulo para o livro 2, para contar livros e não linhas
+	if err := categories.NewCategoryRepository(db).Create(ctx, &categories.Category{ID: 2, Name: "Dados"}); err != nil {
+		t.Fatalf("category: %v", err)
+	}
+	if err := repo.RelationBookCategory(ctx, 2, 2); err != nil {
+		t.Fatalf("relation: %v", err)
+	}
+
+	// uma coautora ligada só por book_author, além da tradutora do seed
+	if err := authors.NewAuthorsRepository(db).Create(ctx, &authors.Authors{ID: 3, Name: "Coautora W"}); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO book_author (book_id, author_id, role, position) VALUES (2, 3, 'author', 3)"); err != nil {
+		t.Fatalf("book_author: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		filter         *books.Filters
+		wantCategories []books.FacetValue
+		wantAuthors    []books.FacetValue
+		wantLanguages  []books.FacetValue
+		wantFormats    []books.FacetValue
+		wantPublishers []books.FacetValue
+	}{
+		{
+			name:   "sem filtros",
+			filter: &books.Filters{},
+			wantCategories: []books.FacetValue{
+				{ID: 1, Value: "Programação", Count: 2},
+				{ID: 2, Value: "Dados", Count: 1},
+			},
+			wantAuthors: []books.FacetValue{
+				{ID: 1, Value: "Autor X", Count: 2},
+				{ID: 3, Value: "Coautora W", Count: 1},
+			},
+			wantLanguages: []books.FacetValue{{Value: "en", Count: 1}, {Value: "pt", Count: 1}},
+			wantFormats:   []books.FacetValue{{Value: "hardcover", Count: 1}, {Value: "paperback", Count: 1}},
+			wantPublishers: []books.FacetValue{
+				{ID: 1, Value: "Editora Z", Count: 1},
+			},
+		},
+		{
+			name:   "mesmos filtros da listagem",
+			filter: &books.Filters{Language: "pt", Pagination: pagination.Params{Page: 1}},
+			wantCategories: []books.FacetValue{
+				{ID: 1, Value: "Programação", Count: 1},
+			},
+			wantAuthors:    []books.FacetValue{{ID: 1, Value: "Autor X", Count: 1}},
+			wantLanguages:  []books.FacetValue{{Value: "pt", Count: 1}},
+			wantFormats:    []books.FacetValue{{Value: "paperback", Count: 1}},
+			wantPublishers: []books.FacetValue{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facets, err := repo.Facets(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if !reflect.DeepEqual(facets.Categories, tt.wantCategories) {
+				t.Errorf("categorias: esperava %+v, recebeu %+v", tt.wantCategories, facets.Categories)
+			}
+			if !reflect.DeepEqual(facets.Languages, tt.wantLanguages) {
+				t.Errorf("idiomas: esperava %+v, recebeu %+v", tt.wantLanguages, facets.Languages)
+			}
+			if !reflect.DeepEqual(facets.Formats, tt.wantFormats) {
+				t.Errorf("formatos: esperava %+v, recebeu %+v", tt.wantFormats, facets.Formats)
+			}
+			if !reflect.DeepEqual(facets.Publishers, tt.wantPublishers) {
+				t.Errorf("editoras: esperava %+v, recebeu %+v", tt.wantPublishers, facets.Publishers)
+			}
+			if !reflect.DeepEqual(facets.Authors, tt.wantAuthors) {
+				t.Errorf("autores: esperava %+v, recebeu %+v", tt.wantAuthors, facets.Authors)
+			}
+		})
+	}
+}
@@ -26,7 +26,10 @@ func setupTest(mockBookSvc BookServcie) (*gin.Engine, *httptest.ResponseRecorder
 	router.DELETE("/api/books/:id", handler.DeleteBook)
 	router.GET("/api/books", handler.ReadAllBooks)
 	router.GET("/api/books/:id", handler.ReadBook)
+	router.GET("/api/books/isbn/:isbn", handler.ReadBookByISBN)
 	router.POST("/api/books/relation", handler.RelationBookCategory)
+	router.PUT("/api/books/:id/categories", handler.ReplaceCategories)
+	router.DELETE("/api/books/:id/categories/:categoryId", handler.RemoveCategory)
 
 	return router, httptest.NewRecorder()
 }
@@ -58,7 +61,31 @@ var expectedBody = `{
     "id": 0,
     "name": "",
     "description": ""
-  }
+  },
+  "isbn_10": "",
+  "isbn_13": "",
+  "publisher_id": 0,
+  "publisher": {
+    "id": 0,
+    "name": "",
+    "country": ""
+  },
+  "edition": 0,
+  "language": "",
+  "publication_year": 0,
+  "pages": 0,
+  "format": "",
+  "call_number": "",
+  "series_id": 0,
+  "series_name": "",
+  "series_volume": 0,
+  "previous_volume_id": 0,
+  "next_volume_id": 0,
+  "cover_url": "",
+  "cover_thumbnail_url": "",
+  "contributors": [],
+  "total_copies": 0,
+  "available_copies": 0
 }
 `
 
@@ -0,0 +1,98 @@
+package books
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidISBN   = errors.New("isbn invalido")
+	ErrISBNMismatch  = errors.New("isbn-10 e isbn-13 não correspondem ao mesmo livro")
+	ErrDuplicateISBN = errors.New("isbn já cadastrado para outro livro")
+	ErrBookNotFound  = errors.New("livro não encontrado")
+)
+
+// NormalizeISBN aceita um ISBN-10 ou ISBN-13, com ou sem hífens e espaços,
+// confere o dígito verificador e devolve as duas formas. ISBN-13 com prefixo
+// 979 não tem ISBN-10 equivalente e retorna isbn10 vazio.
+func NormalizeISBN(raw string) (isbn10, isbn13 string, err error) {
+	clean := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(raw))
+
+	switch len(clean) {
+	case 10:
+		if !validISBN10(clean) {
+			return "", "", ErrInvalidISBN
+		}
+		body := "978" + clean[:9]
+		return clean, body + isbn13CheckDigit(body), nil
+	case 13:
+		if !validISBN13(clean) {
+			return "", "", ErrInvalidISBN
+		}
+		if strings.HasPrefix(clean, "978") {
+			body := clean[3:12]
+			return body + isbn10CheckDigit(body), clean, nil
+		}
+		return "", clean, nil
+	default:
+		return "", "", ErrInvalidISBN
+	}
+}
+
+func validISBN10(s string) bool {
+	for i := 0; i < 9; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	last := s[9]
+	if (last < '0' || last > '9') && last != 'X' {
+		return false
+	}
+
+	return isbn10CheckDigit(s[:9]) == string(last)
+}
+
+func validISBN13(s string) bool {
+	for i := 0; i < 13; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	if !strings.HasPrefix(s, "978") && !strings.HasPrefix(s, "979") {
+		return false
+	}
+
+	return isbn13CheckDigit(s[:12]) == s[12:]
+}
+
+// isbn10CheckDigit calcula o dígito com pesos 10..2 em módulo 11; o resto 10
+// é representado por X.
+func isbn10CheckDigit(body string) string {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(body[i]-'0') * (10 - i)
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return "X"
+	}
+	return string(rune('0' + check))
+}
+
+// isbn13CheckDigit calcula o dígito com pesos alternados 1 e 3 em módulo 10.
+func isbn13CheckDigit(body string) string {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(body[i]-'0') * weight
+	}
+
+	return string(rune('0' + (10-sum%10)%10))
+}
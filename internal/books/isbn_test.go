@@ -0,0 +1,67 @@
+package books
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want10  string
+		want13  string
+		wantErr error
+	}{
+		{name: "isbn-10 com hífens", input: "0-306-40615-2", want10: "0306406152", want13: "9780306406157"},
+		{name: "isbn-13 com hífens", input: "978-0-306-40615-7", want10: "0306406152", want13: "9780306406157"},
+		{name: "isbn-10 com X", input: "0-8044-2957-x", want10: "080442957X", want13: "9780804429573"},
+		{name: "isbn-13 979 sem isbn-10", input: "979-10-90636-07-1", want13: "9791090636071"},
+		{name: "dígito verificador errado", input: "0-306-40615-3", wantErr: ErrInvalidISBN},
+		{name: "tamanho invalido", input: "12345", wantErr: ErrInvalidISBN},
+		{name: "prefixo invalido", input: "1234567890128", wantErr: ErrInvalidISBN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got10, got13, err := NormalizeISBN(tt.input)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+
+			if got10 != tt.want10 || got13 != tt.want13 {
+				t.Errorf("esperava %q/%q, recebeu %q/%q", tt.want10, tt.want13, got10, got13)
+			}
+		})
+	}
+}
+
+func TestBooks_ValidateISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		isbn10  string
+		isbn13  string
+		want13  string
+		wantErr error
+	}{
+		{name: "sem isbn"},
+		{name: "completa isbn-13", isbn10: "0306406152", want13: "9780306406157"},
+		{name: "isbns correspondentes", isbn10: "0306406152", isbn13: "9780306406157", want13: "9780306406157"},
+		{name: "isbns divergentes", isbn10: "0306406152", isbn13: "9791090636071", wantErr: ErrISBNMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Books{Title: "Titulo", Description: "Descrição", ISBN10: tt.isbn10, ISBN13: tt.isbn13}
+
+			err := b.Validate()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil && b.ISBN13 != tt.want13 {
+				t.Errorf("esperava isbn-13 %q, recebeu %q", tt.want13, b.ISBN13)
+			}
+		})
+	}
+}
@@ -2,145 +2,215 @@ package books
 
 import (
 	"context"
-	"sort"
 	"strings"
-	"time"
 
-	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
-	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/callnumber"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
 )
 
 type Filters struct {
 	Title    string
 	Authors  string
 	Category string
-	Page     int
+	// IncludeSubcategories estende o filtro de categoria a todas as
+	// subcategorias das categorias encontradas.
+	IncludeSubcategories bool
+
+	Pagination pagination.Params
+
+	// Contributor filtra pelo nome de qualquer colaborador do livro,
+	// opcionalmente restrito ao papel em ContributorRole.
+	Contributor     string
+	ContributorRole ContributorRole
+
+	Publisher string
+	Edition   int
+	Language  string
+	Format    Format
+	YearFrom  int
+	YearTo    int
+	PagesMin  int
+	PagesMax  int
+
+	// CallNumbers restringe aos livros com número de chamada no intervalo.
+	CallNumbers callnumber.Range
 }
 
-func (r *BookRepository) GetAll(ctx context.Context, filter *Filters) ([]Books, error) {
-	booksMap := make(map[int64]*Books)
-
-	sql := `SELECT b.id, b.title, b.author_id, b.description, b.content,
-		b.created_at, b.updated_at,
-		c.id, c.name, c.created_at,
-		a.id, a.name, a.description
-	FROM book_category bc
-	JOIN books b ON bc.book_id = b.id
-	JOIN categories c ON bc.category_id = c.id
-	JOIN authors a ON b.author_id = a.id`
+// filterFrom reúne os joins que os filtros usam, compartilhados pela
+// listagem e pelas facetas. São todos LEFT JOIN, para que livros sem
+// categoria também entrem.
+const filterFrom = `FROM books b
+	LEFT JOIN book_category bc ON bc.book_id = b.id
+	LEFT JOIN categories c ON bc.category_id = c.id
+	LEFT JOIN authors a ON b.author_id = a.id
+	LEFT JOIN publishers p ON b.publisher_id = p.id`
+
+// subcategoryIDs seleciona as categorias cujo nome começa pelo filtro e todas
+// as que estão abaixo delas na árvore.
+const subcategoryIDs = `WITH RECURSIVE tree(id) AS (
+		SELECT id FROM categories WHERE name LIKE ?
+		UNION
+		SELECT ch.id FROM categories ch JOIN tree t ON ch.parent_id = t.id
+	)
+	SELECT id FROM tree`
+
+// BookSorts são os campos aceitos em sort na listagem de livros. Colunas
+// opcionais entram com COALESCE, já que NULL não se compara no cursor. Em
+// call_number, os livros sem classificação vão para o fim da estante.
+var BookSorts = pagination.Sorts{
+	Fields: map[string]string{
+		"id": "b.id", "title": "b.title_search", "publication_year": "COALESCE(b.publication_year, 0)",
+		"pages": "COALESCE(b.pages, 0)", "created_at": "b.created_at",
+		"call_number": "COALESCE(b.call_number_key, '~')",
+	},
+	Default: "id",
+	Key:     "b.id",
+}
 
+// where monta as condições dos filtros sobre os aliases da listagem (b, a, c,
+// p), para que listagem e facetas usem exatamente o mesmo recorte. Título e
+// nomes são comparados pelas colunas normalizadas, sem acento nem caixa.
+func (f *Filters) where() (string, []any) {
 	var conditions []string
-	var params []interface{}
+	var params []any
 
-	if filter.Title != "" {
-		conditions = append(conditions, "b.title LIKE ?")
-		params = append(params, filter.Title+"%")
+	if f.Title != "" {
+		conditions = append(conditions, "b.title_search LIKE ?")
+		params = append(params, textsearch.Fold(f.Title)+"%")
+	}
+	if f.Authors != "" {
+		conditions = append(conditions, "a.name_search LIKE ?")
+		params = append(params, textsearch.Fold(f.Authors)+"%")
+	}
+	if f.Category != "" {
+		if f.IncludeSubcategories {
+			conditions = append(conditions, "c.id IN ("+subcategoryIDs+")")
+		} else {
+			conditions = append(conditions, "c.name LIKE ?")
+		}
+		params = append(params, f.Category+"%")
+	}
+	if f.Publisher != "" {
+		conditions = append(conditions, "p.name LIKE ?")
+		params = append(params, f.Publisher+"%")
+	}
+	if f.Edition > 0 {
+		conditions = append(conditions, "b.edition = ?")
+		params = append(params, f.Edition)
+	}
+	if f.Language != "" {
+		conditions = append(conditions, "b.language = ?")
+		params = append(params, strings.ToLower(f.Language))
 	}
-	if filter.Authors != "" {
-		conditions = append(conditions, "a.name LIKE ?")
-		params = append(params, filter.Authors+"%")
+	if f.Format != "" {
+		conditions = append(conditions, "b.format = ?")
+		params = append(params, f.Format)
 	}
-	if filter.Category != "" {
-		conditions = append(conditions, "c.name LIKE ?")
-		params = append(params, filter.Category+"%")
+	if f.YearFrom > 0 {
+		conditions = append(conditions, "b.publication_year >= ?")
+		params = append(params, f.YearFrom)
 	}
+	if f.YearTo > 0 {
+		conditions = append(conditions, "b.publication_year <= ?")
+		params = append(params, f.YearTo)
+	}
+	if f.PagesMin > 0 {
+		conditions = append(conditions, "b.pages >= ?")
+		params = append(params, f.PagesMin)
+	}
+	if f.PagesMax > 0 {
+		conditions = append(conditions, "b.pages <= ?")
+		params = append(params, f.PagesMax)
+	}
+	if !f.CallNumbers.IsZero() {
+		conditions = append(conditions, "b.call_number_key >= ? AND b.call_number_key < ?")
+		params = append(params, f.CallNumbers.Low, f.CallNumbers.High)
+	}
+	if f.Contributor != "" || f.ContributorRole != "" {
+		exists := `EXISTS (SELECT 1 FROM book_author fba
+			JOIN authors fa ON fba.author_id = fa.id
+			WHERE fba.book_id = b.id`
+
+		if f.Contributor != "" {
+			exists += " AND fa.name_search LIKE ?"
+			params = append(params, textsearch.Fold(f.Contributor)+"%")
+		}
+		if f.ContributorRole != "" {
+			exists += " AND fba.role = ?"
+			params = append(params, f.ContributorRole)
+		}
 
-	if len(conditions) > 0 {
-		sql += " WHERE " + strings.Join(conditions, " AND ")
+		conditions = append(conditions, exists+")")
 	}
 
-	sql += " ORDER BY b.id ASC"
+	if len(conditions) == 0 {
+		return "", params
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), params
+}
 
-	if filter.Page >= 1 {
-		size := 10
-		offset := (filter.Page - 1) * size
-		sql += " LIMIT ? OFFSET ?"
-		params = append(params, size, offset)
+// GetAll devolve a página pedida, o total de livros sob os filtros e os
+// cursores vizinhos. A página é escolhida sobre os IDs distintos, para que
+// livros com várias categorias não ocupem mais de uma posição.
+func (r *BookRepository) GetAll(ctx context.Context, filter *Filters) ([]Books, pagination.Meta, error) {
+	ids, meta, err := r.pageIDs(ctx, filter)
+	if err != nil {
+		return nil, pagination.Meta{}, err
 	}
 
-	rows, err := r.db.QueryContext(ctx, sql, params...)
+	books, err := r.GetByIds(ctx, ids)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var (
-			bookID, categoryId, authorId, IDAuthor      int64
-			title, description, content                 string
-			categoryName, authorName, authorDec         string
-			createdAtCatStr, createdAtStr, updatedAtStr string
-		)
-
-		if err := rows.Scan(
-			&bookID, &title, &authorId, &description, &content,
-			&createdAtStr, &updatedAtStr,
-			&categoryId, &categoryName, &createdAtCatStr,
-			&IDAuthor, &authorName, &authorDec,
-		); err != nil {
-			return nil, err
-		}
+	return books, meta, nil
+}
 
-		createdAt, err := time.Parse(time.RFC3339, createdAtStr)
-		if err != nil {
-			createdAt, err = time.Parse("2006-01-02 15:04:05", createdAtStr)
-			if err != nil {
-				return nil, err
-			}
-		}
-		updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
-		if err != nil {
-			updatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAtStr)
-			if err != nil {
-				return nil, err
-			}
-		}
-		createdAtCat, err := time.Parse(time.RFC3339, createdAtCatStr)
-		if err != nil {
-			createdAtCat, err = time.Parse("2006-01-02 15:04:05", createdAtCatStr)
-			if err != nil {
-				return nil, err
-			}
-		}
+// pageIDs conta os livros sob os filtros e devolve os IDs da página, já na
+// ordem pedida, com os cursores vizinhos.
+func (r *BookRepository) pageIDs(ctx context.Context, filter *Filters) ([]int64, pagination.Meta, error) {
+	where, params := filter.where()
 
-		if _, ok := booksMap[bookID]; !ok {
-			booksMap[bookID] = &Books{
-				ID:          bookID,
-				Title:       title,
-				AuthorID:    authorId,
-				Description: description,
-				Content:     content,
-				CreatedAt:   createdAt,
-				UpdatedAt:   updatedAt,
-				Categories:  []categories.Category{},
-				Authors:     authors.Authors{},
-			}
-		}
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(DISTINCT b.id) "+filterFrom+where, params...).
+		Scan(&total); err != nil {
+		return nil, pagination.Meta{}, err
+	}
 
-		booksMap[bookID].Categories = append(
-			booksMap[bookID].Categories,
-			categories.Category{
-				ID:        categoryId,
-				Name:      categoryName,
-				CreatedAT: createdAtCat,
-			},
-		)
-
-		booksMap[bookID].Authors = authors.Authors{
-			ID:          IDAuthor,
-			Name:        authorName,
-			Description: authorDec,
+	page := filter.Pagination
+	if seek, args := page.Seek(BookSorts); seek != "" {
+		if where == "" {
+			where = " WHERE " + seek
+		} else {
+			where += " AND " + seek
 		}
+		params = append(params, args...)
 	}
 
-	var books []Books
-	for _, b := range booksMap {
-		books = append(books, *b)
+	// a coluna de ordenação entra no SELECT porque o DISTINCT a exige
+	query := "SELECT DISTINCT b.id, " + BookSorts.Column(page) + " " + filterFrom + where +
+		page.OrderBy(BookSorts) + " LIMIT ? OFFSET ?"
+	rows, err := r.db.QueryContext(ctx, query, append(params, page.Limit(), page.Offset())...)
+	if err != nil {
+		return nil, pagination.Meta{}, err
 	}
+	defer rows.Close()
 
-	sort.Slice(books, func(i, j int) bool {
-		return books[i].ID < books[j].ID
-	})
+	var ids []int64
+	var positions []pagination.Row
+	for rows.Next() {
+		var position pagination.Row
+		if err := rows.Scan(&position.Key, &position.Value); err != nil {
+			return nil, pagination.Meta{}, err
+		}
+		ids = append(ids, position.Key)
+		positions = append(positions, position)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Meta{}, err
+	}
 
-	return books, nil
+	ids, meta := pagination.Window(page, total, ids, positions)
+	return ids, meta, nil
 }
@@ -3,12 +3,16 @@ package books_test
 import (
 	"context"
 	"database/sql"
+	"reflect"
 	"testing"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/callnumber"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/publishers"
 )
 
 func seedData(t *testing.T, db *sql.DB) {
@@ -21,15 +25,31 @@ func seedData(t *testing.T, db *sql.DB) {
 	if err := authorRepo.Create(ctx, &authors.Authors{ID: 1, Name: "Autor X", Description: "Teste"}); err != nil {
 		t.Fatalf("author: %v", err)
 	}
+	if err := authorRepo.Create(ctx, &authors.Authors{ID: 2, Name: "Tradutora Y", Description: "Teste"}); err != nil {
+		t.Fatalf("author: %v", err)
+	}
 
 	if err := categoryRepo.Create(ctx, &categories.Category{ID: 1, Name: "Programação"}); err != nil {
 		t.Fatalf("category: %v", err)
 	}
 
-	if err := bookRepo.Create(ctx, &books.Books{ID: 1, Title: "Go Lang", Description: "D1", Content: "C1", AuthorID: 1}); err != nil {
+	if err := publishers.NewPublishersRepository(db).Create(ctx, &publishers.Publishers{Name: "Editora Z", Country: "US"}); err != nil {
+		t.Fatalf("publisher: %v", err)
+	}
+
+	if err := bookRepo.Create(ctx, &books.Books{ID: 1, Title: "Go Lang", Description: "D1", Content: "C1", AuthorID: 1,
+		Language: "pt", PublicationYear: 1998, Format: books.Paperback, Pages: 180}); err != nil {
 		t.Fatalf("book: %v", err)
 	}
-	if err := bookRepo.Create(ctx, &books.Books{ID: 2, Title: "Python", Description: "D1", Content: "C1", AuthorID: 1}); err != nil {
+	python := &books.Books{ID: 2, Title: "Python", Description: "D1", Content: "C1", PublisherID: 1, Edition: 2,
+		Language: "EN", PublicationYear: 2020, Format: books.Hardcover, Pages: 320, Contributors: []books.Contributor{
+		{AuthorID: 1, Role: books.RoleAuthor},
+		{AuthorID: 2, Role: books.RoleTranslator},
+	}}
+	if err := python.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := bookRepo.Create(ctx, python); err != nil {
 		t.Fatalf("book: %v", err)
 	}
 
@@ -50,6 +70,7 @@ func TestBookRepository_GetAll_TableDriven(t *testing.T) {
 		name          string
 		filter        *books.Filters
 		wantCount     int
+		wantTotal     int
 		wantFirstID   int64
 		wantFirstName string
 	}{
@@ -69,6 +90,15 @@ func TestBookRepository_GetAll_TableDriven(t *testing.T) {
 			wantFirstID:   1,
 			wantFirstName: "Go Lang",
 		},
+		{
+			name: "título sem diferenciar acentos nem maiúsculas",
+			filter: &books.Filters{
+				Title: "GÓ",
+			},
+			wantCount:     1,
+			wantFirstID:   1,
+			wantFirstName: "Go Lang",
+		},
 		{
 			name: "filtro por autor",
 			filter: &books.Filters{
@@ -87,6 +117,83 @@ func TestBookRepository_GetAll_TableDriven(t *testing.T) {
 			wantFirstID:   1,
 			wantFirstName: "Go Lang",
 		},
+		{
+			name: "filtro por colaborador",
+			filter: &books.Filters{
+				Contributor: "Tradutora",
+			},
+			wantCount:     1,
+			wantFirstID:   2,
+			wantFirstName: "Python",
+		},
+		{
+			name: "colaborador sem acento",
+			filter: &books.Filters{
+				Contributor: "tradutóra y",
+			},
+			wantCount:     1,
+			wantFirstID:   2,
+			wantFirstName: "Python",
+		},
+		{
+			name: "filtro por colaborador e papel",
+			filter: &books.Filters{
+				Contributor:     "Autor X",
+				ContributorRole: books.RoleTranslator,
+			},
+			wantCount: 0,
+		},
+		{
+			name: "filtro por editora",
+			filter: &books.Filters{
+				Publisher: "Editora",
+			},
+			wantCount:     1,
+			wantFirstID:   2,
+			wantFirstName: "Python",
+		},
+		{
+			name: "filtro por idioma e formato",
+			filter: &books.Filters{
+				Language: "PT",
+				Format:   books.Paperback,
+			},
+			wantCount:     1,
+			wantFirstID:   1,
+			wantFirstName: "Go Lang",
+		},
+		{
+			name: "filtro por ano, edição e páginas",
+			filter: &books.Filters{
+				YearFrom: 2000,
+				YearTo:   2021,
+				Edition:  2,
+				PagesMin: 300,
+				PagesMax: 400,
+			},
+			wantCount:     1,
+			wantFirstID:   2,
+			wantFirstName: "Python",
+		},
+		{
+			name: "segunda página mantém o total",
+			filter: &books.Filters{
+				Pagination: pagination.Params{Page: 2, PageSize: 1},
+			},
+			wantCount:     1,
+			wantTotal:     2,
+			wantFirstID:   2,
+			wantFirstName: "Python",
+		},
+		{
+			name: "ordenado por título decrescente",
+			filter: &books.Filters{
+				Pagination: pagination.Params{Sort: "title", Order: pagination.Desc},
+			},
+			wantCount:     2,
+			wantFirstID:   2,
+			wantFirstName: "Python",
+		},
 		{
 			name: "nenhum resultado",
 			filter: &books.Filters{
@@ -95,10 +202,10 @@ func TestBookRepository_GetAll_TableDriven(t *testing.T) {
 			wantCount: 0,
 		},
 		{
-			name: "página ignorada quando WHERE existe",
+			name: "página com filtro",
 			filter: &books.Filters{
-				Title: "Go",
-				Page:  1,
+				Title:      "Go",
+				Pagination: pagination.Params{Page: 1},
 			},
 			wantCount:     1,
 			wantFirstID:   1,
@@ -111,11 +218,19 @@ func TestBookRepository_GetAll_TableDriven(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			got, err := repo.GetAll(ctx, tt.filter)
+			got, meta, err := repo.GetAll(ctx, tt.filter)
 			if err != nil {
 				t.Fatalf("erro inesperado: %v", err)
 			}
 
+			wantTotal := tt.wantTotal
+			if wantTotal == 0 {
+				wantTotal = tt.wantCount
+			}
+			if meta.Total != wantTotal {
+				t.Errorf("total errado: esperava %d, veio %d", wantTotal, meta.Total)
+			}
+
 			if len(got) != tt.wantCount {
 				t.Fatalf("qtde errada: esperava %d, veio %d", tt.wantCount, len(got))
 			}
@@ -141,3 +256,214 @@ func TestBookRepository_GetAll_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestBookRepository_GetAll_PaginaPorLivro(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	// com duas categorias, Go Lang rende duas linhas no join
+	if err := categories.NewCategoryRepository(db).Create(ctx, &categories.Category{ID: 2, Name: "Backend"}); err != nil {
+		t.Fatalf("category: %v", err)
+	}
+	if err := repo.RelationBookCategory(ctx, 1, 2); err != nil {
+		t.Fatalf("relation: %v", err)
+	}
+
+	for page, wantID := range []int64{1, 2} {
+		got, meta, err := repo.GetAll(ctx, &books.Filters{Pagination: pagination.Params{Page: page + 1, PageSize: 1}})
+		if err != nil {
+			t.Fatalf("erro inesperado: %v", err)
+		}
+		if meta.Total != 2 || len(got) != 1 || got[0].ID != wantID {
+			t.Fatalf("página %d: esperava livro %d de 2, veio %+v (total %d)", page+1, wantID, got, meta.Total)
+		}
+	}
+}
+
+func TestBookRepository_GetAll_Subcategorias(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	// Programação > Backend > APIs, com Python só em APIs
+	categoryRepo := categories.NewCategoryRepository(db)
+	for _, c := range []categories.Category{{Name: "Backend", ParentID: 1}, {Name: "APIs", ParentID: 2}} {
+		if err := categoryRepo.Create(ctx, &c); err != nil {
+			t.Fatalf("category: %v", err)
+		}
+	}
+	if err := repo.ReplaceCategories(ctx, 2, []int64{3}); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  *books.Filters
+		wantIDs []int64
+	}{
+		{name: "só a categoria", filter: &books.Filters{Category: "Programação"}, wantIDs: []int64{1}},
+		{name: "com subcategorias", filter: &books.Filters{Category: "Programação", IncludeSubcategories: true}, wantIDs: []int64{1, 2}},
+		{name: "a partir do meio da árvore", filter: &books.Filters{Category: "Backend", IncludeSubcategories: true}, wantIDs: []int64{2}},
+		{name: "categoria inexistente", filter: &books.Filters{Category: "Inexistente", IncludeSubcategories: true}, wantIDs: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, meta, err := repo.GetAll(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if meta.Total != len(tt.wantIDs) || len(got) != len(tt.wantIDs) {
+				t.Fatalf("esperava livros %v, veio %+v (total %d)", tt.wantIDs, got, meta.Total)
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id {
+					t.Errorf("posição %d: esperava livro %d, recebeu %d", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestBookRepository_GetAll_NumeroDeChamada(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	// livros 3 a 6; Go Lang e Python seguem sem classificação
+	for _, callNumber := range []string{"599.9 H23", "005.133 G567", "QA76.73 .G63", "512.5 K1"} {
+		b := &books.Books{Title: "Livro " + callNumber, Description: "D", Content: "C", AuthorID: 1, CallNumber: callNumber}
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("book: %v", err)
+		}
+	}
+
+	dewey500, err := callnumber.NewRange("500", "599")
+	if err != nil {
+		t.Fatalf("range: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  *books.Filters
+		wantIDs []int64
+	}{
+		{
+			name:    "ordem de estante, sem classificação no fim",
+			filter:  &books.Filters{Pagination: pagination.Params{Sort: "call_number"}},
+			wantIDs: []int64{4, 6, 3, 5, 1, 2},
+		},
+		{
+			name:    "intervalo 500 a 599",
+			filter:  &books.Filters{CallNumbers: dewey500, Pagination: pagination.Params{Sort: "call_number"}},
+			wantIDs: []int64{6, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := repo.GetAll(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			var ids []int64
+			for _, b := range got {
+				ids = append(ids, b.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("esperava livros %v, recebeu %v", tt.wantIDs, ids)
+			}
+		})
+	}
+
+	book, err := repo.GetById(ctx, 5)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if book.CallNumber != "QA76.73 .G63" {
+		t.Errorf("esperava número de chamada gravado, recebeu %q", book.CallNumber)
+	}
+}
+
+func TestBookRepository_GetAll_Cursor(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	filter := &books.Filters{Category: "Prog", Pagination: pagination.Params{PageSize: 1, Sort: "title", Order: pagination.Desc}}
+
+	first, meta, err := repo.GetAll(ctx, filter)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(first) != 1 || first[0].Title != "Python" || meta.PrevCursor != "" {
+		t.Fatalf("primeira página inesperada: %+v %+v", first, meta)
+	}
+
+	if filter.Pagination.Cursor, err = pagination.DecodeCursor(meta.NextCursor); err != nil {
+		t.Fatalf("next_cursor: %v", err)
+	}
+
+	second, meta, err := repo.GetAll(ctx, filter)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(second) != 1 || second[0].Title != "Go Lang" || meta.NextCursor != "" || meta.PrevCursor == "" {
+		t.Fatalf("segunda página inesperada: %+v %+v", second, meta)
+	}
+	if meta.Total != 2 {
+		t.Errorf("esperava total 2, recebeu %d", meta.Total)
+	}
+}
+
+func TestBookRepository_Contributors(t *testing.T) {
+	db := database.SetupTestDB()
+	seedData(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	got, _, err := repo.GetAll(ctx, &books.Filters{})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if len(got[0].Contributors) != 1 || got[0].Contributors[0].Role != books.RoleAuthor {
+		t.Errorf("esperava autor principal como colaborador: %+v", got[0].Contributors)
+	}
+
+	want := []books.Contributor{
+		{AuthorID: 1, Name: "Autor X", Role: books.RoleAuthor, Position: 1},
+		{AuthorID: 2, Name: "Tradutora Y", Role: books.RoleTranslator, Position: 2},
+	}
+	if !reflect.DeepEqual(got[1].Contributors, want) {
+		t.Errorf("colaboradores: esperado %+v, veio %+v", want, got[1].Contributors)
+	}
+
+	if got[1].Publisher.Name != "Editora Z" || got[1].Language != "en" || got[1].Format != books.Hardcover {
+		t.Errorf("metadados da edição inesperados: %+v", got[1])
+	}
+
+	update := &books.Books{ID: 2, Title: "Python", Description: "D1", Content: "C1", Contributors: []books.Contributor{
+		{AuthorID: 2, Role: books.RoleEditor},
+	}}
+	if err := update.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if err := repo.Update(ctx, update); err != nil {
+		t.Fatalf("erro ao atualizar: %v", err)
+	}
+
+	found, err := repo.GetById(ctx, 2)
+	if err != nil {
+		t.Fatalf("erro ao buscar: %v", err)
+	}
+
+	if found.AuthorID != 2 || len(found.Contributors) != 1 || found.Contributors[0].Role != books.RoleEditor {
+		t.Errorf("esperava colaboradores substituídos: %+v", found)
+	}
+}
@@ -2,26 +2,78 @@ package books
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
 )
 
+// bookSelect lê um livro por linha. Categorias e colaboradores vêm em
+// consultas próprias, para que livros sem eles também apareçam e nenhum join
+// multiplique as linhas.
+const bookSelect = `SELECT b.id, b.title, COALESCE(b.author_id, 0), b.description, b.content,
+		b.created_at, b.updated_at, b.isbn_10, b.isbn_13, b.call_number,
+		COALESCE(a.id, 0), COALESCE(a.name, ''), COALESCE(a.description, ''),
+		(SELECT COUNT(*) FROM copies cp WHERE cp.book_id = b.id),
+		(SELECT COUNT(*) FROM copies cp WHERE cp.book_id = b.id AND cp.status = 'available'),
+		` + editionSelect + `,
+		` + seriesSelect + `,
+		` + coverSelect + `
+	FROM books b
+	LEFT JOIN authors a ON b.author_id = a.id
+	LEFT JOIN publishers p ON b.publisher_id = p.id
+	LEFT JOIN series s ON b.series_id = s.id
+	` + coverJoins
+
 func (r *BookRepository) GetById(ctx context.Context, id int64) (*Books, error) {
-	bookMap := make(map[int64]*Books)
+	booksMap, err := r.loadBooks(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
 
-	sql := `SELECT b.id, b.title, b.author_id, b.description, b.content,
-		b.created_at, b.updated_at,
-		c.id, c.name, c.created_at,
-		a.id, a.name, a.description
-		FROM book_category bc
-		JOIN books b ON bc.book_id = b.id
-		JOIN categories c ON bc.category_id = c.id
-		JOIN authors a ON b.author_id = a.id
-		WHERE b.id = ?`
+	book, ok := booksMap[id]
+	if !ok {
+		return nil, ErrBookNotFound
+	}
+
+	return book, nil
+}
 
-	rows, err := r.db.QueryContext(ctx, sql, id)
+func (r *BookRepository) GetByIds(ctx context.Context, ids []int64) ([]Books, error) {
+	booksMap, err := r.loadBooks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]Books, 0, len(ids))
+	for _, id := range ids {
+		if b, ok := booksMap[id]; ok {
+			books = append(books, *b)
+		}
+	}
+
+	return books, nil
+}
+
+// loadBooks carrega os livros informados com categorias e colaboradores, em
+// uma consulta para cada, independente de quantos livros forem.
+func (r *BookRepository) loadBooks(ctx context.Context, ids []int64) (map[int64]*Books, error) {
+	booksMap := make(map[int64]*Books, len(ids))
+	if len(ids) == 0 {
+		return booksMap, nil
+	}
+
+	params := make([]any, len(ids))
+	for i, id := range ids {
+		params[i] = id
+	}
+
+	query := bookSelect + `
+	WHERE b.id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)`
+
+	rows, err := r.db.QueryContext(ctx, query, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -29,77 +81,111 @@ func (r *BookRepository) GetById(ctx context.Context, id int64) (*Books, error)
 
 	for rows.Next() {
 		var (
-			bookID, categoryId, authorId, authorID      int64
-			title, description, content                 string
-			categoryName, authorName, authorDesc        string
-			createdAtStr, updatedAtStr, createdAtCatStr string
+			book                       Books
+			author                     authors.Authors
+			createdAtStr, updatedAtStr string
+			isbn10, isbn13, callNumber sql.NullString
+			edition                    editionColumns
+			series                     seriesColumns
+			cover                      coverColumns
 		)
 
-		err := rows.Scan(
-			&bookID, &title, &authorId, &description,
-			&content, &createdAtStr, &updatedAtStr,
-			&categoryId, &categoryName, &createdAtCatStr,
-			&authorID, &authorName, &authorDesc,
-		)
-		if err != nil {
+		if err := rows.Scan(append([]any{
+			&book.ID, &book.Title, &book.AuthorID, &book.Description, &book.Content,
+			&createdAtStr, &updatedAtStr, &isbn10, &isbn13, &callNumber,
+			&author.ID, &author.Name, &author.Description,
+			&book.TotalCopies, &book.AvailableCopies,
+		}, append(append(edition.targets(), series.targets()...), cover.targets()...)...)...); err != nil {
 			return nil, err
 		}
 
-		createdAt, err := time.Parse(time.RFC3339, createdAtStr)
-		if err != nil {
-			createdAt, err = time.Parse("2006-01-02 15:04:05", createdAtStr)
-			if err != nil {
-				return nil, err
-			}
-		}
-		updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
-		if err != nil {
-			updatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAtStr)
-			if err != nil {
-				return nil, err
-			}
+		if book.CreatedAt, err = parseTimestamp(createdAtStr); err != nil {
+			return nil, err
 		}
-		createdAtCat, err := time.Parse(time.RFC3339, createdAtCatStr)
-		if err != nil {
-			createdAtCat, err = time.Parse("2006-01-02 15:04:05", createdAtCatStr)
-			if err != nil {
-				return nil, err
-			}
+		if book.UpdatedAt, err = parseTimestamp(updatedAtStr); err != nil {
+			return nil, err
 		}
 
-		if _, ok := bookMap[bookID]; !ok {
-			bookMap[bookID] = &Books{
-				ID:          bookID,
-				Title:       title,
-				AuthorID:    authorId,
-				Description: description,
-				Content:     content,
-				CreatedAt:   createdAt,
-				UpdatedAt:   updatedAt,
-				Categories:  []categories.Category{},
-				Authors:     authors.Authors{},
-			}
-		}
+		book.Authors = author
+		book.ISBN10 = isbn10.String
+		book.ISBN13 = isbn13.String
+		book.CallNumber = callNumber.String
+		edition.apply(&book)
+		series.apply(&book)
+		cover.apply(&book)
 
-		bookMap[bookID].Categories = append(
-			bookMap[bookID].Categories,
-			categories.Category{
-				ID:        categoryId,
-				Name:      categoryName,
-				CreatedAT: createdAtCat,
-			},
+		booksMap[book.ID] = &book
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadCategories(ctx, booksMap); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadContributors(ctx, booksMap); err != nil {
+		return nil, err
+	}
+
+	return booksMap, nil
+}
+
+// loadCategories busca em uma única consulta as categorias de todos os
+// livros carregados. Livros sem categoria ficam com a lista vazia.
+func (r *BookRepository) loadCategories(ctx context.Context, booksMap map[int64]*Books) error {
+	if len(booksMap) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(booksMap))
+	params := make([]any, 0, len(booksMap))
+
+	for id, b := range booksMap {
+		placeholders = append(placeholders, "?")
+		params = append(params, id)
+		b.Categories = []categories.Category{}
+	}
+
+	query := `SELECT bc.book_id, c.id, c.name, c.created_at
+		FROM book_category bc
+		JOIN categories c ON bc.category_id = c.id
+		WHERE bc.book_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY bc.book_id, c.id`
+
+	rows, err := r.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			bookID       int64
+			c            categories.Category
+			createdAtStr string
 		)
 
-		bookMap[bookID].Authors = authors.Authors{
-			ID:          authorID,
-			Name:        authorName,
-			Description: authorDesc,
+		if err := rows.Scan(&bookID, &c.ID, &c.Name, &createdAtStr); err != nil {
+			return err
+		}
+
+		if c.CreatedAT, err = parseTimestamp(createdAtStr); err != nil {
+			return err
 		}
-	}
 
-	for _, book := range bookMap {
-		return book, nil
+		booksMap[bookID].Categories = append(booksMap[bookID].Categories, c)
 	}
 
-	return nil, nil
+	return rows.Err()
+}
+
+// parseTimestamp aceita as datas como o MySQL e o SQLite as devolvem.
+func parseTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02 15:04:05", s)
 }
@@ -3,6 +3,7 @@ package books_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
@@ -90,3 +91,68 @@ func TestBookRepository_GetById(t *testing.T) {
 		})
 	}
 }
+
+func TestBookRepository_LivroSemCategoria(t *testing.T) {
+	db := database.SetupTestDB()
+	seedDataUnic(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	orphan := &books.Books{Title: "Sem Categoria", Description: "D2", Content: "C2", AuthorID: 1}
+	if err := repo.Create(ctx, orphan); err != nil {
+		t.Fatalf("book: %v", err)
+	}
+
+	got, err := repo.GetById(ctx, orphan.ID)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if got.Title != orphan.Title || got.Categories == nil || len(got.Categories) != 0 || got.Authors.Name != "Autor X" {
+		t.Errorf("livro sem categoria inesperado: %+v", got)
+	}
+
+	all, meta, err := repo.GetAll(ctx, &books.Filters{})
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if meta.Total != 2 || len(all) != 2 || all[1].ID != orphan.ID {
+		t.Errorf("esperava os dois livros na listagem, veio %+v (total %d)", all, meta.Total)
+	}
+	if len(all[0].Categories) != 1 {
+		t.Errorf("esperava a categoria do primeiro livro: %+v", all[0].Categories)
+	}
+
+	filtered, _, err := repo.GetAll(ctx, &books.Filters{Category: "Prog"})
+	if err != nil || len(filtered) != 1 || filtered[0].ID == orphan.ID {
+		t.Errorf("o filtro de categoria não deveria trazer o livro sem categoria: %+v (%v)", filtered, err)
+	}
+
+	if _, err := repo.GetById(ctx, 999); !errors.Is(err, books.ErrBookNotFound) {
+		t.Errorf("esperava ErrBookNotFound, recebeu %v", err)
+	}
+}
+
+func TestBookRepository_GetByIds(t *testing.T) {
+	db := database.SetupTestDB()
+	seedDataUnic(t, db)
+	repo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	if err := repo.Create(ctx, &books.Books{Title: "Rust", Description: "D2", Content: "C2", AuthorID: 1}); err != nil {
+		t.Fatalf("book: %v", err)
+	}
+
+	// a ordem pedida é mantida e IDs inexistentes são ignorados
+	got, err := repo.GetByIds(ctx, []int64{2, 99, 1})
+	if err != nil {
+		t.Fatalf("não esperava erro: %v", err)
+	}
+
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 1 {
+		t.Fatalf("esperava os livros 2 e 1 nessa ordem, recebeu %+v", got)
+	}
+
+	if len(got[1].Categories) != 1 {
+		t.Errorf("esperava as categorias carregadas, recebeu %+v", got[1].Categories)
+	}
+}
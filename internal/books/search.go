@@ -0,0 +1,207 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
+	"github.com/go-sql-driver/mysql"
+)
+
+var ErrEmptyQuery = errors.New("informe o termo de busca")
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+
+	// maxSearchTerms limita o tamanho das expressões enviadas ao índice.
+	maxSearchTerms = 10
+
+	// vocabularyTTL é por quanto tempo o vocabulário das sugestões fica em
+	// memória; títulos e autores novos entram na recarga seguinte.
+	vocabularyTTL = 10 * time.Minute
+)
+
+// SearchResult é uma página da busca. Quando a consulta não encontra nada,
+// Suggestions traz as correções possíveis e, se a primeira encontrar livros,
+// os resultados vêm dela e CorrectedQuery a informa.
+type SearchResult struct {
+	Books          []Books
+	Total          int
+	Suggestions    []string
+	CorrectedQuery string
+}
+
+// searchTerms separa a consulta em palavras sem acento e em minúsculas,
+// descartando pontuação e operadores de busca, para que o texto do usuário
+// nunca vire sintaxe.
+func searchTerms(query string) []string {
+	terms := textsearch.Words(query)
+
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	return terms
+}
+
+func isMySQL(db *sql.DB) bool {
+	_, ok := db.Driver().(*mysql.MySQLDriver)
+	return ok
+}
+
+// SearchIDs devolve os livros que contêm todos os termos no título, na
+// descrição, no conteúdo ou no nome de algum autor, do mais relevante para o
+// menos relevante, e o total de livros encontrados.
+func (r *BookRepository) SearchIDs(ctx context.Context, query string, limit int) ([]int64, int, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, 0, ErrEmptyQuery
+	}
+
+	if r.mysql {
+		return r.searchFullText(ctx, terms, limit)
+	}
+	return r.searchFTS(ctx, terms, limit)
+}
+
+// searchFullText usa os índices FULLTEXT do MySQL; o título pesa mais que os
+// autores, que pesam mais que o restante do texto. No modo booleano cada termo
+// vai com +, e todos precisam aparecer no livro, seja no texto dele ou no nome
+// de algum autor, como no FTS do SQLite.
+func (r *BookRepository) searchFullText(ctx context.Context, terms []string, limit int) ([]int64, int, error) {
+	clauses := make([]string, 0, len(terms))
+	whereArgs := make([]any, 0, 2*len(terms))
+	for _, t := range terms {
+		clauses = append(clauses, `(MATCH(b.title, b.description, b.content) AGAINST(? IN BOOLEAN MODE)
+			OR b.id IN (SELECT ba.book_id FROM book_author ba JOIN authors a ON a.id = ba.author_id
+				WHERE MATCH(a.name) AGAINST(? IN BOOLEAN MODE)))`)
+		whereArgs = append(whereArgs, "+"+t, "+"+t)
+	}
+	where := "WHERE " + strings.Join(clauses, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM books b "+where, whereArgs...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	all := "+" + strings.Join(terms, " +")
+
+	query := `SELECT b.id,
+		MATCH(b.title) AGAINST(? IN BOOLEAN MODE) * 4
+		+ COALESCE((SELECT MAX(MATCH(a.name) AGAINST(? IN BOOLEAN MODE)) FROM book_author ba
+			JOIN authors a ON a.id = ba.author_id WHERE ba.book_id = b.id), 0) * 2
+		+ MATCH(b.title, b.description, b.content) AGAINST(? IN BOOLEAN MODE) AS score
+		FROM books b ` + where + `
+		ORDER BY score DESC, b.id
+		LIMIT ?`
+
+	args := append([]any{all, all, all}, whereArgs...)
+	return r.scanSearch(ctx, total, query, append(args, limit)...)
+}
+
+// searchFTS usa o índice books_fts do SQLite. O FTS4 não tem função de
+// relevância, então a ordem é por camadas: termos no título, depois nos
+// autores, depois na descrição, e por fim só no conteúdo.
+func (r *BookRepository) searchFTS(ctx context.Context, terms []string, limit int) ([]int64, int, error) {
+	match := func(column string) string {
+		parts := make([]string, 0, len(terms))
+		for _, t := range terms {
+			// searchTerms devolve minúsculas, que nunca viram operadores (AND, OR, NOT)
+			term := t
+			if column != "" {
+				term = column + ":" + term
+			}
+			parts = append(parts, term)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM books_fts WHERE books_fts MATCH ?", match("")).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT rowid,
+		(rowid IN (SELECT rowid FROM books_fts WHERE books_fts MATCH ?)) * 4
+		+ (rowid IN (SELECT rowid FROM books_fts WHERE books_fts MATCH ?)) * 2
+		+ (rowid IN (SELECT rowid FROM books_fts WHERE books_fts MATCH ?)) AS score
+		FROM books_fts WHERE books_fts MATCH ?
+		ORDER BY score DESC, rowid
+		LIMIT ?`
+
+	return r.scanSearch(ctx, total, query, match("title"), match("authors"), match("description"), match(""), limit)
+}
+
+func (r *BookRepository) scanSearch(ctx context.Context, total int, query string, args ...any) ([]int64, int, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var (
+			id    int64
+			score float64
+		)
+		if err := rows.Scan(&id, &score); err != nil {
+			return nil, 0, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, total, rows.Err()
+}
+
+// vocabularyCache guarda o vocabulário do acervo, para que cada busca sem
+// resultado não percorra todos os títulos e autores. Uma recarga por vez vai
+// ao banco; as buscas que chegam durante ela esperam o resultado.
+type vocabularyCache struct {
+	mu       sync.Mutex
+	value    textsearch.Vocabulary
+	loadedAt time.Time
+	now      func() time.Time
+}
+
+func (c *vocabularyCache) get(ctx context.Context, load func(context.Context) (textsearch.Vocabulary, error)) (textsearch.Vocabulary, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.value != nil && now.Sub(c.loadedAt) < vocabularyTTL {
+		return c.value, nil
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.value = value
+	c.loadedAt = now
+	return value, nil
+}
+
+// Vocabulary reúne as palavras dos títulos e dos nomes de autores, usadas
+// para sugerir correções de consultas sem resultado.
+func (r *BookRepository) Vocabulary(ctx context.Context) (textsearch.Vocabulary, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT title_search FROM books UNION ALL SELECT name_search FROM authors")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vocabulary := textsearch.Vocabulary{}
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, err
+		}
+		vocabulary.Add(text)
+	}
+	return vocabulary, rows.Err()
+}
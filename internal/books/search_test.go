@@ -0,0 +1,161 @@
+package books_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+func TestBookRepository_SearchIDs(t *testing.T) {
+	db := database.SetupTestDB()
+	ctx := context.Background()
+
+	authorRepo := authors.NewAuthorsRepository(db)
+	repo := books.NewBookRepository(db)
+
+	maria := &authors.Authors{Name: "Maria Clara"}
+	pedro := &authors.Authors{Name: "Pedro Bandeira"}
+	for _, a := range []*authors.Authors{maria, pedro} {
+		if err := authorRepo.Create(ctx, a); err != nil {
+			t.Fatalf("author: %v", err)
+		}
+	}
+
+	seed := []*books.Books{
+		{Title: "A fazenda", Description: "Animais do campo", Content: "O porquinho fugiu do chiqueiro", AuthorID: maria.ID},
+		{Title: "A menina e o porquinho", Description: "Amizade na fazenda", Content: "Era uma vez", AuthorID: maria.ID},
+		{Title: "Droga de americana", Description: "Suspense juvenil", Content: "Os Karas investigam", AuthorID: pedro.ID},
+		{Title: "Histórias do porquinho", Description: "Contos", Content: "Coletânea", AuthorID: pedro.ID},
+	}
+	for _, b := range seed {
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("book: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantIDs   []int64
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:      "título antes do conteúdo",
+			query:     "porquinho",
+			wantIDs:   []int64{seed[1].ID, seed[3].ID, seed[0].ID},
+			wantTotal: 3,
+		},
+		{
+			name:      "todos os termos, ignorando pontuação",
+			query:     "porquinho, menina!",
+			wantIDs:   []int64{seed[1].ID},
+			wantTotal: 1,
+		},
+		{
+			name:      "nome do autor",
+			query:     "bandeira",
+			wantIDs:   []int64{seed[2].ID, seed[3].ID},
+			wantTotal: 2,
+		},
+		{
+			name:      "limite mantém o total",
+			query:     "porquinho",
+			wantIDs:   []int64{seed[1].ID},
+			wantTotal: 3,
+		},
+		{
+			name:      "sem diferenciar acentos nem maiúsculas",
+			query:     "HISTORIAS",
+			wantIDs:   []int64{seed[3].ID},
+			wantTotal: 1,
+		},
+		{
+			name:    "sem termos",
+			query:   " ** ",
+			wantErr: books.ErrEmptyQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := len(tt.wantIDs)
+			if limit == 0 {
+				limit = 10
+			}
+
+			ids, total, err := repo.SearchIDs(ctx, tt.query, limit)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if total != tt.wantTotal {
+				t.Errorf("esperava total %d, recebeu %d", tt.wantTotal, total)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("esperava %v, recebeu %v", tt.wantIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("esperava %v, recebeu %v", tt.wantIDs, ids)
+					break
+				}
+			}
+		})
+	}
+
+	maria.Name = "Maria Lobato"
+	if err := authorRepo.Update(ctx, maria); err != nil {
+		t.Fatalf("erro ao renomear autor: %v", err)
+	}
+
+	ids, _, err := repo.SearchIDs(ctx, "lobato", 10)
+	if err != nil || len(ids) != 2 {
+		t.Errorf("índice não acompanhou o nome do autor: %v (%v)", ids, err)
+	}
+}
+
+func TestBookRepository_Vocabulary(t *testing.T) {
+	db := database.SetupTestDB()
+	ctx := context.Background()
+
+	author := &authors.Authors{Name: "João Guimarães Rosa"}
+	if err := authors.NewAuthorsRepository(db).Create(ctx, author); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+
+	repo := books.NewBookRepository(db)
+	if err := repo.Create(ctx, &books.Books{Title: "Grande Sertão: Veredas", Description: "Romance", Content: "Nonada", AuthorID: author.ID}); err != nil {
+		t.Fatalf("book: %v", err)
+	}
+
+	vocabulary, err := repo.Vocabulary(ctx)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "guimaraes sertao", want: nil},
+		{query: "guimaraez", want: []string{"guimaraes"}},
+		{query: "sertão veredaz", want: []string{"sertao veredas"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := vocabulary.Suggest(tt.query)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("esperava %v, recebeu %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package books
+
+import "database/sql"
+
+// seriesSelect lista a série do livro e os volumes vizinhos, calculados na
+// leitura para refletir inclusões e remoções feitas em /series; depende do
+// LEFT JOIN series s.
+const seriesSelect = `b.series_id, s.name, b.series_volume,
+		(SELECT pv.id FROM books pv WHERE pv.series_id = b.series_id AND pv.series_volume < b.series_volume
+			ORDER BY pv.series_volume DESC LIMIT 1),
+		(SELECT nv.id FROM books nv WHERE nv.series_id = b.series_id AND nv.series_volume > b.series_volume
+			ORDER BY nv.series_volume ASC LIMIT 1)`
+
+// seriesColumns recebe as colunas opcionais de seriesSelect.
+type seriesColumns struct {
+	seriesID, volume, previous, next sql.NullInt64
+	name                             sql.NullString
+}
+
+func (s *seriesColumns) targets() []any {
+	return []any{&s.seriesID, &s.name, &s.volume, &s.previous, &s.next}
+}
+
+func (s *seriesColumns) apply(b *Books) {
+	b.SeriesID = s.seriesID.Int64
+	b.SeriesName = s.name.String
+	b.SeriesVolume = int(s.volume.Int64)
+	b.PreviousVolumeID = s.previous.Int64
+	b.NextVolumeID = s.next.Int64
+}
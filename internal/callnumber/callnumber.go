@@ -0,0 +1,168 @@
+// Package callnumber interpreta números de chamada de estante nos sistemas
+// Dewey (CDD) e da Library of Congress (LCC) e gera chaves que, comparadas
+// byte a byte, seguem a ordem em que os livros ficam na estante.
+package callnumber
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+type Scheme string
+
+const (
+	Dewey Scheme = "dewey"
+	LCC   Scheme = "lcc"
+)
+
+var (
+	ErrInvalid = errors.New("número de chamada invalido; use Dewey (ex.: 823.914 T567h) ou LCC (ex.: QA76.73 .G63 2015)")
+	ErrRange   = errors.New("intervalo de números de chamada invalido")
+)
+
+var (
+	deweyPattern = regexp.MustCompile(`^(\d{3})(?:\.(\d+))?(?:\s+(.+))?$`)
+	// as classes LCC não começam por I, O, W, X nem Y
+	lccPattern  = regexp.MustCompile(`^([A-HJ-NP-VZ][A-Z]{0,2})(?:\s?(\d{1,4})(?:\.(\d+))?)?(?:\s*(\..+|\s.+))?$`)
+	partPattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+)
+
+// CallNumber é um número de chamada já validado. Text é o número como foi
+// informado, sem espaços sobrando; Key é a chave de ordenação na estante.
+type CallNumber struct {
+	Scheme Scheme
+	Text   string
+	Key    string
+}
+
+// A chave começa pelo sistema, para que Dewey e LCC não se misturem, e usa
+// só espaço, dígitos e letras maiúsculas. O espaço separa as partes e, por
+// vir antes de qualquer dígito ou letra, põe "599" antes de "599 A" e este
+// antes de "599.1". keyEnd vem depois de todos eles e fecha os intervalos.
+const (
+	deweyPrefix = "D"
+	lccPrefix   = "L"
+	keyEnd      = "~"
+)
+
+// Parse reconhece o sistema pelo início do número: três dígitos para Dewey,
+// de uma a três letras para LCC.
+func Parse(s string) (CallNumber, error) {
+	text := strings.Join(strings.Fields(s), " ")
+	upper := strings.ToUpper(text)
+
+	var key string
+	var ok bool
+	scheme := Dewey
+
+	if m := deweyPattern.FindStringSubmatch(upper); m != nil {
+		key, ok = deweyKey(m[1], m[2], m[3])
+	} else if m := lccPattern.FindStringSubmatch(upper); m != nil && (m[2] != "" || m[4] == "") {
+		scheme = LCC
+		key, ok = lccKey(m[1], m[2], m[3], m[4])
+	}
+
+	if !ok {
+		return CallNumber{}, ErrInvalid
+	}
+	return CallNumber{Scheme: scheme, Text: text, Key: key}, nil
+}
+
+// Compare ordena dois números de chamada como na estante.
+func Compare(a, b CallNumber) int {
+	return strings.Compare(a.Key, b.Key)
+}
+
+// deweyKey monta a chave de um número Dewey. A classe tem sempre três
+// dígitos, então a parte decimal pode vir logo em seguida: comparar as casas
+// decimais como texto já segue a ordem numérica, desde que sem zeros à
+// direita (823.9 e 823.90 são a mesma classe).
+func deweyKey(class, decimals, rest string) (string, bool) {
+	key := deweyPrefix + class + strings.TrimRight(decimals, "0")
+	return withParts(key, rest)
+}
+
+// lccKey monta a chave de um número LCC. As letras da classe completam três
+// posições com espaço (Q antes de QA) e o número da classe completa quatro
+// dígitos com zeros (QA9 antes de QA76); as casas decimais e os cutters
+// seguem a mesma regra do Dewey.
+func lccKey(letters, number, decimals, rest string) (string, bool) {
+	key := lccPrefix + letters + strings.Repeat(" ", 3-len(letters))
+	if number != "" {
+		key += strings.Repeat("0", 4-len(number)) + number + strings.TrimRight(decimals, "0")
+	}
+	return withParts(key, rest)
+}
+
+// withParts acrescenta à chave os cutters, marcas de obra e anos que vêm
+// depois da classe. Os cutters são decimais (G56 vem antes de G567, que vem
+// antes de G57), o que a comparação de texto já respeita.
+func withParts(key, rest string) (string, bool) {
+	parts := strings.FieldsFunc(rest, func(r rune) bool { return r == ' ' || r == '.' })
+	if rest != "" && len(parts) == 0 {
+		return "", false
+	}
+
+	for _, part := range parts {
+		if !partPattern.MatchString(part) {
+			return "", false
+		}
+		key += " " + part
+	}
+	return key, true
+}
+
+// Range é um intervalo de chaves. Low é inclusivo e High exclusivo; vazios
+// deixam o intervalo aberto naquela ponta.
+type Range struct {
+	Low  string
+	High string
+}
+
+// NewRange monta o intervalo entre duas classes, inclusive, com todos os
+// números que começam por elas: "500" a "599" inclui 599.9 e 599.9 H23. As
+// duas pontas são opcionais, mas quando informadas devem ser do mesmo
+// sistema e estar em ordem.
+func NewRange(from, to string) (Range, error) {
+	var r Range
+	var fromNumber, toNumber CallNumber
+
+	if strings.TrimSpace(from) != "" {
+		n, err := Parse(from)
+		if err != nil {
+			return Range{}, err
+		}
+		fromNumber, r.Low = n, n.Key
+	}
+
+	if strings.TrimSpace(to) != "" {
+		n, err := Parse(to)
+		if err != nil {
+			return Range{}, err
+		}
+		toNumber, r.High = n, n.Key+keyEnd
+	}
+
+	if r.Low != "" && r.High != "" && (fromNumber.Scheme != toNumber.Scheme || r.Low >= r.High) {
+		return Range{}, ErrRange
+	}
+
+	// sem uma das pontas, o intervalo não passa para o outro sistema
+	switch {
+	case r.Low != "" && r.High == "":
+		r.High = r.Low[:1] + keyEnd
+	case r.High != "" && r.Low == "":
+		r.Low = r.High[:1]
+	}
+
+	return r, nil
+}
+
+func (r Range) IsZero() bool {
+	return r.Low == "" && r.High == ""
+}
+
+func (r Range) Contains(n CallNumber) bool {
+	return !r.IsZero() && n.Key >= r.Low && n.Key < r.High
+}
@@ -0,0 +1,139 @@
+package callnumber
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		scheme  Scheme
+		text    string
+		wantErr bool
+	}{
+		{in: "823.914 T567h", scheme: Dewey, text: "823.914 T567h"},
+		{in: "  005.133   G567p  2015 ", scheme: Dewey, text: "005.133 G567p 2015"},
+		{in: "500", scheme: Dewey, text: "500"},
+		{in: "QA76.73 .G63 K47 2015", scheme: LCC, text: "QA76.73 .G63 K47 2015"},
+		{in: "QA76.73.G63", scheme: LCC, text: "QA76.73.G63"},
+		{in: "PS 3545", scheme: LCC, text: "PS 3545"},
+		{in: "QA", scheme: LCC, text: "QA"},
+		{in: "82", wantErr: true},
+		{in: "823.", wantErr: true},
+		{in: "823.914T", wantErr: true},
+		{in: "823.914 T-5", wantErr: true},
+		{in: "IQ76", wantErr: true},
+		{in: "QA .G63", wantErr: true},
+		{in: "QA76.73 .", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("esperava ErrInvalid, recebeu %v (%+v)", err, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got.Scheme != tt.scheme || got.Text != tt.text {
+				t.Errorf("esperava %s %q, recebeu %s %q", tt.scheme, tt.text, got.Scheme, got.Text)
+			}
+		})
+	}
+}
+
+func TestCompare_OrdemDeEstante(t *testing.T) {
+	// em ordem de estante; a ordem lexical poria QA9 depois de QA76
+	shelf := [][]string{
+		{"005.133 G567", "005.133 G57", "005.2", "500", "599", "599 A1", "599.05", "599.1", "599.9 H23", "600"},
+		{"Q", "Q325", "QA9", "QA76 .G63", "QA76.73 .G63", "QA76.73 .G63 2015", "QA76.8", "QA76.8 .A1", "QB1"},
+	}
+
+	for _, want := range shelf {
+		got := slices.Clone(want)
+		rand.New(rand.NewSource(1)).Shuffle(len(got), func(i, j int) { got[i], got[j] = got[j], got[i] })
+
+		slices.SortFunc(got, func(a, b string) int {
+			return Compare(mustParse(t, a), mustParse(t, b))
+		})
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("esperava %v, recebeu %v", want, got)
+		}
+	}
+
+	if Compare(mustParse(t, "823.9"), mustParse(t, "823.90")) != 0 {
+		t.Errorf("823.9 e 823.90 deveriam ser a mesma classe")
+	}
+}
+
+func TestNewRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		in      []string
+		out     []string
+		wantErr error
+	}{
+		{
+			name: "centena inteira",
+			from: "500", to: "599",
+			in:  []string{"500", "512.5 K1", "599", "599.99 Z9"},
+			out: []string{"499.9", "600", "QA76"},
+		},
+		{
+			name: "só o início",
+			from: "800",
+			in:   []string{"800", "999.9"},
+			out:  []string{"799", "PS3545"},
+		},
+		{
+			name: "só o fim, em LCC",
+			to:   "QA76",
+			in:   []string{"A1", "QA76.73 .G63"},
+			out:  []string{"QA77", "500"},
+		},
+		{name: "sistemas diferentes", from: "500", to: "QA76", wantErr: ErrRange},
+		{name: "fora de ordem", from: "600", to: "500", wantErr: ErrRange},
+		{name: "ponta invalida", from: "5", wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRange(tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+
+			for _, s := range tt.in {
+				if !r.Contains(mustParse(t, s)) {
+					t.Errorf("%s deveria estar no intervalo %+v", s, r)
+				}
+			}
+			for _, s := range tt.out {
+				if r.Contains(mustParse(t, s)) {
+					t.Errorf("%s não deveria estar no intervalo %+v", s, r)
+				}
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, s string) CallNumber {
+	t.Helper()
+
+	n, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return n
+}
@@ -4,14 +4,30 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("categoria não encontrada")
+	ErrParentNotFound   = errors.New("categoria pai não encontrada")
+	ErrCategoryCycle    = errors.New("a categoria não pode ficar abaixo dela mesma nem de uma subcategoria sua")
 )
 
 type Category struct {
-	ID        int64
-	Name      string
+	ID   int64
+	Name string
+	// ParentID é a categoria logo acima na árvore, ou 0 nas categorias raiz.
+	ParentID  int64
 	CreatedAT time.Time
 }
 
+// Node é uma categoria com as subcategorias logo abaixo dela.
+type Node struct {
+	Category
+	Children []Node
+}
+
 type CategoryCreator interface {
 	Create(ctx context.Context, c *Category) error
 	Update(ctx context.Context, category *Category) error
@@ -19,8 +35,14 @@ type CategoryCreator interface {
 }
 
 type CategoryRead interface {
-	GetAll(ctx context.Context) ([]Category, error)
+	GetAll(ctx context.Context, p pagination.Params) ([]Category, pagination.Meta, error)
 	GetById(ctx context.Context, id int64) (*Category, error)
+	// Ancestors devolve as categorias acima da informada, da raiz até o pai.
+	Ancestors(ctx context.Context, id int64) ([]Category, error)
+	// Descendants devolve todas as categorias abaixo da informada, nível a
+	// nível.
+	Descendants(ctx context.Context, id int64) ([]Category, error)
+	Tree(ctx context.Context) ([]Node, error)
 }
 
 type ICategoryRepository interface {
@@ -35,3 +57,32 @@ func (c *Category) Validate() error {
 
 	return nil
 }
+
+// BuildTree monta a árvore a partir da lista de categorias. Categorias cujo
+// pai não está na lista entram como raiz.
+func BuildTree(list []Category) []Node {
+	known := make(map[int64]bool, len(list))
+	for _, c := range list {
+		known[c.ID] = true
+	}
+
+	children := make(map[int64][]Category)
+	for _, c := range list {
+		parent := c.ParentID
+		if !known[parent] {
+			parent = 0
+		}
+		children[parent] = append(children[parent], c)
+	}
+
+	var build func(parent int64) []Node
+	build = func(parent int64) []Node {
+		nodes := make([]Node, 0, len(children[parent]))
+		for _, c := range children[parent] {
+			nodes = append(nodes, Node{Category: c, Children: build(c.ID)})
+		}
+		return nodes
+	}
+
+	return build(0)
+}
@@ -3,18 +3,47 @@ package categories
 // @Description Dados para criar categoria
 type CategoryRequest struct {
 	Name string `json:"name" binding:"required" example:"Infantil"`
+	// ParentID coloca a categoria abaixo de outra; ausente ou 0 a deixa na raiz.
+	ParentID int64 `json:"parent_id" example:"1"`
 }
 
 type CategoryResponse struct {
 	ID        int64  `json:"id"`
 	Name      string `json:"name"`
+	ParentID  int64  `json:"parent_id,omitempty"`
 	CreatedAT string `json:"created_at"`
 }
 
+// CategoryNodeResponse é uma categoria da árvore com as subcategorias dela.
+type CategoryNodeResponse struct {
+	CategoryResponse
+	Children []CategoryNodeResponse `json:"children"`
+}
+
 func ToResponse(cat *Category) CategoryResponse {
 	return CategoryResponse{
 		ID:        cat.ID,
 		Name:      cat.Name,
+		ParentID:  cat.ParentID,
 		CreatedAT: cat.CreatedAT.Format("02/01/06 15:04:05"),
 	}
 }
+
+func toListResponse(list []Category) []CategoryResponse {
+	response := make([]CategoryResponse, 0, len(list))
+	for _, cat := range list {
+		response = append(response, ToResponse(&cat))
+	}
+	return response
+}
+
+func toTreeResponse(nodes []Node) []CategoryNodeResponse {
+	response := make([]CategoryNodeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		response = append(response, CategoryNodeResponse{
+			CategoryResponse: ToResponse(&n.Category),
+			Children:         toTreeResponse(n.Children),
+		})
+	}
+	return response
+}
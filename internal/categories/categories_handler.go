@@ -1,9 +1,11 @@
 package categories
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -17,14 +19,29 @@ func NewCategoryHandler(svc CategoryService, log *zap.Logger) *CategoryHandler {
 	return &CategoryHandler{svc: svc, logApp: log}
 }
 
+// categoryError converte os erros do serviço nas respostas da API.
+func categoryError(err error) error {
+	switch {
+	case errors.Is(err, ErrCategoryNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrParentNotFound):
+		return middleware.NotFound.Messager(err.Error())
+	case errors.Is(err, ErrCategoryCycle):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
 // @Summary Cria um nova categoria
 // @Description Recebe um objeto JSON CategoryRequest e salva a categoria no banco de dados.
 // @Tags categories
 // @Accept  json
 // @Produce json
 // @Param   category body CategoryRequest true "Dados da Nova categoria a ser criado"
-// @Success 201 {object} CategoryRequest "Categoria criada com sucesso"
+// @Success 201 {object} CategoryResponse "Categoria criada com sucesso"
 // @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 404 {object} middleware.APIError "Categoria pai não encontrada"
 // @Failure 500 {object} middleware.APIError "Erro interno do servidor"
 // @Security ApiKeyAuth
 // @Router /api/categories [post]
@@ -43,12 +60,13 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	}
 
 	category := &Category{
-		Name: dto.Name,
+		Name:     dto.Name,
+		ParentID: dto.ParentID,
 	}
 
 	if err := h.svc.Create(ctx, category); err != nil {
 		h.logApp.Error("falha ao criar categoria", zap.Error(err))
-		_ = c.Error(middleware.InternalErr)
+		_ = c.Error(categoryError(err))
 		c.Abort()
 		return
 	}
@@ -61,13 +79,26 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 // @Tags categories
 // @Accept json
 // @Produce json
-// @Success 200 {array} CategoryResponse
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 10, máximo 100)"
+// @Param sort query string false "Campo de ordenação" Enums(id, name, created_at)
+// @Param order query string false "Direção da ordenação" Enums(asc, desc)
+// @Param cursor query string false "Cursor de next_cursor ou prev_cursor; substitui page, sort e order"
+// @Success 200 {object} pagination.Page[CategoryResponse]
+// @Failure 400 {object} middleware.APIError "Paginação ou ordenação inválida"
 // @Failure 500 {object} middleware.APIError "Erro interno"
 // @Router /public/api/categories [get]
 func (h *CategoryHandler) ReadCategories(c *gin.Context) {
 	h.logApp.Info("Rota de obter categorias")
 
-	allCategories, err := h.svc.GetAll(c.Request.Context())
+	params, err := pagination.Parse(c.Request.URL.Query(), CategorySorts)
+	if err != nil {
+		h.logApp.Error("falha ao ler paginação", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	allCategories, meta, err := h.svc.GetAll(c.Request.Context(), params)
 	if err != nil {
 		h.logApp.Error("falha ao obter categorias", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
@@ -75,12 +106,7 @@ func (h *CategoryHandler) ReadCategories(c *gin.Context) {
 		return
 	}
 
-	response := make([]CategoryResponse, 0, len(allCategories))
-	for _, cat := range allCategories {
-		response = append(response, ToResponse(&cat))
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, pagination.NewPage(toListResponse(allCategories), meta, params))
 }
 
 // @Summary Obter categoria
@@ -90,6 +116,7 @@ func (h *CategoryHandler) ReadCategories(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Recebe o id da categoria"
 // @Success 200 {object} CategoryResponse
+// @Failure 404 {object} middleware.APIError "Categoria não encontrada"
 // @Failure 500 {object} middleware.APIError "Erro interno"
 // @Router /public/api/categories/{id} [get]
 func (h *CategoryHandler) ReadCategory(c *gin.Context) {
@@ -105,7 +132,7 @@ func (h *CategoryHandler) ReadCategory(c *gin.Context) {
 	category, err := h.svc.GetById(c.Request.Context(), id)
 	if err != nil {
 		h.logApp.Error("falha ao obter categoria", zap.Error(err))
-		_ = c.Error(middleware.NotFound)
+		_ = c.Error(categoryError(err))
 		c.Abort()
 		return
 	}
@@ -113,6 +140,86 @@ func (h *CategoryHandler) ReadCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, ToResponse(category))
 }
 
+// @Summary Árvore de categorias
+// @Description Retorna todas as categorias aninhadas sob as categorias raiz
+// @Tags categories
+// @Produce json
+// @Success 200 {array} CategoryNodeResponse
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/categories/tree [get]
+func (h *CategoryHandler) ReadTree(c *gin.Context) {
+	h.logApp.Info("Rota de obter árvore de categorias")
+
+	tree, err := h.svc.Tree(c.Request.Context())
+	if err != nil {
+		h.logApp.Error("falha ao obter árvore de categorias", zap.Error(err))
+		_ = c.Error(categoryError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toTreeResponse(tree))
+}
+
+// @Summary Categorias acima de uma categoria
+// @Description Retorna a cadeia de categorias da raiz até o pai da categoria
+// @Tags categories
+// @Produce json
+// @Param id path int true "Recebe o id da categoria"
+// @Success 200 {array} CategoryResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Categoria não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/categories/{id}/ancestors [get]
+func (h *CategoryHandler) ReadAncestors(c *gin.Context) {
+	h.logApp.Info("Rota de obter categorias acima")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	ancestors, err := h.svc.Ancestors(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao obter categorias acima", zap.Error(err))
+		_ = c.Error(categoryError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toListResponse(ancestors))
+}
+
+// @Summary Subcategorias de uma categoria
+// @Description Retorna as subcategorias de todos os níveis abaixo da categoria, das mais próximas às mais distantes
+// @Tags categories
+// @Produce json
+// @Param id path int true "Recebe o id da categoria"
+// @Success 200 {array} CategoryResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Categoria não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/categories/{id}/descendants [get]
+func (h *CategoryHandler) ReadDescendants(c *gin.Context) {
+	h.logApp.Info("Rota de obter subcategorias")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	descendants, err := h.svc.Descendants(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao obter subcategorias", zap.Error(err))
+		_ = c.Error(categoryError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toListResponse(descendants))
+}
+
 // @Summary Atualiza uma categoria
 // @Description Recebe um objeto JSON CategoryRequest e atualiza a categoria no banco de dados.
 // @Tags categories
@@ -122,6 +229,8 @@ func (h *CategoryHandler) ReadCategory(c *gin.Context) {
 // @Param   category body CategoryRequest true "Dados da nova categoria a ser atualizada"
 // @Success 204  "Categoria atualizada com sucesso"
 // @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 404 {object} middleware.APIError "Categoria pai não encontrada"
+// @Failure 409 {object} middleware.APIError "O novo pai fecharia um ciclo na árvore"
 // @Failure 500 {object} middleware.APIError "Erro interno do servidor"
 // @Security ApiKeyAuth
 // @Router /api/categories/{id} [put]
@@ -144,14 +253,15 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	}
 
 	updateCategory := &Category{
-		ID:   id,
-		Name: dto.Name,
+		ID:       id,
+		Name:     dto.Name,
+		ParentID: dto.ParentID,
 	}
 
 	err = h.svc.Update(c.Request.Context(), updateCategory)
 	if err != nil {
 		h.logApp.Error("falha ao atualizar categoria", zap.Error(err))
-		_ = c.Error(middleware.InternalErr)
+		_ = c.Error(categoryError(err))
 		return
 	}
 
@@ -159,7 +269,7 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 }
 
 // @Summary Exclui uma categoria pelo ID
-// @Description Exclui uma categoria específica do banco de dados.
+// @Description Exclui uma categoria específica do banco de dados. As subcategorias passam para o pai dela.
 // @Tags categories
 // @Accept  json
 // @Produce json
@@ -167,7 +277,7 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 // @Param   id path int true "ID da categoria a ser excluída"
 // @Success 204 "Nenhum Conteúdo"
 // @Failure 400 {object} middleware.APIError "Requisição Inválida (ID com formato incorreto)"
-// @Failure 404 {object} middleware.APIError "Livro não encontrado"
+// @Failure 404 {object} middleware.APIError "Categoria não encontrada"
 // @Router /api/categories/{id} [delete]
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	h.logApp.Info("Rota de apagar categoria")
@@ -181,7 +291,7 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		h.logApp.Error("falha ao apagar categoria", zap.Error(err))
-		_ = c.Error(middleware.NotFound)
+		_ = c.Error(categoryError(err))
 		return
 	}
 
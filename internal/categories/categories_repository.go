@@ -4,20 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 type CategoryRepository struct {
-	db *sql.DB
+	db        *sql.DB
+	forUpdate string
 }
 
 func NewCategoryRepository(db *sql.DB) *CategoryRepository {
-	return &CategoryRepository{db: db}
+	return &CategoryRepository{db: db, forUpdate: database.ForUpdate(db)}
 }
 
 func (r *CategoryRepository) Create(ctx context.Context, c *Category) error {
-	query := "INSERT INTO categories (name) VALUES (?)"
+	query := "INSERT INTO categories (name, parent_id) VALUES (?, ?)"
 
-	resul, err := r.db.ExecContext(ctx, query, c.Name)
+	resul, err := r.db.ExecContext(ctx, query, c.Name, nullParent(c.ParentID))
 	if err != nil {
 		return err
 	}
@@ -30,70 +34,237 @@ func (r *CategoryRepository) Create(ctx context.Context, c *Category) error {
 	return nil
 }
 
-func (r *CategoryRepository) GetAll(ctx context.Context) ([]Category, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT * FROM categories")
+// CategorySorts são os campos aceitos em sort na listagem de categorias.
+var CategorySorts = pagination.Sorts{
+	Fields:  map[string]string{"id": "id", "name": "name", "created_at": "created_at"},
+	Default: "id",
+	Key:     "id",
+}
+
+func (r *CategoryRepository) GetAll(ctx context.Context, p pagination.Params) ([]Category, pagination.Meta, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM categories").Scan(&total); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	seek, args := p.Seek(CategorySorts)
+	if seek != "" {
+		seek = " WHERE " + seek
+	}
+
+	query := "SELECT id, name, COALESCE(parent_id, 0), created_at, " + CategorySorts.Column(p) + " FROM categories" + seek + p.OrderBy(CategorySorts) + " LIMIT ? OFFSET ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, p.Limit(), p.Offset())...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 	defer rows.Close()
 
 	var categories []Category
+	var positions []pagination.Row
 
 	for rows.Next() {
 		var c Category
-
-		if err := rows.Scan(&c.ID, &c.Name, &c.CreatedAT); err != nil {
-			return nil, err
+		var position pagination.Row
+		if err := rows.Scan(&c.ID, &c.Name, &c.ParentID, &c.CreatedAT, &position.Value); err != nil {
+			return nil, pagination.Meta{}, err
 		}
+		position.Key = c.ID
+
 		categories = append(categories, c)
+		positions = append(positions, position)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Meta{}, err
 	}
 
-	return categories, nil
+	categories, meta := pagination.Window(p, total, categories, positions)
+	return categories, meta, nil
 }
 
 func (r *CategoryRepository) GetById(ctx context.Context, id int64) (*Category, error) {
 	var c Category
 
-	err := r.db.QueryRowContext(ctx, `SELECT * FROM categories WHERE id = ?`, id).
-		Scan(&c.ID, &c.Name, &c.CreatedAT)
+	err := r.db.QueryRowContext(ctx, `SELECT id, name, COALESCE(parent_id, 0), created_at FROM categories WHERE id = ?`, id).
+		Scan(&c.ID, &c.Name, &c.ParentID, &c.CreatedAT)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCategoryNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &c, nil
 }
 
-func (r *CategoryRepository) Update(ctx context.Context, c *Category) error {
-	sql := "UPDATE categories SET name = ? WHERE id = ?"
+// maxDepth limita a descida nas consultas recursivas, para que um ciclo
+// gravado fora da API não as deixe rodando sem fim.
+const maxDepth = 64
+
+// Ancestors sobe a árvore a partir da categoria e devolve a cadeia da raiz
+// até o pai, sem a própria categoria.
+func (r *CategoryRepository) Ancestors(ctx context.Context, id int64) ([]Category, error) {
+	query := `WITH RECURSIVE chain(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0 FROM categories WHERE id = ?
+			UNION ALL
+			SELECT p.id, p.parent_id, chain.depth + 1
+			FROM categories p JOIN chain ON p.id = chain.parent_id
+			WHERE chain.depth < ?
+		)
+		SELECT c.id, c.name, COALESCE(c.parent_id, 0), c.created_at
+		FROM chain JOIN categories c ON c.id = chain.id
+		ORDER BY chain.depth DESC`
+
+	list, err := r.queryCategories(ctx, query, id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+	return list[:len(list)-1], nil
+}
+
+// Descendants desce a árvore a partir da categoria e devolve as
+// subcategorias de todos os níveis, das mais próximas às mais distantes.
+func (r *CategoryRepository) Descendants(ctx context.Context, id int64) ([]Category, error) {
+	query := `WITH RECURSIVE tree(id, depth) AS (
+			SELECT id, 0 FROM categories WHERE id = ?
+			UNION ALL
+			SELECT ch.id, tree.depth + 1
+			FROM categories ch JOIN tree ON ch.parent_id = tree.id
+			WHERE tree.depth < ?
+		)
+		SELECT c.id, c.name, COALESCE(c.parent_id, 0), c.created_at
+		FROM tree JOIN categories c ON c.id = tree.id
+		ORDER BY tree.depth, c.name, c.id`
+
+	list, err := r.queryCategories(ctx, query, id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+	return list[1:], nil
+}
+
+func (r *CategoryRepository) Tree(ctx context.Context) ([]Node, error) {
+	list, err := r.queryCategories(ctx, "SELECT id, name, COALESCE(parent_id, 0), created_at FROM categories ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildTree(list), nil
+}
 
-	result, err := r.db.ExecContext(ctx, sql, c.Name, c.ID)
+func (r *CategoryRepository) queryCategories(ctx context.Context, query string, args ...any) ([]Category, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []Category{}
+	for rows.Next() {
+		var c Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.ParentID, &c.CreatedAT); err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+
+	return list, rows.Err()
+}
+
+// Update grava nome e pai da categoria. A conferência do novo pai e o UPDATE
+// correm na mesma transação, lendo com bloqueio a categoria e cada ancestral
+// do pai: duas trocas simultâneas (A abaixo de B e B abaixo de A) esperam uma
+// pela outra, e a segunda já enxerga o pai gravado pela primeira.
+func (r *CategoryRepository) Update(ctx context.Context, c *Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
-	rowsAffected, err := result.RowsAffected()
+	var id int64
+	err = tx.QueryRowContext(ctx, "SELECT id FROM categories WHERE id = ?"+r.forUpdate, c.ID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCategoryNotFound
+	}
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return errors.New("erro ao atualizar categoria")
+	if err := r.checkParent(ctx, tx, c); err != nil {
+		return err
+	}
+
+	query := "UPDATE categories SET name = ?, parent_id = ? WHERE id = ?"
+	if _, err := tx.ExecContext(ctx, query, c.Name, nullParent(c.ParentID), c.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkParent sobe do novo pai até a raiz, uma categoria por vez; achar a
+// própria categoria no caminho fecharia um ciclo na árvore.
+func (r *CategoryRepository) checkParent(ctx context.Context, tx *sql.Tx, c *Category) error {
+	id := c.ParentID
+	for depth := 0; id > 0 && depth <= maxDepth; depth++ {
+		if id == c.ID {
+			return ErrCategoryCycle
+		}
+
+		var parentID sql.NullInt64
+		err := tx.QueryRowContext(ctx, "SELECT parent_id FROM categories WHERE id = ?"+r.forUpdate, id).Scan(&parentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		id = parentID.Int64
 	}
 	return nil
 }
 
+// Delete apaga a categoria e passa as subcategorias dela para o pai, para
+// que nenhum ramo se solte da árvore.
 func (r *CategoryRepository) Delete(ctx context.Context, id int64) error {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM categories WHERE id = ?", id)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 
-	rowsAffected, err := result.RowsAffected()
+	var parentID sql.NullInt64
+	err = tx.QueryRowContext(ctx, "SELECT parent_id FROM categories WHERE id = ?", id).Scan(&parentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCategoryNotFound
+	}
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return errors.New("erro ao apagar categoria")
+	if _, err := tx.ExecContext(ctx, "UPDATE categories SET parent_id = ? WHERE parent_id = ?", parentID, id); err != nil {
+		return err
 	}
-	return nil
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM categories WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// nullParent grava as categorias raiz com parent_id NULL.
+func nullParent(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id > 0}
 }
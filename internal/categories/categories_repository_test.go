@@ -2,10 +2,13 @@ package categories_test
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 func TestCategoryRepository_Create(t *testing.T) {
@@ -63,3 +66,157 @@ func TestCategoryRepository_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoryRepository_GetAll_CursorPorData(t *testing.T) {
+	db := database.SetupTestDB()
+	r := categories.NewCategoryRepository(db)
+	ctx := context.Background()
+
+	for _, name := range []string{"Infantil", "Romance", "Poesia"} {
+		if err := r.Create(ctx, &categories.Category{Name: name}); err != nil {
+			t.Fatalf("category: %v", err)
+		}
+	}
+
+	// criadas no mesmo segundo, o desempate pelo id decide a ordem
+	params := pagination.Params{PageSize: 2, Sort: "created_at", Order: pagination.Desc}
+
+	var got []string
+	for range 3 {
+		page, meta, err := r.GetAll(ctx, params)
+		if err != nil {
+			t.Fatalf("erro inesperado: %v", err)
+		}
+		for _, c := range page {
+			got = append(got, c.Name)
+		}
+
+		if meta.NextCursor == "" {
+			break
+		}
+		if params.Cursor, err = pagination.DecodeCursor(meta.NextCursor); err != nil {
+			t.Fatalf("next_cursor: %v", err)
+		}
+	}
+
+	if want := []string{"Poesia", "Romance", "Infantil"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("esperava %v, recebeu %v", want, got)
+	}
+}
+
+func TestCategoryRepository_Arvore(t *testing.T) {
+	db := database.SetupTestDB()
+	r := categories.NewCategoryRepository(db)
+	ctx := context.Background()
+
+	// 1 Ficção > 2 Fantasia > 3 Fantasia Urbana, 4 Poesia na raiz
+	for _, c := range []categories.Category{
+		{Name: "Ficção"}, {Name: "Fantasia", ParentID: 1}, {Name: "Fantasia Urbana", ParentID: 2}, {Name: "Poesia"},
+	} {
+		if err := r.Create(ctx, &c); err != nil {
+			t.Fatalf("category: %v", err)
+		}
+	}
+
+	names := func(list []categories.Category) []string {
+		out := []string{}
+		for _, c := range list {
+			out = append(out, c.Name)
+		}
+		return out
+	}
+
+	ancestors, err := r.Ancestors(ctx, 3)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if want := []string{"Ficção", "Fantasia"}; !reflect.DeepEqual(names(ancestors), want) {
+		t.Errorf("ancestrais: esperava %v, recebeu %v", want, names(ancestors))
+	}
+
+	descendants, err := r.Descendants(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if want := []string{"Fantasia", "Fantasia Urbana"}; !reflect.DeepEqual(names(descendants), want) {
+		t.Errorf("descendentes: esperava %v, recebeu %v", want, names(descendants))
+	}
+
+	if _, err := r.Ancestors(ctx, 99); !errors.Is(err, categories.ErrCategoryNotFound) {
+		t.Errorf("esperava ErrCategoryNotFound, recebeu %v", err)
+	}
+	if _, err := r.Descendants(ctx, 99); !errors.Is(err, categories.ErrCategoryNotFound) {
+		t.Errorf("esperava ErrCategoryNotFound, recebeu %v", err)
+	}
+
+	tree, err := r.Tree(ctx)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(tree) != 2 || tree[0].Name != "Ficção" || tree[1].Name != "Poesia" {
+		t.Fatalf("raízes inesperadas: %+v", tree)
+	}
+	if len(tree[0].Children) != 1 || len(tree[0].Children[0].Children) != 1 ||
+		tree[0].Children[0].Children[0].Name != "Fantasia Urbana" {
+		t.Errorf("ramo de Ficção inesperado: %+v", tree[0])
+	}
+
+	// ao apagar Fantasia, Fantasia Urbana sobe para Ficção
+	if err := r.Delete(ctx, 2); err != nil {
+		t.Fatalf("erro ao apagar: %v", err)
+	}
+	moved, err := r.GetById(ctx, 3)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if moved.ParentID != 1 {
+		t.Errorf("esperava parent_id 1, recebeu %d", moved.ParentID)
+	}
+
+	if err := r.Delete(ctx, 2); !errors.Is(err, categories.ErrCategoryNotFound) {
+		t.Errorf("esperava ErrCategoryNotFound, recebeu %v", err)
+	}
+}
+
+func TestCategoryRepository_Update_Pai(t *testing.T) {
+	db := database.SetupTestDB()
+	r := categories.NewCategoryRepository(db)
+	ctx := context.Background()
+
+	// 1 Ficção > 2 Fantasia, 3 Poesia na raiz
+	for _, c := range []categories.Category{
+		{Name: "Ficção"}, {Name: "Fantasia", ParentID: 1}, {Name: "Poesia"},
+	} {
+		if err := r.Create(ctx, &c); err != nil {
+			t.Fatalf("category: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		input   categories.Category
+		wantErr error
+	}{
+		{name: "move para outro ramo", input: categories.Category{ID: 1, Name: "Ficção", ParentID: 3}},
+		{name: "pai é a própria categoria", input: categories.Category{ID: 2, Name: "Fantasia", ParentID: 2}, wantErr: categories.ErrCategoryCycle},
+		{name: "pai é uma subcategoria", input: categories.Category{ID: 3, Name: "Poesia", ParentID: 2}, wantErr: categories.ErrCategoryCycle},
+		{name: "pai inexistente", input: categories.Category{ID: 2, Name: "Fantasia", ParentID: 99}, wantErr: categories.ErrParentNotFound},
+		{name: "categoria inexistente", input: categories.Category{ID: 99, Name: "Contos"}, wantErr: categories.ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Update(ctx, &tt.input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava %v, recebeu %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// as recusas não gravaram nada: Poesia continua na raiz
+	poesia, err := r.GetById(ctx, 3)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if poesia.ParentID != 0 {
+		t.Errorf("esperava Poesia na raiz, recebeu parent_id %d", poesia.ParentID)
+	}
+}
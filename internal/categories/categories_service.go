@@ -1,6 +1,11 @@
 package categories
 
-import "context"
+import (
+	"context"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
 
 type CategoryService interface {
 	ICategoryRepository
@@ -19,11 +24,15 @@ func (s *serviceCategory) Create(ctx context.Context, c *Category) error {
 		return err
 	}
 
+	if err := s.checkParent(ctx, c); err != nil {
+		return err
+	}
+
 	return s.cat.Create(ctx, c)
 }
 
-func (s *serviceCategory) GetAll(ctx context.Context) ([]Category, error) {
-	return s.cat.GetAll(ctx)
+func (s *serviceCategory) GetAll(ctx context.Context, p pagination.Params) ([]Category, pagination.Meta, error) {
+	return s.cat.GetAll(ctx, p)
 }
 
 func (s *serviceCategory) GetById(ctx context.Context, id int64) (*Category, error) {
@@ -39,9 +48,36 @@ func (s *serviceCategory) Update(ctx context.Context, c *Category) error {
 		return err
 	}
 
+	// o repositório confere o pai e o ciclo na mesma transação do UPDATE
 	return s.cat.Update(ctx, c)
 }
 
+// checkParent confere se o pai de uma categoria nova existe. Ela ainda não
+// tem subcategorias, então não há ciclo possível.
+func (s *serviceCategory) checkParent(ctx context.Context, c *Category) error {
+	if c.ParentID == 0 {
+		return nil
+	}
+
+	_, err := s.cat.GetById(ctx, c.ParentID)
+	if errors.Is(err, ErrCategoryNotFound) {
+		return ErrParentNotFound
+	}
+	return err
+}
+
+func (s *serviceCategory) Ancestors(ctx context.Context, id int64) ([]Category, error) {
+	return s.cat.Ancestors(ctx, id)
+}
+
+func (s *serviceCategory) Descendants(ctx context.Context, id int64) ([]Category, error) {
+	return s.cat.Descendants(ctx, id)
+}
+
+func (s *serviceCategory) Tree(ctx context.Context) ([]Node, error) {
+	return s.cat.Tree(ctx)
+}
+
 func (s *serviceCategory) Delete(ctx context.Context, id int64) error {
 	err := s.cat.Delete(ctx, id)
 	if err != nil {
@@ -54,3 +54,41 @@ func Test_serviceCategory_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_serviceCategory_Update_Pai(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *Category
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:  "move para outro ramo",
+			input: &Category{ID: 3, Name: "Fantasia Urbana", ParentID: 4},
+		},
+		{
+			name:    "pai é uma subcategoria",
+			input:   &Category{ID: 1, Name: "Ficção", ParentID: 3},
+			repoErr: ErrCategoryCycle,
+			wantErr: ErrCategoryCycle,
+		},
+		{
+			name:    "pai inexistente",
+			input:   &Category{ID: 1, Name: "Ficção", ParentID: 99},
+			repoErr: ErrParentNotFound,
+			wantErr: ErrParentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCategoryRepo)
+
+			mockRepo.On("Update", mock.Anything, tt.input).Return(tt.repoErr)
+
+			err := NewCategoryService(mockRepo).Update(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package categories
 import (
 	"context"
 
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,9 +16,9 @@ func (m *MockCategoryRepo) Create(ctx context.Context, cat *Category) error {
 	return args.Error(0)
 }
 
-func (m *MockCategoryRepo) GetAll(ctx context.Context) ([]Category, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]Category), args.Error(1)
+func (m *MockCategoryRepo) GetAll(ctx context.Context, p pagination.Params) ([]Category, pagination.Meta, error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).([]Category), args.Get(1).(pagination.Meta), args.Error(2)
 }
 
 func (m *MockCategoryRepo) GetById(ctx context.Context, id int64) (*Category, error) {
@@ -43,3 +44,27 @@ func (m *MockCategoryRepo) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockCategoryRepo) Ancestors(ctx context.Context, id int64) ([]Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Category), args.Error(1)
+}
+
+func (m *MockCategoryRepo) Descendants(ctx context.Context, id int64) ([]Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Category), args.Error(1)
+}
+
+func (m *MockCategoryRepo) Tree(ctx context.Context) ([]Node, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Node), args.Error(1)
+}
@@ -0,0 +1,119 @@
+package copies
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	Available Status = "available"
+	OnLoan    Status = "on_loan"
+	Lost      Status = "lost"
+	InRepair  Status = "in_repair"
+	// OnHold indica um exemplar separado para o primeiro da fila de reservas.
+	OnHold Status = "on_hold"
+)
+
+type Condition string
+
+const (
+	New     Condition = "new"
+	Good    Condition = "good"
+	Fair    Condition = "fair"
+	Poor    Condition = "poor"
+	Damaged Condition = "damaged"
+)
+
+var (
+	ErrCopyNotFound = errors.New("exemplar não encontrado")
+	ErrCopyOnLoan   = errors.New("exemplar está emprestado ou reservado")
+)
+
+type Copies struct {
+	ID            int64
+	BookID        int64
+	Barcode       string
+	ShelfLocation string
+	Condition     Condition
+	Status        Status
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type CopyCreator interface {
+	Create(ctx context.Context, c *Copies) error
+	Update(ctx context.Context, c *Copies) error
+	Delete(ctx context.Context, bookID, id int64) error
+}
+
+type CopyRead interface {
+	GetByBook(ctx context.Context, bookID int64) ([]Copies, error)
+	GetById(ctx context.Context, bookID, id int64) (*Copies, error)
+}
+
+// HoldQueue movimenta a fila de reservas do livro na mesma transação que
+// muda o status do exemplar. É implementada por holds.Queue.
+type HoldQueue interface {
+	// Release entrega o exemplar que voltou à estante ao primeiro da fila.
+	Release(ctx context.Context, tx *sql.Tx, bookID, copyID int64) error
+	// Withdraw devolve à fila a reserva pronta que aguardava o exemplar.
+	Withdraw(ctx context.Context, tx *sql.Tx, bookID, copyID int64) error
+}
+
+type ICopyRepository interface {
+	CopyCreator
+	CopyRead
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case Available, OnLoan, Lost, InRepair, OnHold:
+		return true
+	}
+	return false
+}
+
+// Managed informa se o status é controlado pelos empréstimos e reservas,
+// e não pode ser definido manualmente.
+func (s Status) Managed() bool {
+	return s == OnLoan || s == OnHold
+}
+
+// canLeaveFor informa se um exemplar emprestado ou separado pode sair de
+// circulação para o status informado.
+func (s Status) canLeaveFor(to Status) bool {
+	return to == Lost || (s == OnHold && to == InRepair)
+}
+
+func (c Condition) Valid() bool {
+	switch c {
+	case New, Good, Fair, Poor, Damaged:
+		return true
+	}
+	return false
+}
+
+func (c *Copies) Validate() error {
+	if c.BookID <= 0 {
+		return errors.New("livro invalido")
+	}
+
+	if strings.TrimSpace(c.Barcode) == "" {
+		return errors.New("codigo de barras em branco")
+	}
+
+	if !c.Condition.Valid() {
+		return errors.New("estado de conservação invalido")
+	}
+
+	if !c.Status.Valid() {
+		return errors.New("status invalido")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package copies
+
+// @Description Dados de um exemplar físico do livro
+type CopyRequest struct {
+	Barcode       string    `json:"barcode" binding:"required" example:"0001234567"`
+	ShelfLocation string    `json:"shelf_location" example:"Estante 3, prateleira B"`
+	Condition     Condition `json:"condition" example:"good"`
+	Status        Status    `json:"status" example:"available"`
+}
+
+type CopyResponse struct {
+	ID            int64     `json:"id"`
+	BookID        int64     `json:"book_id"`
+	Barcode       string    `json:"barcode"`
+	ShelfLocation string    `json:"shelf_location"`
+	Condition     Condition `json:"condition"`
+	Status        Status    `json:"status"`
+	CreatedAt     string    `json:"created_at"`
+	UpdatedAt     string    `json:"updated_at"`
+}
+
+func ToResponse(c *Copies) CopyResponse {
+	return CopyResponse{
+		ID:            c.ID,
+		BookID:        c.BookID,
+		Barcode:       c.Barcode,
+		ShelfLocation: c.ShelfLocation,
+		Condition:     c.Condition,
+		Status:        c.Status,
+		CreatedAt:     c.CreatedAt.Format("02/01/06 15:04:05"),
+		UpdatedAt:     c.UpdatedAt.Format("02/01/06 15:04:05"),
+	}
+}
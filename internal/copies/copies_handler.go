@@ -0,0 +1,239 @@
+package copies
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CopyHandler struct {
+	svc    CopyService
+	logApp *zap.Logger
+}
+
+func NewCopyHandler(svc CopyService, logApp *zap.Logger) *CopyHandler {
+	return &CopyHandler{svc: svc, logApp: logApp}
+}
+
+func copyError(err error) error {
+	switch {
+	case errors.Is(err, ErrCopyNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrCopyOnLoan):
+		return middleware.Conflict.Messager(ErrCopyOnLoan.Error())
+	case errors.Is(err, ErrStatusManagedByLoans):
+		return middleware.BadRequest.Messager(ErrStatusManagedByLoans.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Cadastra um exemplar
+// @Description Recebe um objeto JSON CopyRequest e cadastra um exemplar físico do livro. Um exemplar disponível vai direto para o primeiro da fila de reservas, se houver.
+// @Tags copies
+// @Accept  json
+// @Produce json
+// @Param   id path int true "ID do livro"
+// @Param   copy body CopyRequest true "Dados do exemplar"
+// @Success 201 {object} CopyResponse "Exemplar criado com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/copies [post]
+func (h *CopyHandler) CreateCopy(c *gin.Context) {
+	h.logApp.Info("Rota de criar exemplar")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	var dto CopyRequest
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	newCopy := &Copies{
+		BookID:        bookID,
+		Barcode:       dto.Barcode,
+		ShelfLocation: dto.ShelfLocation,
+		Condition:     dto.Condition,
+		Status:        dto.Status,
+	}
+
+	if err := h.svc.Create(c.Request.Context(), newCopy); err != nil {
+		h.logApp.Error("falha ao criar exemplar", zap.Error(err))
+		_ = c.Error(copyError(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(newCopy))
+}
+
+// @Summary Listar exemplares
+// @Description Retorna os exemplares físicos de um livro.
+// @Tags copies
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Success 200 {array} CopyResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/books/{id}/copies [get]
+func (h *CopyHandler) ReadCopies(c *gin.Context) {
+	h.logApp.Info("Rota de obter exemplares")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	copies, err := h.svc.GetByBook(c.Request.Context(), bookID)
+	if err != nil {
+		h.logApp.Error("falha ao obter exemplares", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	response := make([]CopyResponse, 0, len(copies))
+	for _, cp := range copies {
+		response = append(response, ToResponse(&cp))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Obter exemplar
+// @Description Retorna um exemplar específico do livro.
+// @Tags copies
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Param copyId path int true "ID do exemplar"
+// @Success 200 {object} CopyResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Exemplar não encontrado"
+// @Router /public/api/books/{id}/copies/{copyId} [get]
+func (h *CopyHandler) ReadCopy(c *gin.Context) {
+	h.logApp.Info("Rota de obter exemplar")
+
+	bookID, copyID, ok := h.copyParams(c)
+	if !ok {
+		return
+	}
+
+	found, err := h.svc.GetById(c.Request.Context(), bookID, copyID)
+	if err != nil {
+		h.logApp.Error("falha ao obter exemplar", zap.Error(err))
+		_ = c.Error(copyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(found))
+}
+
+// @Summary Atualiza um exemplar
+// @Description Recebe um objeto JSON CopyRequest e atualiza o exemplar. Um exemplar separado para reserva que vai para perdido ou reparo devolve a reserva à frente da fila.
+// @Tags copies
+// @Accept  json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Param copyId path int true "ID do exemplar"
+// @Param copy body CopyRequest true "Dados do exemplar"
+// @Success 204 "Exemplar atualizado com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida"
+// @Failure 404 {object} middleware.APIError "Exemplar não encontrado"
+// @Failure 409 {object} middleware.APIError "Exemplar emprestado"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/copies/{copyId} [put]
+func (h *CopyHandler) UpdateCopy(c *gin.Context) {
+	h.logApp.Info("Rota de atualizar exemplar")
+
+	bookID, copyID, ok := h.copyParams(c)
+	if !ok {
+		return
+	}
+
+	var dto CopyRequest
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	updateCopy := &Copies{
+		ID:            copyID,
+		BookID:        bookID,
+		Barcode:       dto.Barcode,
+		ShelfLocation: dto.ShelfLocation,
+		Condition:     dto.Condition,
+		Status:        dto.Status,
+	}
+
+	if err := h.svc.Update(c.Request.Context(), updateCopy); err != nil {
+		h.logApp.Error("falha ao atualizar exemplar", zap.Error(err))
+		_ = c.Error(copyError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Exclui um exemplar
+// @Description Exclui um exemplar que não esteja emprestado nem separado para reserva. Os empréstimos antigos do exemplar continuam no histórico.
+// @Tags copies
+// @Accept  json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Param copyId path int true "ID do exemplar"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Exemplar não encontrado"
+// @Failure 409 {object} middleware.APIError "Exemplar emprestado"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/copies/{copyId} [delete]
+func (h *CopyHandler) DeleteCopy(c *gin.Context) {
+	h.logApp.Info("Rota de apagar exemplar")
+
+	bookID, copyID, ok := h.copyParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), bookID, copyID); err != nil {
+		h.logApp.Error("falha ao apagar exemplar", zap.Error(err))
+		_ = c.Error(copyError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CopyHandler) copyParams(c *gin.Context) (int64, int64, bool) {
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return 0, 0, false
+	}
+
+	copyID, err := middleware.GetIdParamByName(c, "copyId")
+	if err != nil {
+		h.logApp.Error("falha ao verificar id do exemplar", zap.Error(err))
+		_ = c.Error(err)
+		return 0, 0, false
+	}
+
+	return bookID, copyID, true
+}
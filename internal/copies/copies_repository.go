@@ -0,0 +1,185 @@
+package copies
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+type CopyRepository struct {
+	db        *sql.DB
+	queue     HoldQueue
+	forUpdate string
+}
+
+func NewCopyRepository(db *sql.DB, queue HoldQueue) *CopyRepository {
+	return &CopyRepository{db: db, queue: queue, forUpdate: database.ForUpdate(db)}
+}
+
+// Create grava o exemplar. Um exemplar disponível passa antes pela fila de
+// reservas do livro, na mesma transação, e pode sair dela já separado.
+func (r *CopyRepository) Create(ctx context.Context, c *Copies) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `INSERT INTO copies (book_id, barcode, shelf_location, physical_condition, status)
+		VALUES (?, ?, ?, ?, ?)`
+
+	result, err := tx.ExecContext(ctx, query, c.BookID, c.Barcode, c.ShelfLocation, c.Condition, c.Status)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if c.Status == Available {
+		if err := r.release(ctx, tx, c.BookID, id, &c.Status); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	c.ID = id
+	return nil
+}
+
+// Update grava os dados do exemplar, na mesma transação que movimenta a fila:
+// um exemplar separado que sai de circulação devolve a reserva que o
+// aguardava, e um que volta a ficar disponível vai para o primeiro da fila.
+func (r *CopyRepository) Update(ctx context.Context, c *Copies) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var current Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM copies WHERE id = ? AND book_id = ?"+r.forUpdate, c.ID, c.BookID).
+		Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCopyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE copies SET barcode = ?, shelf_location = ?, physical_condition = ?, status = ?,
+		updated_at = CURRENT_TIMESTAMP WHERE id = ? AND book_id = ?`
+
+	if _, err := tx.ExecContext(ctx, query, c.Barcode, c.ShelfLocation, c.Condition, c.Status, c.ID, c.BookID); err != nil {
+		return err
+	}
+
+	if current == OnHold && c.Status != OnHold {
+		if err := r.queue.Withdraw(ctx, tx, c.BookID, c.ID); err != nil {
+			return err
+		}
+	}
+
+	if c.Status == Available && current != Available {
+		if err := r.release(ctx, tx, c.BookID, c.ID, &c.Status); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// release entrega o exemplar à fila e relê o status com que ele ficou.
+func (r *CopyRepository) release(ctx context.Context, tx *sql.Tx, bookID, id int64, status *Status) error {
+	if err := r.queue.Release(ctx, tx, bookID, id); err != nil {
+		return err
+	}
+
+	return tx.QueryRowContext(ctx, "SELECT status FROM copies WHERE id = ?", id).Scan(status)
+}
+
+// Delete apaga o exemplar que não esteja emprestado nem separado. Os
+// empréstimos antigos continuam no histórico, sem o exemplar (copy_id NULL).
+func (r *CopyRepository) Delete(ctx context.Context, bookID, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var current Status
+	err = tx.QueryRowContext(ctx, "SELECT status FROM copies WHERE id = ? AND book_id = ?"+r.forUpdate, id, bookID).
+		Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCopyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if current.Managed() {
+		return ErrCopyOnLoan
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM copies WHERE id = ? AND book_id = ?", id, bookID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *CopyRepository) GetByBook(ctx context.Context, bookID int64) ([]Copies, error) {
+	query := `SELECT id, book_id, barcode, shelf_location, physical_condition, status, created_at, updated_at
+		FROM copies WHERE book_id = ? ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var copies []Copies
+
+	for rows.Next() {
+		var c Copies
+		if err := rows.Scan(
+			&c.ID, &c.BookID, &c.Barcode, &c.ShelfLocation, &c.Condition, &c.Status, &c.CreatedAt, &c.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		copies = append(copies, c)
+	}
+
+	return copies, rows.Err()
+}
+
+func (r *CopyRepository) GetById(ctx context.Context, bookID, id int64) (*Copies, error) {
+	query := `SELECT id, book_id, barcode, shelf_location, physical_condition, status, created_at, updated_at
+		FROM copies WHERE id = ? AND book_id = ?`
+
+	var c Copies
+	err := r.db.QueryRowContext(ctx, query, id, bookID).Scan(
+		&c.ID, &c.BookID, &c.Barcode, &c.ShelfLocation, &c.Condition, &c.Status, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCopyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
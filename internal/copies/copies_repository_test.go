@@ -0,0 +1,83 @@
+package copies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/holds"
+)
+
+func TestCopyRepository_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []*copies.Copies
+		wantErr bool
+	}{
+		{
+			name: "sucesso",
+			input: []*copies.Copies{
+				{BookID: 1, Barcode: "0001", ShelfLocation: "A1", Condition: copies.Good, Status: copies.Available},
+			},
+			wantErr: false,
+		},
+		{
+			name: "erro codigo de barras duplicado",
+			input: []*copies.Copies{
+				{BookID: 1, Barcode: "0001", Condition: copies.Good, Status: copies.Available},
+				{BookID: 1, Barcode: "0001", Condition: copies.Good, Status: copies.Available},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := database.SetupTestDB()
+			ctx := context.Background()
+
+			if err := authors.NewAuthorsRepository(db).Create(ctx, &authors.Authors{Name: "Autor X", Description: "Teste"}); err != nil {
+				t.Fatalf("author: %v", err)
+			}
+			if err := books.NewBookRepository(db).Create(ctx, &books.Books{Title: "Go Lang", Description: "D1", Content: "C1", AuthorID: 1}); err != nil {
+				t.Fatalf("book: %v", err)
+			}
+
+			r := copies.NewCopyRepository(db, holds.NewQueue())
+
+			var err error
+			for _, c := range tt.input {
+				if err = r.Create(ctx, c); err != nil {
+					break
+				}
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, mais não ocorreu: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("não esperava erro, mais ocorreu: %v", err)
+			}
+
+			found, err := r.GetById(ctx, 1, tt.input[0].ID)
+			if err != nil {
+				t.Fatalf("erro ao buscar: %v", err)
+			}
+
+			if found.Barcode != tt.input[0].Barcode {
+				t.Errorf("esperava barcode=%s, recebeu=%s", tt.input[0].Barcode, found.Barcode)
+			}
+
+			if _, err := r.GetById(ctx, 2, tt.input[0].ID); !errors.Is(err, copies.ErrCopyNotFound) {
+				t.Errorf("esperava ErrCopyNotFound para outro livro, recebeu %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package copies
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrStatusManagedByLoans = errors.New("status on_loan e on_hold são controlados pelos empréstimos e reservas")
+
+type CopyService interface {
+	ICopyRepository
+}
+
+type serviceCopy struct {
+	repo ICopyRepository
+}
+
+func NewCopyService(repo ICopyRepository) *serviceCopy {
+	return &serviceCopy{repo: repo}
+}
+
+func (s *serviceCopy) Create(ctx context.Context, c *Copies) error {
+	if c.Condition == "" {
+		c.Condition = Good
+	}
+
+	if c.Status == "" {
+		c.Status = Available
+	}
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if c.Status.Managed() {
+		return ErrStatusManagedByLoans
+	}
+
+	return s.repo.Create(ctx, c)
+}
+
+func (s *serviceCopy) GetByBook(ctx context.Context, bookID int64) ([]Copies, error) {
+	return s.repo.GetByBook(ctx, bookID)
+}
+
+func (s *serviceCopy) GetById(ctx context.Context, bookID, id int64) (*Copies, error) {
+	return s.repo.GetById(ctx, bookID, id)
+}
+
+// Update altera os dados de um exemplar. Um exemplar emprestado só pode ser
+// marcado como perdido, e um separado para reserva, como perdido ou em
+// reparo; a liberação é feita pelo empréstimo ou pela reserva.
+func (s *serviceCopy) Update(ctx context.Context, c *Copies) error {
+	current, err := s.repo.GetById(ctx, c.BookID, c.ID)
+	if err != nil {
+		return err
+	}
+
+	if c.Condition == "" {
+		c.Condition = current.Condition
+	}
+
+	if c.Status == "" {
+		c.Status = current.Status
+	}
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if current.Status.Managed() && c.Status != current.Status && !current.Status.canLeaveFor(c.Status) {
+		return ErrCopyOnLoan
+	}
+
+	if !current.Status.Managed() && c.Status.Managed() {
+		return ErrStatusManagedByLoans
+	}
+
+	return s.repo.Update(ctx, c)
+}
+
+func (s *serviceCopy) Delete(ctx context.Context, bookID, id int64) error {
+	current, err := s.repo.GetById(ctx, bookID, id)
+	if err != nil {
+		return err
+	}
+
+	if current.Status.Managed() {
+		return ErrCopyOnLoan
+	}
+
+	return s.repo.Delete(ctx, bookID, id)
+}
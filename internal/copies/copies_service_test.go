@@ -0,0 +1,75 @@
+package copies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_serviceCopy_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *Copies
+		input   *Copies
+		wantErr error
+	}{
+		{
+			name:    "sucesso",
+			current: &Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: Good, Status: Available},
+			input:   &Copies{ID: 1, BookID: 1, Barcode: "0001", Status: InRepair},
+		},
+		{
+			name:    "exemplar emprestado",
+			current: &Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: Good, Status: OnLoan},
+			input:   &Copies{ID: 1, BookID: 1, Barcode: "0001", Status: Available},
+			wantErr: ErrCopyOnLoan,
+		},
+		{
+			name:    "emprestado marcado como perdido",
+			current: &Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: Good, Status: OnLoan},
+			input:   &Copies{ID: 1, BookID: 1, Barcode: "0001", Status: Lost},
+		},
+		{
+			name:    "separado enviado para reparo",
+			current: &Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: Good, Status: OnHold},
+			input:   &Copies{ID: 1, BookID: 1, Barcode: "0001", Status: InRepair},
+		},
+		{
+			name:    "emprestado enviado para reparo",
+			current: &Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: Good, Status: OnLoan},
+			input:   &Copies{ID: 1, BookID: 1, Barcode: "0001", Status: InRepair},
+			wantErr: ErrCopyOnLoan,
+		},
+		{
+			name:    "status controlado pelos empréstimos",
+			current: &Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: Good, Status: Available},
+			input:   &Copies{ID: 1, BookID: 1, Barcode: "0001", Status: OnLoan},
+			wantErr: ErrStatusManagedByLoans,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCopyRepo)
+			mockRepo.On("GetById", mock.Anything, int64(1), int64(1)).Return(tt.current, nil)
+
+			if tt.wantErr == nil {
+				mockRepo.On("Update", mock.Anything, tt.input).Return(nil)
+			}
+
+			svc := NewCopyService(mockRepo)
+
+			err := svc.Update(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.current.Condition, tt.input.Condition)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
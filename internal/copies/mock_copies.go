@@ -0,0 +1,45 @@
+package copies
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCopyRepo struct {
+	mock.Mock
+}
+
+func (m *MockCopyRepo) Create(ctx context.Context, c *Copies) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockCopyRepo) Update(ctx context.Context, c *Copies) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockCopyRepo) Delete(ctx context.Context, bookID, id int64) error {
+	args := m.Called(ctx, bookID, id)
+	return args.Error(0)
+}
+
+func (m *MockCopyRepo) GetByBook(ctx context.Context, bookID int64) ([]Copies, error) {
+	args := m.Called(ctx, bookID)
+	return args.Get(0).([]Copies), args.Error(1)
+}
+
+func (m *MockCopyRepo) GetById(ctx context.Context, bookID, id int64) (*Copies, error) {
+	args := m.Called(ctx, bookID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	c, ok := args.Get(0).(*Copies)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return c, args.Error(1)
+}
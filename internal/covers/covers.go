@@ -0,0 +1,88 @@
+package covers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrCoverNotFound   = errors.New("capa não encontrada")
+	ErrBookNotFound    = errors.New("livro não encontrado")
+	ErrFileTooLarge    = errors.New("arquivo maior que o limite permitido")
+	ErrUnsupportedType = errors.New("tipo de imagem não suportado, use JPEG, PNG ou WebP")
+	ErrInvalidImage    = errors.New("imagem inválida ou corrompida")
+)
+
+// Size identifica uma das versões geradas da capa.
+type Size string
+
+const (
+	Original Size = "original"
+	Small    Size = "small"
+	Medium   Size = "medium"
+)
+
+// thumbnailWidths define a largura máxima de cada miniatura; a altura segue
+// a proporção da imagem original.
+var thumbnailWidths = map[Size]int{
+	Small:  160,
+	Medium: 480,
+}
+
+// Covers é uma versão armazenada da capa de um livro.
+type Covers struct {
+	ID          int64
+	BookID      int64
+	Size        Size
+	StorageKey  string
+	URL         string
+	ContentType string
+	Width       int
+	Height      int
+	CreatedAt   time.Time
+}
+
+type CoverCreator interface {
+	// Replace troca todas as versões da capa do livro e devolve as chaves de
+	// armazenamento que deixaram de ser usadas.
+	Replace(ctx context.Context, bookID int64, versions []Covers) ([]string, error)
+	Delete(ctx context.Context, bookID int64) ([]string, error)
+}
+
+type CoverRead interface {
+	GetByBook(ctx context.Context, bookID int64) ([]Covers, error)
+	BookExists(ctx context.Context, bookID int64) (bool, error)
+}
+
+type ICoverRepository interface {
+	CoverCreator
+	CoverRead
+}
+
+// Policy limita o upload de capas.
+type Policy struct {
+	MaxBytes  int64
+	MaxPixels int
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxBytes:  5 << 20,
+		MaxPixels: 40_000_000,
+	}
+}
+
+// PolicyFromEnv lê COVER_MAX_BYTES, usando o padrão para valores ausentes ou
+// inválidos.
+func PolicyFromEnv() Policy {
+	p := DefaultPolicy()
+
+	if value, err := strconv.ParseInt(os.Getenv("COVER_MAX_BYTES"), 10, 64); err == nil && value > 0 {
+		p.MaxBytes = value
+	}
+
+	return p
+}
@@ -0,0 +1,29 @@
+package covers
+
+type CoverResponse struct {
+	Size        Size   `json:"size"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func ToResponse(c *Covers) CoverResponse {
+	return CoverResponse{
+		Size:        c.Size,
+		URL:         c.URL,
+		ContentType: c.ContentType,
+		Width:       c.Width,
+		Height:      c.Height,
+		CreatedAt:   c.CreatedAt.Format("02/01/06 15:04:05"),
+	}
+}
+
+func toResponseList(versions []Covers) []CoverResponse {
+	response := make([]CoverResponse, 0, len(versions))
+	for _, c := range versions {
+		response = append(response, ToResponse(&c))
+	}
+	return response
+}
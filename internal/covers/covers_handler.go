@@ -0,0 +1,164 @@
+package covers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// multipartOverhead cobre cabeçalhos e delimitadores do formulário além do
+// arquivo em si.
+const multipartOverhead = 64 << 10
+
+type CoverHandler struct {
+	svc    CoverService
+	logApp *zap.Logger
+}
+
+func NewCoverHandler(svc CoverService, logApp *zap.Logger) *CoverHandler {
+	return &CoverHandler{svc: svc, logApp: logApp}
+}
+
+func coverError(err error) error {
+	switch {
+	case errors.Is(err, ErrCoverNotFound), errors.Is(err, ErrBookNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrFileTooLarge):
+		return middleware.TooLarge.Messager(err.Error())
+	case errors.Is(err, ErrUnsupportedType):
+		return middleware.Unsupported.Messager(err.Error())
+	case errors.Is(err, ErrInvalidImage):
+		return middleware.BadRequest.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Envia a capa do livro
+// @Description Recebe a imagem no campo "cover" (multipart), valida tipo e tamanho, gera as miniaturas e substitui a capa atual.
+// @Tags covers
+// @Accept  multipart/form-data
+// @Produce json
+// @Param   id path int true "ID do livro"
+// @Param   cover formData file true "Imagem JPEG, PNG ou WebP"
+// @Success 200 {array} CoverResponse "Versões da capa gravadas"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida"
+// @Failure 404 {object} middleware.APIError "Livro não encontrado"
+// @Failure 413 {object} middleware.APIError "Arquivo maior que o limite"
+// @Failure 415 {object} middleware.APIError "Tipo de imagem não suportado"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/cover [put]
+func (h *CoverHandler) UploadCover(c *gin.Context) {
+	h.logApp.Info("Rota de enviar capa")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	maxBytes := h.svc.MaxBytes()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+multipartOverhead)
+
+	file, header, err := c.Request.FormFile("cover")
+	if err != nil {
+		h.logApp.Error("falha ao ler arquivo", zap.Error(err))
+
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = c.Error(coverError(ErrFileTooLarge))
+			return
+		}
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxBytes {
+		h.logApp.Error("arquivo maior que o limite", zap.Int64("tamanho", header.Size))
+		_ = c.Error(coverError(ErrFileTooLarge))
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		h.logApp.Error("falha ao ler arquivo", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	versions, err := h.svc.Upload(c.Request.Context(), bookID, data, header.Header.Get("Content-Type"))
+	if err != nil {
+		h.logApp.Error("falha ao gravar capa", zap.Error(err))
+		_ = c.Error(coverError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toResponseList(versions))
+}
+
+// @Summary Capa do livro
+// @Description Retorna a capa original e as miniaturas do livro com suas URLs públicas.
+// @Tags covers
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Success 200 {array} CoverResponse
+// @Failure 404 {object} middleware.APIError "Livro sem capa"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/books/{id}/cover [get]
+func (h *CoverHandler) ReadCover(c *gin.Context) {
+	h.logApp.Info("Rota de obter capa")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	versions, err := h.svc.GetByBook(c.Request.Context(), bookID)
+	if err != nil {
+		h.logApp.Error("falha ao obter capa", zap.Error(err))
+		_ = c.Error(coverError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toResponseList(versions))
+}
+
+// @Summary Remove a capa do livro
+// @Description Remove a capa e as miniaturas do livro.
+// @Tags covers
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Livro sem capa"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/cover [delete]
+func (h *CoverHandler) DeleteCover(c *gin.Context) {
+	h.logApp.Info("Rota de remover capa")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), bookID); err != nil {
+		h.logApp.Error("falha ao remover capa", zap.Error(err))
+		_ = c.Error(coverError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,135 @@
+package covers
+
+import (
+	"context"
+	"database/sql"
+)
+
+type CoverRepository struct {
+	db *sql.DB
+}
+
+func NewCoverRepository(db *sql.DB) *CoverRepository {
+	return &CoverRepository{db: db}
+}
+
+func (r *CoverRepository) BookExists(ctx context.Context, bookID int64) (bool, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM books WHERE id = ?", bookID).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *CoverRepository) Replace(ctx context.Context, bookID int64, versions []Covers) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	stale, err := storageKeys(ctx, tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM book_covers WHERE book_id = ?", bookID); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO book_covers (book_id, size, storage_key, url, content_type, width, height, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	for i := range versions {
+		v := &versions[i]
+
+		result, err := tx.ExecContext(ctx, query, bookID, v.Size, v.StorageKey, v.URL, v.ContentType, v.Width, v.Height, v.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		v.ID = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return stale, nil
+}
+
+func (r *CoverRepository) Delete(ctx context.Context, bookID int64) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	keys, err := storageKeys(ctx, tx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrCoverNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM book_covers WHERE book_id = ?", bookID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *CoverRepository) GetByBook(ctx context.Context, bookID int64) ([]Covers, error) {
+	query := `SELECT id, book_id, size, storage_key, url, content_type, width, height, created_at
+		FROM book_covers WHERE book_id = ? ORDER BY width ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []Covers{}
+
+	for rows.Next() {
+		var v Covers
+
+		if err := rows.Scan(&v.ID, &v.BookID, &v.Size, &v.StorageKey, &v.URL, &v.ContentType,
+			&v.Width, &v.Height, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func storageKeys(ctx context.Context, tx *sql.Tx, bookID int64) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT storage_key FROM book_covers WHERE book_id = ?", bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
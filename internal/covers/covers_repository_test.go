@@ -0,0 +1,100 @@
+package covers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/covers"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+func TestCoverRepository_Replace(t *testing.T) {
+	db := database.SetupTestDB()
+	r := covers.NewCoverRepository(db)
+	bookRepo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	author := &authors.Authors{Name: "Autor X"}
+	if err := authors.NewAuthorsRepository(db).Create(ctx, author); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+	cat := &categories.Category{Name: "Ficção"}
+	if err := categories.NewCategoryRepository(db).Create(ctx, cat); err != nil {
+		t.Fatalf("category: %v", err)
+	}
+	book := &books.Books{Title: "Go Lang", Description: "D", Content: "C", AuthorID: author.ID}
+	if err := bookRepo.Create(ctx, book); err != nil {
+		t.Fatalf("book: %v", err)
+	}
+	if err := bookRepo.RelationBookCategory(ctx, book.ID, cat.ID); err != nil {
+		t.Fatalf("relation: %v", err)
+	}
+
+	exists, err := r.BookExists(ctx, book.ID)
+	if err != nil || !exists {
+		t.Fatalf("esperava livro existente (%v)", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	version := func(size covers.Size, key string, width int) covers.Covers {
+		return covers.Covers{
+			Size: size, StorageKey: key, URL: covers.PublicRoute + "/" + key,
+			ContentType: "image/png", Width: width, Height: width, CreatedAt: now,
+		}
+	}
+
+	first := []covers.Covers{
+		version(covers.Original, "books/1/original-1.png", 1000),
+		version(covers.Small, "books/1/small-1.png", 160),
+		version(covers.Medium, "books/1/medium-1.png", 480),
+	}
+	stale, err := r.Replace(ctx, book.ID, first)
+	if err != nil || len(stale) != 0 {
+		t.Fatalf("primeira capa: stale %v, erro %v", stale, err)
+	}
+
+	got, err := bookRepo.GetById(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar livro: %v", err)
+	}
+	if got.CoverURL != first[0].URL || got.CoverThumbnailURL != first[1].URL {
+		t.Errorf("urls inesperadas: %q %q", got.CoverURL, got.CoverThumbnailURL)
+	}
+
+	// capa sem miniaturas, como acontece com WebP
+	second := []covers.Covers{version(covers.Original, "books/1/original-2.webp", 0)}
+	stale, err = r.Replace(ctx, book.ID, second)
+	if err != nil {
+		t.Fatalf("erro ao trocar capa: %v", err)
+	}
+	if len(stale) != 3 {
+		t.Errorf("esperava 3 chaves antigas, recebeu %v", stale)
+	}
+
+	got, err = bookRepo.GetById(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar livro: %v", err)
+	}
+	if got.CoverThumbnailURL != second[0].URL {
+		t.Errorf("miniatura deveria cair para o original, recebeu %q", got.CoverThumbnailURL)
+	}
+
+	versions, err := r.GetByBook(ctx, book.ID)
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("esperava 1 versão, recebeu %d (%v)", len(versions), err)
+	}
+
+	keys, err := r.Delete(ctx, book.ID)
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("esperava 1 chave apagada, recebeu %v (%v)", keys, err)
+	}
+
+	if _, err := r.Delete(ctx, book.ID); !errors.Is(err, covers.ErrCoverNotFound) {
+		t.Errorf("esperava ErrCoverNotFound, recebeu %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package covers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type CoverService interface {
+	Upload(ctx context.Context, bookID int64, data []byte, declaredType string) ([]Covers, error)
+	GetByBook(ctx context.Context, bookID int64) ([]Covers, error)
+	Delete(ctx context.Context, bookID int64) error
+	MaxBytes() int64
+}
+
+type serviceCover struct {
+	repo    ICoverRepository
+	storage Storage
+	policy  Policy
+	now     func() time.Time
+}
+
+func NewCoverService(repo ICoverRepository, storage Storage, policy Policy) *serviceCover {
+	return &serviceCover{
+		repo:    repo,
+		storage: storage,
+		policy:  policy,
+		now:     func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+func (s *serviceCover) MaxBytes() int64 {
+	return s.policy.MaxBytes
+}
+
+// Upload valida a imagem, grava o original e as miniaturas e só então troca
+// a capa no banco. WebP é gravado sem miniaturas, pois a biblioteca padrão não
+// decodifica o formato; as rotas de leitura usam o original no lugar delas.
+func (s *serviceCover) Upload(ctx context.Context, bookID int64, data []byte, declaredType string) ([]Covers, error) {
+	if int64(len(data)) > s.policy.MaxBytes {
+		return nil, ErrFileTooLarge
+	}
+
+	contentType, err := detectType(data, declaredType)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.BookExists(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrBookNotFound
+	}
+
+	// o sufixo aleatório impede que dois envios no mesmo segundo gravem por
+	// cima um do outro e que a limpeza apague os arquivos recém-gravados
+	suffix, err := newKeySuffix()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	ext := allowedTypes[contentType]
+	key := func(size Size) string {
+		return fmt.Sprintf("books/%d/%s-%d-%s%s", bookID, size, now.Unix(), suffix, ext)
+	}
+
+	original := Covers{BookID: bookID, Size: Original, StorageKey: key(Original), ContentType: contentType, CreatedAt: now}
+	files := map[string][]byte{original.StorageKey: data}
+	versions := []Covers{original}
+
+	if contentType != "image/webp" {
+		img, err := decode(data, s.policy.MaxPixels)
+		if err != nil {
+			return nil, err
+		}
+		versions[0].Width, versions[0].Height = img.Bounds().Dx(), img.Bounds().Dy()
+
+		for _, size := range []Size{Small, Medium} {
+			thumb := thumbnail(img, thumbnailWidths[size])
+
+			encoded, err := encode(thumb, contentType)
+			if err != nil {
+				return nil, err
+			}
+
+			version := Covers{
+				BookID:      bookID,
+				Size:        size,
+				StorageKey:  key(size),
+				ContentType: contentType,
+				Width:       thumb.Bounds().Dx(),
+				Height:      thumb.Bounds().Dy(),
+				CreatedAt:   now,
+			}
+			files[version.StorageKey] = encoded
+			versions = append(versions, version)
+		}
+	}
+
+	for i := range versions {
+		if err := s.storage.Put(ctx, versions[i].StorageKey, files[versions[i].StorageKey], contentType); err != nil {
+			s.removeFiles(ctx, keysOf(versions[:i]))
+			return nil, err
+		}
+		versions[i].URL = s.storage.URL(versions[i].StorageKey)
+	}
+
+	stale, err := s.repo.Replace(ctx, bookID, versions)
+	if err != nil {
+		s.removeFiles(ctx, keysOf(versions))
+		return nil, err
+	}
+
+	current := keysOf(versions)
+	stale = slices.DeleteFunc(stale, func(key string) bool {
+		return slices.Contains(current, key)
+	})
+
+	s.removeFiles(ctx, stale)
+	return versions, nil
+}
+
+func (s *serviceCover) GetByBook(ctx context.Context, bookID int64) ([]Covers, error) {
+	versions, err := s.repo.GetByBook(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrCoverNotFound
+	}
+	return versions, nil
+}
+
+func (s *serviceCover) Delete(ctx context.Context, bookID int64) error {
+	keys, err := s.repo.Delete(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	s.removeFiles(ctx, keys)
+	return nil
+}
+
+// removeFiles apaga arquivos que já não são referenciados. Falhas deixam só
+// arquivos órfãos no armazenamento e não desfazem a operação principal.
+func (s *serviceCover) removeFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		_ = s.storage.Delete(ctx, key)
+	}
+}
+
+func newKeySuffix() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func keysOf(versions []Covers) []string {
+	keys := make([]string, 0, len(versions))
+	for _, v := range versions {
+		keys = append(keys, v.StorageKey)
+	}
+	return keys
+}
@@ -0,0 +1,169 @@
+package covers
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("falha ao gerar png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func Test_serviceCover_Upload(t *testing.T) {
+	webp := append([]byte("RIFF\x24\x00\x00\x00WEBPVP8 "), make([]byte, 32)...)
+
+	tests := []struct {
+		name         string
+		data         []byte
+		declared     string
+		bookExists   bool
+		wantVersions int
+		wantErr      error
+	}{
+		{
+			name:         "png gera miniaturas",
+			data:         pngImage(t, 1000, 1500),
+			declared:     "image/png",
+			bookExists:   true,
+			wantVersions: 3,
+		},
+		{
+			name:         "webp grava só o original",
+			data:         webp,
+			declared:     "image/webp",
+			bookExists:   true,
+			wantVersions: 1,
+		},
+		{
+			name:     "tipo não suportado",
+			data:     []byte("GIF89a conteúdo qualquer"),
+			declared: "image/gif",
+			wantErr:  ErrUnsupportedType,
+		},
+		{
+			name:     "tipo declarado diferente do conteúdo",
+			data:     pngImage(t, 10, 10),
+			declared: "image/jpeg",
+			wantErr:  ErrUnsupportedType,
+		},
+		{
+			name:     "arquivo grande",
+			data:     make([]byte, 2048),
+			declared: "image/png",
+			wantErr:  ErrFileTooLarge,
+		},
+		{
+			name:     "livro inexistente",
+			data:     pngImage(t, 10, 10),
+			declared: "image/png",
+			wantErr:  ErrBookNotFound,
+		},
+		{
+			name:       "png corrompido",
+			data:       append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...),
+			declared:   "image/png",
+			bookExists: true,
+			wantErr:    ErrInvalidImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCoverRepo)
+			mockStorage := new(MockStorage)
+
+			if tt.wantErr != ErrFileTooLarge && tt.wantErr != ErrUnsupportedType {
+				mockRepo.On("BookExists", mock.Anything, int64(1)).Return(tt.bookExists, nil)
+			}
+
+			if tt.wantErr == nil {
+				mockStorage.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockRepo.On("Replace", mock.Anything, int64(1), mock.Anything).Return([]string{"books/1/original-1.png"}, nil)
+				mockStorage.On("Delete", mock.Anything, "books/1/original-1.png").Return(nil)
+			}
+
+			policy := DefaultPolicy()
+			if tt.wantErr == ErrFileTooLarge {
+				policy.MaxBytes = 1024
+			}
+
+			svc := NewCoverService(mockRepo, mockStorage, policy)
+
+			versions, err := svc.Upload(context.Background(), 1, tt.data, tt.declared)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, versions, tt.wantVersions)
+				assert.Equal(t, Original, versions[0].Size)
+				assert.NotEmpty(t, versions[0].URL)
+				mockStorage.AssertNumberOfCalls(t, "Put", tt.wantVersions)
+			}
+
+			for _, v := range versions[min(1, len(versions)):] {
+				assert.LessOrEqual(t, v.Width, thumbnailWidths[v.Size])
+				assert.Equal(t, v.Width*3/2, v.Height)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_serviceCover_Upload_MesmoSegundo(t *testing.T) {
+	mockRepo := new(MockCoverRepo)
+	mockStorage := new(MockStorage)
+
+	// o banco devolve como antigas as chaves do primeiro envio: no próprio
+	// primeiro envio elas são as recém-gravadas e não podem ser apagadas
+	var first []Covers
+	stale := []string{""}
+
+	mockRepo.On("BookExists", mock.Anything, int64(1)).Return(true, nil)
+	mockStorage.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Replace", mock.Anything, int64(1), mock.Anything).Run(func(args mock.Arguments) {
+		versions := args.Get(2).([]Covers)
+		if first == nil {
+			first = versions
+		}
+		stale[0] = first[0].StorageKey
+	}).Return(stale, nil)
+	mockStorage.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	svc := NewCoverService(mockRepo, mockStorage, DefaultPolicy())
+	svc.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	data := append([]byte("RIFF\x24\x00\x00\x00WEBPVP8 "), make([]byte, 32)...)
+
+	if _, err := svc.Upload(context.Background(), 1, data, "image/webp"); err != nil {
+		t.Fatalf("primeiro envio: %v", err)
+	}
+	second, err := svc.Upload(context.Background(), 1, data, "image/webp")
+	if err != nil {
+		t.Fatalf("segundo envio: %v", err)
+	}
+
+	assert.NotEqual(t, first[0].StorageKey, second[0].StorageKey)
+	mockStorage.AssertCalled(t, "Delete", mock.Anything, first[0].StorageKey)
+	mockStorage.AssertNotCalled(t, "Delete", mock.Anything, second[0].StorageKey)
+	mockStorage.AssertNumberOfCalls(t, "Delete", 1)
+}
@@ -0,0 +1,49 @@
+package covers
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCoverRepo struct {
+	mock.Mock
+}
+
+func (m *MockCoverRepo) Replace(ctx context.Context, bookID int64, versions []Covers) ([]string, error) {
+	args := m.Called(ctx, bookID, versions)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockCoverRepo) Delete(ctx context.Context, bookID int64) ([]string, error) {
+	args := m.Called(ctx, bookID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockCoverRepo) GetByBook(ctx context.Context, bookID int64) ([]Covers, error) {
+	args := m.Called(ctx, bookID)
+	return args.Get(0).([]Covers), args.Error(1)
+}
+
+func (m *MockCoverRepo) BookExists(ctx context.Context, bookID int64) (bool, error) {
+	args := m.Called(ctx, bookID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockStorage) URL(key string) string {
+	return PublicRoute + "/" + key
+}
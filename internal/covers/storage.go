@@ -0,0 +1,96 @@
+package covers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PublicRoute é o caminho onde o armazenamento local serve os arquivos.
+const PublicRoute = "/public/covers"
+
+// Storage guarda os arquivos das capas. A implementação padrão usa o disco
+// local; outras (S3, CDN) só precisam devolver a URL pública de cada chave.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type LocalStorage struct {
+	dir string
+}
+
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{dir: dir}
+}
+
+// LocalStorageFromEnv lê COVER_STORAGE_DIR, usando uploads/covers por padrão.
+func LocalStorageFromEnv() *LocalStorage {
+	dir := os.Getenv("COVER_STORAGE_DIR")
+	if dir == "" {
+		dir = "uploads/covers"
+	}
+	return NewLocalStorage(dir)
+}
+
+func (s *LocalStorage) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, "..") {
+		return "", errors.New("chave de armazenamento inválida")
+	}
+	return filepath.Join(s.dir, clean), nil
+}
+
+// Put grava em arquivo temporário e renomeia, para que a rota pública nunca
+// sirva um arquivo pela metade.
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return PublicRoute + "/" + key
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"log"
 	"os"
+	"strings"
 
 	// Importa o driver MySQL para registrar no database/sql
 	_ "github.com/go-sql-driver/mysql"
@@ -32,6 +33,19 @@ func Connection() *sql.DB {
 //go:embed schema.sql
 var schema string
 
+// search.sql cria o índice de busca textual do banco de testes. O FTS5 só
+// existe quando o driver é compilado com a tag sqlite_fts5; sem ela o mesmo
+// índice é criado com FTS4, que aceita as mesmas consultas usadas pelo
+// repositório de livros.
+//
+//go:embed search.sql
+var searchSchema string
+
+const (
+	fts5Table = "fts5(title, description, body, authors, tokenize='unicode61')"
+	fts4Table = "fts4(title, description, body, authors, tokenize=unicode61)"
+)
+
 func SetupTestDB() *sql.DB {
 	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
@@ -48,5 +62,12 @@ func SetupTestDB() *sql.DB {
 		log.Fatalf("Erro ao criar tabelas: %v", err)
 	}
 
+	if _, err := db.Exec(strings.ReplaceAll(searchSchema, "{{fts}}", fts5Table)); err != nil {
+		if _, err := db.Exec(strings.ReplaceAll(searchSchema, "{{fts}}", fts4Table)); err != nil {
+			db.Close()
+			log.Fatalf("Erro ao criar índice de busca: %v", err)
+		}
+	}
+
 	return db
 }
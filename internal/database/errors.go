@@ -0,0 +1,23 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// IsDuplicateKey reconhece a violação de chave única no MySQL (1062) e no
+// SQLite dos testes, para que os repositórios convertam a corrida entre dois
+// pedidos iguais no mesmo erro da verificação feita antes do INSERT.
+func IsDuplicateKey(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -0,0 +1,17 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ForUpdate devolve a cláusula de leitura com bloqueio de linha do banco. O
+// SQLite dos testes não a aceita e já serializa as escritas, então lá ela
+// fica vazia.
+func ForUpdate(db *sql.DB) string {
+	if _, ok := db.Driver().(*mysql.MySQLDriver); ok {
+		return " FOR UPDATE"
+	}
+	return ""
+}
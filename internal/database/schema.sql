@@ -1,30 +1,217 @@
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL UNIQUE,
+    username VARCHAR(100) NOT NULL,
+    password VARCHAR(255) NOT NULL,
+    bio TEXT,
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    role VARCHAR(10) DEFAULT 'user' CHECK(role IN ('user', 'admin')),
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id INTEGER NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    family_id VARCHAR(32) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    foreign key(user_id) references users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(32) NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS token_cutoffs (
+    user_id INTEGER NOT NULL PRIMARY KEY,
+    revoked_before TIMESTAMP NOT NULL,
+    kept_session_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS password_resets (
+    id INTEGER NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    foreign key(user_id) references users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS role_changes (
+    id INTEGER NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    changed_by INTEGER NOT NULL,
+    old_role VARCHAR(10) NOT NULL,
+    new_role VARCHAR(10) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id INTEGER NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    foreign key(user_id) references users(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS authors (
     id INTEGER NOT NULL PRIMARY KEY,
     name VARCHAR(100) NOT NULL CHECK(name <> ''),
-    description TEXT NOT NULL 
+    description TEXT NOT NULL,
+    name_search VARCHAR(100) NOT NULL DEFAULT ''
 );
 		
 CREATE TABLE IF NOT EXISTS categories (
     id INTEGER NOT NULL PRIMARY KEY,
     name VARCHAR(100) NOT NULL CHECK(name <> ''),
+    parent_id INTEGER NULL REFERENCES categories(id) ON DELETE SET NULL,
     created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE IF NOT EXISTS publishers (
+    id INTEGER NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL CHECK(name <> ''),
+    country VARCHAR(100) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS series (
+    id INTEGER NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL CHECK(name <> ''),
+    description TEXT NOT NULL DEFAULT ''
+);
+
 CREATE TABLE IF NOT EXISTS books (
     id INTEGER NOT NULL PRIMARY KEY,
     title VARCHAR(100) NOT NULL CHECK(title <> ''),
     description TEXT NOT NULL CHECK(description <> ''),
     content TEXT NOT NULL CHECK(content <> ''),
     author_id INTEGER,
+    isbn_10 VARCHAR(10) UNIQUE,
+    isbn_13 VARCHAR(13) UNIQUE,
+    call_number VARCHAR(64),
+    call_number_key VARCHAR(96),
+    publisher_id INTEGER,
+    edition INTEGER,
+    language VARCHAR(2),
+    publication_year INTEGER,
+    pages INTEGER,
+    format VARCHAR(20) CHECK(format IN ('hardcover', 'paperback', 'ebook', 'audiobook', 'other')),
+    series_id INTEGER,
+    series_volume INTEGER CHECK(series_volume > 0),
+    title_search VARCHAR(100) NOT NULL DEFAULT '',
     created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
-    foreign key(author_id) references authors(id)
+    UNIQUE(series_id, series_volume),
+    foreign key(author_id) references authors(id),
+    foreign key(publisher_id) references publishers(id) ON DELETE SET NULL,
+    foreign key(series_id) references series(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS book_category (
     id INTEGER NOT NULL PRIMARY KEY,
     book_id INTEGER,
     category_id INTEGER,
+    UNIQUE(book_id, category_id),
     foreign key(book_id) references books(id),
     foreign key(category_id) references categories(id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS book_author (
+    id INTEGER NOT NULL PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'author' CHECK(role IN ('author', 'editor', 'translator', 'illustrator')),
+    position INTEGER NOT NULL DEFAULT 1,
+    UNIQUE(book_id, author_id, role),
+    foreign key(book_id) references books(id) ON DELETE CASCADE,
+    foreign key(author_id) references authors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS book_covers (
+    id INTEGER NOT NULL PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    size VARCHAR(20) NOT NULL CHECK(size IN ('original', 'small', 'medium')),
+    storage_key VARCHAR(255) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(book_id, size),
+    foreign key(book_id) references books(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS copies (
+    id INTEGER NOT NULL PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    barcode VARCHAR(50) NOT NULL UNIQUE CHECK(barcode <> ''),
+    shelf_location VARCHAR(100) NOT NULL DEFAULT '',
+    physical_condition VARCHAR(20) NOT NULL DEFAULT 'good',
+    status VARCHAR(20) NOT NULL DEFAULT 'available',
+    created_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    foreign key(book_id) references books(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS loans (
+    id INTEGER NOT NULL PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    copy_id INTEGER,
+    user_id INTEGER NOT NULL,
+    loaned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    due_date TIMESTAMP NOT NULL,
+    returned_at TIMESTAMP NULL,
+    renewals INTEGER NOT NULL DEFAULT 0,
+    foreign key(book_id) references books(id),
+    foreign key(copy_id) references copies(id) ON DELETE SET NULL,
+    foreign key(user_id) references users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS holds (
+    id INTEGER NOT NULL PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    copy_id INTEGER,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ready_at TIMESTAMP NULL,
+    expires_at TIMESTAMP NULL,
+    active INTEGER GENERATED ALWAYS AS (CASE WHEN status IN ('waiting', 'ready') THEN 1 END) VIRTUAL,
+    UNIQUE(user_id, book_id, active),
+    foreign key(book_id) references books(id) ON DELETE CASCADE,
+    foreign key(user_id) references users(id) ON DELETE CASCADE,
+    foreign key(copy_id) references copies(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS fine_entries (
+    id INTEGER NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    loan_id INTEGER,
+    kind VARCHAR(20) NOT NULL CHECK(kind IN ('accrual', 'waiver', 'adjustment', 'payment')),
+    amount_cents INTEGER NOT NULL CHECK(amount_cents <> 0),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    foreign key(user_id) references users(id),
+    foreign key(loan_id) references loans(id)
+);
+
+CREATE TRIGGER IF NOT EXISTS fine_entries_no_update BEFORE UPDATE ON fine_entries
+BEGIN
+    SELECT RAISE(ABORT, 'lançamentos de multa são imutáveis');
+END;
+
+CREATE TRIGGER IF NOT EXISTS fine_entries_no_delete BEFORE DELETE ON fine_entries
+BEGIN
+    SELECT RAISE(ABORT, 'lançamentos de multa são imutáveis');
+END;
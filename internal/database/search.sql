@@ -0,0 +1,42 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS books_fts USING {{fts}};
+
+CREATE TRIGGER IF NOT EXISTS books_fts_insert AFTER INSERT ON books
+BEGIN
+    INSERT INTO books_fts (rowid, title, description, body, authors)
+    VALUES (new.id, new.title, new.description, new.content, '');
+END;
+
+CREATE TRIGGER IF NOT EXISTS books_fts_update AFTER UPDATE OF title, description, content ON books
+BEGIN
+    UPDATE books_fts SET title = new.title, description = new.description, body = new.content
+    WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS books_fts_delete AFTER DELETE ON books
+BEGIN
+    DELETE FROM books_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS book_author_fts_insert AFTER INSERT ON book_author
+BEGIN
+    UPDATE books_fts SET authors = (
+        SELECT COALESCE(group_concat(a.name, ' '), '') FROM book_author ba
+        JOIN authors a ON a.id = ba.author_id WHERE ba.book_id = new.book_id
+    ) WHERE rowid = new.book_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS book_author_fts_delete AFTER DELETE ON book_author
+BEGIN
+    UPDATE books_fts SET authors = (
+        SELECT COALESCE(group_concat(a.name, ' '), '') FROM book_author ba
+        JOIN authors a ON a.id = ba.author_id WHERE ba.book_id = old.book_id
+    ) WHERE rowid = old.book_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS authors_fts_update AFTER UPDATE OF name ON authors
+BEGIN
+    UPDATE books_fts SET authors = (
+        SELECT COALESCE(group_concat(a.name, ' '), '') FROM book_author ba
+        JOIN authors a ON a.id = ba.author_id WHERE ba.book_id = books_fts.rowid
+    ) WHERE rowid IN (SELECT book_id FROM book_author WHERE author_id = new.id);
+END;
@@ -0,0 +1,151 @@
+package fines
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SystemActor identifica os lançamentos gerados automaticamente pela API.
+const SystemActor = "sistema"
+
+type Kind string
+
+const (
+	Accrual    Kind = "accrual"
+	Waiver     Kind = "waiver"
+	Adjustment Kind = "adjustment"
+	Payment    Kind = "payment"
+)
+
+var (
+	ErrInvalidKind          = errors.New("tipo de lançamento invalido")
+	ErrInvalidAmount        = errors.New("valor do lançamento invalido")
+	ErrNoteRequired         = errors.New("motivo obrigatório para abono ou ajuste")
+	ErrAmountExceedsBalance = errors.New("valor maior que o saldo devedor")
+)
+
+// Entries é um lançamento do extrato de multas do usuario. Valores são em
+// centavos: positivos aumentam a dívida, negativos a reduzem. Lançamentos
+// nunca são alterados ou removidos; correções entram como novos ajustes.
+type Entries struct {
+	ID          int64
+	UserID      int64
+	LoanID      int64
+	Kind        Kind
+	AmountCents int64
+	Note        string
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+// Policy define o cálculo das multas por atraso e o limite de saldo a partir
+// do qual o usuario não pode pegar novos empréstimos.
+type Policy struct {
+	DailyRateCents      int64
+	CapCents            int64
+	BlockThresholdCents int64
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		DailyRateCents:      100,
+		CapCents:            2000,
+		BlockThresholdCents: 1000,
+	}
+}
+
+// PolicyFromEnv lê FINE_DAILY_RATE_CENTS, FINE_CAP_CENTS e
+// FINE_BLOCK_THRESHOLD_CENTS, usando o padrão para valores ausentes ou inválidos.
+func PolicyFromEnv() Policy {
+	p := DefaultPolicy()
+
+	p.DailyRateCents = envCents("FINE_DAILY_RATE_CENTS", p.DailyRateCents)
+	p.CapCents = envCents("FINE_CAP_CENTS", p.CapCents)
+	p.BlockThresholdCents = envCents("FINE_BLOCK_THRESHOLD_CENTS", p.BlockThresholdCents)
+
+	return p
+}
+
+func envCents(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// LateFee calcula a multa de uma devolução. Cada dia iniciado após o prazo
+// conta como um dia de atraso; CapCents zero desativa o teto.
+func (p Policy) LateFee(dueDate, returnedAt time.Time) int64 {
+	if !returnedAt.After(dueDate) {
+		return 0
+	}
+
+	day := 24 * time.Hour
+	days := int64((returnedAt.Sub(dueDate) + day - 1) / day)
+
+	fee := days * p.DailyRateCents
+	if p.CapCents > 0 && fee > p.CapCents {
+		fee = p.CapCents
+	}
+
+	return fee
+}
+
+type FineCreator interface {
+	Create(ctx context.Context, e *Entries) error
+}
+
+type FineRead interface {
+	GetByUser(ctx context.Context, userID int64) ([]Entries, error)
+	Balance(ctx context.Context, userID int64) (int64, error)
+}
+
+type IFineRepository interface {
+	FineCreator
+	FineRead
+}
+
+func (k Kind) Valid() bool {
+	switch k {
+	case Accrual, Waiver, Adjustment, Payment:
+		return true
+	}
+	return false
+}
+
+// Validate confere o sinal do valor de acordo com o tipo: multas somam ao
+// saldo, abonos e pagamentos subtraem e ajustes podem ir nos dois sentidos.
+func (e *Entries) Validate() error {
+	if e.UserID <= 0 {
+		return errors.New("usuario invalido")
+	}
+
+	if !e.Kind.Valid() {
+		return ErrInvalidKind
+	}
+
+	switch e.Kind {
+	case Accrual:
+		if e.AmountCents <= 0 {
+			return ErrInvalidAmount
+		}
+	case Waiver, Payment:
+		if e.AmountCents >= 0 {
+			return ErrInvalidAmount
+		}
+	case Adjustment:
+		if e.AmountCents == 0 {
+			return ErrInvalidAmount
+		}
+	}
+
+	if (e.Kind == Waiver || e.Kind == Adjustment) && e.Note == "" {
+		return ErrNoteRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package fines
+
+// @Description Lançamento manual no extrato de multas. Abonos e pagamentos usam valores positivos.
+type EntryRequest struct {
+	Kind        Kind   `json:"kind" binding:"required,oneof=waiver adjustment payment" example:"payment"`
+	AmountCents int64  `json:"amount_cents" binding:"required" example:"500"`
+	LoanID      int64  `json:"loan_id" example:"1"`
+	Note        string `json:"note" example:"Pagamento no balcão"`
+}
+
+type EntryResponse struct {
+	ID          int64  `json:"id"`
+	LoanID      int64  `json:"loan_id"`
+	Kind        Kind   `json:"kind"`
+	AmountCents int64  `json:"amount_cents"`
+	Note        string `json:"note"`
+	CreatedBy   string `json:"created_by"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type AccountResponse struct {
+	UserID       int64           `json:"user_id"`
+	BalanceCents int64           `json:"balance_cents"`
+	Blocked      bool            `json:"blocked"`
+	Entries      []EntryResponse `json:"entries"`
+}
+
+func ToResponse(e *Entries) EntryResponse {
+	return EntryResponse{
+		ID:          e.ID,
+		LoanID:      e.LoanID,
+		Kind:        e.Kind,
+		AmountCents: e.AmountCents,
+		Note:        e.Note,
+		CreatedBy:   e.CreatedBy,
+		CreatedAt:   e.CreatedAt.Format("02/01/06 15:04:05"),
+	}
+}
+
+func ToAccountResponse(a *Account) AccountResponse {
+	entries := make([]EntryResponse, 0, len(a.Entries))
+	for _, e := range a.Entries {
+		entries = append(entries, ToResponse(&e))
+	}
+
+	return AccountResponse{
+		UserID:       a.UserID,
+		BalanceCents: a.BalanceCents,
+		Blocked:      a.Blocked,
+		Entries:      entries,
+	}
+}
@@ -0,0 +1,112 @@
+package fines
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type FineHandler struct {
+	svc    FineService
+	logApp *zap.Logger
+}
+
+func NewFineHandler(svc FineService, logApp *zap.Logger) *FineHandler {
+	return &FineHandler{svc: svc, logApp: logApp}
+}
+
+func fineError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidKind), errors.Is(err, ErrInvalidAmount), errors.Is(err, ErrNoteRequired):
+		return middleware.BadRequest.Messager(err.Error())
+	case errors.Is(err, ErrAmountExceedsBalance):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Extrato de multas do usuario
+// @Description Retorna o saldo devedor, o bloqueio para empréstimos e todos os lançamentos do usuario. Só o próprio usuario ou um admin.
+// @Tags fines
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do usuario"
+// @Success 200 {object} AccountResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 403 {object} middleware.APIError "Multas de outro usuario"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/fines [get]
+func (h *FineHandler) ReadUserFines(c *gin.Context) {
+	h.logApp.Info("Rota de extrato de multas")
+
+	userID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	account, err := h.svc.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		h.logApp.Error("falha ao obter multas", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToAccountResponse(account))
+}
+
+// @Summary Registra lançamento de multa
+// @Description Registra abono (waiver), ajuste (adjustment) ou pagamento (payment) no extrato do usuario.
+// @Description Lançamentos são imutáveis; para corrigir um valor registre um novo ajuste.
+// @Tags fines
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do usuario"
+// @Param entry body EntryRequest true "Dados do lançamento"
+// @Success 201 {object} EntryResponse "Lançamento registrado"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida"
+// @Failure 409 {object} middleware.APIError "Valor maior que o saldo devedor"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/fines [post]
+func (h *FineHandler) CreateFineEntry(c *gin.Context) {
+	h.logApp.Info("Rota de lançar multa")
+
+	userID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	var dto EntryRequest
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	entry := &Entries{
+		UserID:      userID,
+		LoanID:      dto.LoanID,
+		Kind:        dto.Kind,
+		AmountCents: dto.AmountCents,
+		Note:        dto.Note,
+		CreatedBy:   c.GetString(middleware.GinContextKeyEmail),
+	}
+
+	if err := h.svc.Record(c.Request.Context(), entry); err != nil {
+		h.logApp.Error("falha ao lançar multa", zap.Error(err))
+		_ = c.Error(fineError(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(entry))
+}
@@ -0,0 +1,137 @@
+package fines
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+type FineRepository struct {
+	db        *sql.DB
+	forUpdate string
+}
+
+func NewFineRepository(db *sql.DB) *FineRepository {
+	return &FineRepository{db: db, forUpdate: database.ForUpdate(db)}
+}
+
+// Create grava o lançamento. Valores negativos só entram se couberem no saldo
+// devedor, conferido com os lançamentos do usuario travados na mesma
+// transação para que dois pagamentos simultâneos não passem do saldo.
+func (r *FineRepository) Create(ctx context.Context, e *Entries) error {
+	if e.AmountCents >= 0 {
+		return InsertEntry(ctx, r.db, e)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	balance, err := r.lockBalance(ctx, tx, e.UserID)
+	if err != nil {
+		return err
+	}
+
+	if balance+e.AmountCents < 0 {
+		return ErrAmountExceedsBalance
+	}
+
+	if err := InsertEntry(ctx, tx, e); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *FineRepository) lockBalance(ctx context.Context, tx *sql.Tx, userID int64) (int64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT amount_cents FROM fine_entries WHERE user_id = ?"+r.forUpdate, userID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var balance int64
+	for rows.Next() {
+		var amount int64
+		if err := rows.Scan(&amount); err != nil {
+			return 0, err
+		}
+		balance += amount
+	}
+
+	return balance, rows.Err()
+}
+
+// Execer é satisfeito por *sql.DB e *sql.Tx, permitindo que outros
+// repositórios gravem lançamentos dentro das próprias transações.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// InsertEntry grava um lançamento no extrato e preenche o ID gerado.
+func InsertEntry(ctx context.Context, q Execer, e *Entries) error {
+	query := `INSERT INTO fine_entries (user_id, loan_id, kind, amount_cents, note, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	var loanID sql.NullInt64
+	if e.LoanID > 0 {
+		loanID = sql.NullInt64{Int64: e.LoanID, Valid: true}
+	}
+
+	result, err := q.ExecContext(ctx, query, e.UserID, loanID, e.Kind, e.AmountCents, e.Note, e.CreatedBy, e.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	e.ID = id
+	return nil
+}
+
+func (r *FineRepository) GetByUser(ctx context.Context, userID int64) ([]Entries, error) {
+	query := `SELECT id, user_id, loan_id, kind, amount_cents, note, created_by, created_at
+		FROM fine_entries WHERE user_id = ? ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entries
+
+	for rows.Next() {
+		var (
+			e      Entries
+			loanID sql.NullInt64
+		)
+
+		if err := rows.Scan(&e.ID, &e.UserID, &loanID, &e.Kind, &e.AmountCents, &e.Note, &e.CreatedBy, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		e.LoanID = loanID.Int64
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *FineRepository) Balance(ctx context.Context, userID int64) (int64, error) {
+	var balance int64
+
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount_cents), 0) FROM fine_entries WHERE user_id = ?", userID).
+		Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
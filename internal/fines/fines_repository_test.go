@@ -0,0 +1,73 @@
+package fines_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func TestFineRepository_Ledger(t *testing.T) {
+	db := database.SetupTestDB()
+	ctx := context.Background()
+
+	if err := users.NewUsersRepository(db).Create(ctx, &users.Users{Name: "Leitor", Email: "um@email.com", Username: "um", Password: "hash", Role: users.User}); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+
+	repo := fines.NewFineRepository(db)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	entries := []*fines.Entries{
+		{UserID: 1, Kind: fines.Accrual, AmountCents: 700, CreatedBy: fines.SystemActor, CreatedAt: now},
+		{UserID: 1, Kind: fines.Waiver, AmountCents: -200, Note: "primeira vez", CreatedBy: "admin@email.com", CreatedAt: now},
+		{UserID: 1, Kind: fines.Payment, AmountCents: -300, CreatedBy: "admin@email.com", CreatedAt: now},
+	}
+
+	for _, e := range entries {
+		if err := repo.Create(ctx, e); err != nil {
+			t.Fatalf("erro ao lançar: %v", err)
+		}
+	}
+
+	overpay := &fines.Entries{UserID: 1, Kind: fines.Payment, AmountCents: -300, CreatedBy: "admin@email.com", CreatedAt: now}
+	if err := repo.Create(ctx, overpay); !errors.Is(err, fines.ErrAmountExceedsBalance) {
+		t.Errorf("esperava ErrAmountExceedsBalance, recebeu %v", err)
+	}
+
+	balance, err := repo.Balance(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao calcular saldo: %v", err)
+	}
+	if balance != 200 {
+		t.Errorf("esperava saldo 200, recebeu %d", balance)
+	}
+
+	got, err := repo.GetByUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar extrato: %v", err)
+	}
+	if len(got) != 3 || got[0].Kind != fines.Accrual || got[2].Kind != fines.Payment {
+		t.Errorf("extrato inesperado: %+v", got)
+	}
+
+	if _, err := db.ExecContext(ctx, "UPDATE fine_entries SET amount_cents = 1 WHERE id = 1"); err == nil {
+		t.Error("esperava erro ao alterar lançamento")
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM fine_entries WHERE id = 1"); err == nil {
+		t.Error("esperava erro ao remover lançamento")
+	}
+
+	empty, err := repo.Balance(ctx, 2)
+	if err != nil {
+		t.Fatalf("erro ao calcular saldo: %v", err)
+	}
+	if empty != 0 {
+		t.Errorf("esperava saldo zerado, recebeu %d", empty)
+	}
+}
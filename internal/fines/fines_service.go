@@ -0,0 +1,113 @@
+package fines
+
+import (
+	"context"
+	"time"
+)
+
+// Account reúne o saldo devedor do usuario e o extrato que o compõe.
+type Account struct {
+	UserID       int64
+	BalanceCents int64
+	Blocked      bool
+	Entries      []Entries
+}
+
+type FineService interface {
+	LateReturnEntry(userID, loanID int64, dueDate, returnedAt time.Time) (*Entries, error)
+	IsBlocked(ctx context.Context, userID int64) (bool, error)
+	Record(ctx context.Context, e *Entries) error
+	GetAccount(ctx context.Context, userID int64) (*Account, error)
+}
+
+type serviceFine struct {
+	repo   IFineRepository
+	policy Policy
+	now    func() time.Time
+}
+
+func NewFineService(repo IFineRepository, policy Policy) *serviceFine {
+	return &serviceFine{
+		repo:   repo,
+		policy: policy,
+		now:    func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+// LateReturnEntry monta o lançamento da multa de uma devolução atrasada, que
+// é gravado pela própria devolução na mesma transação. Devoluções no prazo
+// não geram lançamento e retornam nil.
+func (s *serviceFine) LateReturnEntry(userID, loanID int64, dueDate, returnedAt time.Time) (*Entries, error) {
+	fee := s.policy.LateFee(dueDate, returnedAt)
+	if fee == 0 {
+		return nil, nil
+	}
+
+	entry := &Entries{
+		UserID:      userID,
+		LoanID:      loanID,
+		Kind:        Accrual,
+		AmountCents: fee,
+		Note:        "multa por atraso na devolução",
+		CreatedBy:   SystemActor,
+		CreatedAt:   s.now(),
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *serviceFine) IsBlocked(ctx context.Context, userID int64) (bool, error) {
+	balance, err := s.repo.Balance(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return balance > s.policy.BlockThresholdCents, nil
+}
+
+// Record registra um lançamento manual. Abonos e pagamentos são informados
+// como valores positivos; o repositório recusa os que ultrapassam o saldo
+// devedor.
+func (s *serviceFine) Record(ctx context.Context, e *Entries) error {
+	if e.Kind == Accrual {
+		return ErrInvalidKind
+	}
+
+	if e.Kind == Waiver || e.Kind == Payment {
+		if e.AmountCents <= 0 {
+			return ErrInvalidAmount
+		}
+		e.AmountCents = -e.AmountCents
+	}
+
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	e.CreatedAt = s.now()
+
+	return s.repo.Create(ctx, e)
+}
+
+func (s *serviceFine) GetAccount(ctx context.Context, userID int64) (*Account, error) {
+	entries, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance int64
+	for _, e := range entries {
+		balance += e.AmountCents
+	}
+
+	return &Account{
+		UserID:       userID,
+		BalanceCents: balance,
+		Blocked:      balance > s.policy.BlockThresholdCents,
+		Entries:      entries,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package fines
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPolicy_LateFee(t *testing.T) {
+	policy := Policy{DailyRateCents: 100, CapCents: 500}
+	due := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		returnedAt time.Time
+		want       int64
+	}{
+		{name: "no prazo", returnedAt: due, want: 0},
+		{name: "uma hora de atraso", returnedAt: due.Add(time.Hour), want: 100},
+		{name: "dois dias de atraso", returnedAt: due.Add(48 * time.Hour), want: 200},
+		{name: "limitado ao teto", returnedAt: due.Add(30 * 24 * time.Hour), want: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, policy.LateFee(due, tt.returnedAt))
+		})
+	}
+}
+
+func Test_serviceFine_Record(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      *Entries
+		repoErr    error
+		wantAmount int64
+		wantErr    error
+	}{
+		{
+			name:       "pagamento",
+			input:      &Entries{UserID: 1, Kind: Payment, AmountCents: 300},
+			wantAmount: -300,
+		},
+		{
+			name:       "ajuste positivo",
+			input:      &Entries{UserID: 1, Kind: Adjustment, AmountCents: 150, Note: "dano no exemplar"},
+			wantAmount: 150,
+		},
+		{
+			name:    "pagamento acima do saldo",
+			input:   &Entries{UserID: 1, Kind: Payment, AmountCents: 600},
+			repoErr: ErrAmountExceedsBalance,
+			wantErr: ErrAmountExceedsBalance,
+		},
+		{
+			name:    "abono sem motivo",
+			input:   &Entries{UserID: 1, Kind: Waiver, AmountCents: 100},
+			wantErr: ErrNoteRequired,
+		},
+		{
+			name:    "multa manual",
+			input:   &Entries{UserID: 1, Kind: Accrual, AmountCents: 100},
+			wantErr: ErrInvalidKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockFineRepo)
+			if tt.repoErr != nil || tt.wantErr == nil {
+				mockRepo.On("Create", mock.Anything, tt.input).Return(tt.repoErr)
+			}
+
+			svc := NewFineService(mockRepo, DefaultPolicy())
+
+			err := svc.Record(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantAmount, tt.input.AmountCents)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_serviceFine_IsBlocked(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance int64
+		want    bool
+	}{
+		{name: "abaixo do limite", balance: 1000, want: false},
+		{name: "acima do limite", balance: 1001, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockFineRepo)
+			mockRepo.On("Balance", mock.Anything, int64(1)).Return(tt.balance, nil)
+
+			svc := NewFineService(mockRepo, DefaultPolicy())
+
+			got, err := svc.IsBlocked(context.Background(), 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package fines
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFineRepo struct {
+	mock.Mock
+}
+
+func (m *MockFineRepo) Create(ctx context.Context, e *Entries) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockFineRepo) GetByUser(ctx context.Context, userID int64) ([]Entries, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]Entries), args.Error(1)
+}
+
+func (m *MockFineRepo) Balance(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,76 @@
+package holds
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultPickupWindow é o prazo que o primeiro da fila tem para retirar o
+// exemplar separado antes que a reserva expire.
+const DefaultPickupWindow = 3 * 24 * time.Hour
+
+type Status string
+
+const (
+	Waiting   Status = "waiting"
+	Ready     Status = "ready"
+	Fulfilled Status = "fulfilled"
+	Cancelled Status = "cancelled"
+	Expired   Status = "expired"
+)
+
+var (
+	ErrHoldNotFound   = errors.New("reserva não encontrada")
+	ErrHoldNotActive  = errors.New("reserva não está ativa")
+	ErrDuplicateHold  = errors.New("usuario já possui reserva ativa para o livro")
+	ErrCopyAvailable  = errors.New("livro possui exemplares disponíveis para empréstimo")
+	ErrInvalidHoldRef = errors.New("livro ou usuario invalido")
+)
+
+type Holds struct {
+	ID        int64
+	BookID    int64
+	UserID    int64
+	CopyID    int64
+	Status    Status
+	Position  int
+	CreatedAt time.Time
+	ReadyAt   *time.Time
+	ExpiresAt *time.Time
+}
+
+type HoldCreator interface {
+	Create(ctx context.Context, h *Holds) error
+	Cancel(ctx context.Context, bookID, id int64, now time.Time) error
+	// ExpireReady expira as reservas cujo prazo de retirada terminou e
+	// repassa o exemplar ao próximo da fila.
+	ExpireReady(ctx context.Context, now time.Time) (int, error)
+}
+
+type HoldRead interface {
+	GetById(ctx context.Context, id int64) (*Holds, error)
+	GetByBook(ctx context.Context, bookID int64) ([]Holds, error)
+	GetByUser(ctx context.Context, userID int64) ([]Holds, error)
+	HasActiveHold(ctx context.Context, userID, bookID int64) (bool, error)
+	// HasPendingHolds informa se outro usuario aguarda o livro na fila.
+	HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error)
+	CountAvailableCopies(ctx context.Context, bookID int64) (int, error)
+}
+
+type IHoldRepository interface {
+	HoldCreator
+	HoldRead
+}
+
+func (h *Holds) Validate() error {
+	if h.BookID <= 0 || h.UserID <= 0 {
+		return ErrInvalidHoldRef
+	}
+
+	return nil
+}
+
+func (s Status) Active() bool {
+	return s == Waiting || s == Ready
+}
@@ -0,0 +1,42 @@
+package holds
+
+import "time"
+
+// @Description Usuario da reserva. Só admins informam user_id; para os
+// @Description demais a reserva é sempre do próprio usuario autenticado.
+type HoldRequest struct {
+	UserID int64 `json:"user_id" example:"1"`
+}
+
+type HoldResponse struct {
+	ID        int64  `json:"id"`
+	BookID    int64  `json:"book_id"`
+	UserID    int64  `json:"user_id"`
+	CopyID    int64  `json:"copy_id"`
+	Status    Status `json:"status"`
+	Position  int    `json:"position"`
+	CreatedAt string `json:"created_at"`
+	ReadyAt   string `json:"ready_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format("02/01/06 15:04:05")
+}
+
+func ToResponse(h *Holds) HoldResponse {
+	return HoldResponse{
+		ID:        h.ID,
+		BookID:    h.BookID,
+		UserID:    h.UserID,
+		CopyID:    h.CopyID,
+		Status:    h.Status,
+		Position:  h.Position,
+		CreatedAt: formatTime(&h.CreatedAt),
+		ReadyAt:   formatTime(h.ReadyAt),
+		ExpiresAt: formatTime(h.ExpiresAt),
+	}
+}
@@ -0,0 +1,218 @@
+package holds
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HoldHandler struct {
+	svc    HoldService
+	logApp *zap.Logger
+}
+
+func NewHoldHandler(svc HoldService, logApp *zap.Logger) *HoldHandler {
+	return &HoldHandler{svc: svc, logApp: logApp}
+}
+
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, ErrHoldNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrInvalidHoldRef):
+		return middleware.BadRequest.Messager(err.Error())
+	case errors.Is(err, ErrCopyAvailable), errors.Is(err, ErrDuplicateHold), errors.Is(err, ErrHoldNotActive):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+func toResponseList(holds []Holds) []HoldResponse {
+	response := make([]HoldResponse, 0, len(holds))
+	for _, h := range holds {
+		response = append(response, ToResponse(&h))
+	}
+	return response
+}
+
+// @Summary Reserva um livro
+// @Description Coloca o usuario autenticado na fila de reservas de um livro sem exemplares disponíveis. Admins podem reservar para outro usuario informando user_id.
+// @Tags holds
+// @Accept  json
+// @Produce json
+// @Param   id path int true "ID do livro"
+// @Param   hold body HoldRequest false "Usuario da reserva (só admin)"
+// @Success 201 {object} HoldResponse "Reserva criada com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida"
+// @Failure 403 {object} middleware.APIError "Reserva para outro usuario sem perfil admin"
+// @Failure 409 {object} middleware.APIError "Livro disponível ou reserva duplicada"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/holds [post]
+func (h *HoldHandler) PlaceHold(c *gin.Context) {
+	h.logApp.Info("Rota de reservar livro")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	claims, ok := middleware.ClaimsFrom(c)
+	if !ok {
+		_ = c.Error(middleware.Unauthorized)
+		return
+	}
+
+	var dto HoldRequest
+
+	// o corpo é opcional: sem ele, a reserva é do próprio usuario
+	if err := c.ShouldBindJSON(&dto); err != nil && !errors.Is(err, io.EOF) {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	userID := claims.UserID
+	if dto.UserID != 0 && dto.UserID != userID {
+		if !middleware.IsAdmin(c) {
+			_ = c.Error(middleware.Forbidden.Messager("Só admins reservam para outro usuario."))
+			return
+		}
+		userID = dto.UserID
+	}
+
+	hold := &Holds{BookID: bookID, UserID: userID}
+
+	if err := h.svc.Place(c.Request.Context(), hold); err != nil {
+		h.logApp.Error("falha ao reservar livro", zap.Error(err))
+		_ = c.Error(holdError(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(hold))
+}
+
+// @Summary Fila de reservas do livro
+// @Description Retorna as reservas ativas do livro em ordem de chegada.
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Success 200 {array} HoldResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/holds [get]
+func (h *HoldHandler) ReadBookHolds(c *gin.Context) {
+	h.logApp.Info("Rota de fila de reservas")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	holds, err := h.svc.GetByBook(c.Request.Context(), bookID)
+	if err != nil {
+		h.logApp.Error("falha ao obter reservas", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toResponseList(holds))
+}
+
+// @Summary Cancela uma reserva
+// @Description Cancela a reserva; se havia exemplar separado, ele passa ao próximo da fila. Só o dono da reserva ou um admin pode cancelá-la.
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do livro"
+// @Param holdId path int true "ID da reserva"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 403 {object} middleware.APIError "Reserva de outro usuario"
+// @Failure 404 {object} middleware.APIError "Reserva não encontrada"
+// @Failure 409 {object} middleware.APIError "Reserva não está ativa"
+// @Security ApiKeyAuth
+// @Router /api/books/{id}/holds/{holdId} [delete]
+func (h *HoldHandler) CancelHold(c *gin.Context) {
+	h.logApp.Info("Rota de cancelar reserva")
+
+	bookID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	holdID, err := middleware.GetIdParamByName(c, "holdId")
+	if err != nil {
+		h.logApp.Error("falha ao verificar id da reserva", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	hold, err := h.svc.GetById(c.Request.Context(), holdID)
+	if err == nil && hold.BookID != bookID {
+		err = ErrHoldNotFound
+	}
+	if err != nil {
+		h.logApp.Error("falha ao obter reserva", zap.Error(err))
+		_ = c.Error(holdError(err))
+		return
+	}
+
+	if !middleware.SelfOrAdmin(c, hold.UserID) {
+		_ = c.Error(middleware.Forbidden.Messager("Acesso negado à reserva de outro usuario."))
+		return
+	}
+
+	if err := h.svc.Cancel(c.Request.Context(), bookID, holdID); err != nil {
+		h.logApp.Error("falha ao cancelar reserva", zap.Error(err))
+		_ = c.Error(holdError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Reservas do usuario
+// @Description Retorna as reservas do usuario, ativas e encerradas. Só o próprio usuario ou um admin.
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do usuario"
+// @Success 200 {array} HoldResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 403 {object} middleware.APIError "Reservas de outro usuario"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/holds [get]
+func (h *HoldHandler) ReadUserHolds(c *gin.Context) {
+	h.logApp.Info("Rota de reservas do usuário")
+
+	userID, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	holds, err := h.svc.GetByUser(c.Request.Context(), userID)
+	if err != nil {
+		h.logApp.Error("falha ao obter reservas", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, toResponseList(holds))
+}
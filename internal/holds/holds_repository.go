@@ -0,0 +1,250 @@
+package holds
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+)
+
+type HoldRepository struct {
+	db *sql.DB
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+const selectHolds = `SELECT h.id, h.book_id, h.user_id, h.copy_id, h.status, h.created_at, h.ready_at, h.expires_at,
+	CASE WHEN h.status = 'waiting' THEN
+		(SELECT COUNT(*) FROM holds w WHERE w.book_id = h.book_id AND w.status = 'waiting' AND w.id <= h.id)
+	ELSE 0 END
+	FROM holds h`
+
+// Create grava a reserva. O índice único sobre as reservas ativas recusa a
+// segunda de dois pedidos simultâneos que passaram pela verificação do serviço.
+func (r *HoldRepository) Create(ctx context.Context, h *Holds) error {
+	query := "INSERT INTO holds (book_id, user_id, status, created_at) VALUES (?, ?, ?, ?)"
+
+	result, err := r.db.ExecContext(ctx, query, h.BookID, h.UserID, h.Status, h.CreatedAt)
+	if database.IsDuplicateKey(err) {
+		return ErrDuplicateHold
+	}
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	h.ID = id
+	return nil
+}
+
+func (r *HoldRepository) Cancel(ctx context.Context, bookID, id int64, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		status Status
+		copyID sql.NullInt64
+	)
+
+	err = tx.QueryRowContext(ctx, "SELECT status, copy_id FROM holds WHERE id = ? AND book_id = ?", id, bookID).
+		Scan(&status, &copyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrHoldNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if !status.Active() {
+		return ErrHoldNotActive
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE holds SET status = ? WHERE id = ?", Cancelled, id); err != nil {
+		return err
+	}
+
+	if status == Ready && copyID.Valid {
+		if err := ReleaseCopy(ctx, tx, bookID, copyID.Int64, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *HoldRepository) ExpireReady(ctx context.Context, now time.Time) (int, error) {
+	type expiredHold struct {
+		id, bookID, copyID int64
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, book_id, copy_id FROM holds WHERE status = ? AND expires_at < ? ORDER BY id ASC", Ready, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var expired []expiredHold
+	for rows.Next() {
+		var h expiredHold
+		if err := rows.Scan(&h.id, &h.bookID, &h.copyID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		expired = append(expired, h)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, h := range expired {
+		ok, err := r.expireHold(ctx, h.id, h.bookID, h.copyID, now)
+		if err != nil {
+			return count, err
+		}
+		if ok {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *HoldRepository) expireHold(ctx context.Context, id, bookID, copyID int64, now time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.ExecContext(ctx, "UPDATE holds SET status = ? WHERE id = ? AND status = ?", Expired, id, Ready)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if err := ReleaseCopy(ctx, tx, bookID, copyID, now); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (r *HoldRepository) GetByBook(ctx context.Context, bookID int64) ([]Holds, error) {
+	query := selectHolds + " WHERE h.book_id = ? AND h.status IN (?, ?) ORDER BY h.id ASC"
+
+	return r.query(ctx, query, bookID, Waiting, Ready)
+}
+
+func (r *HoldRepository) GetById(ctx context.Context, id int64) (*Holds, error) {
+	holds, err := r.query(ctx, selectHolds+" WHERE h.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(holds) == 0 {
+		return nil, ErrHoldNotFound
+	}
+	return &holds[0], nil
+}
+
+func (r *HoldRepository) GetByUser(ctx context.Context, userID int64) ([]Holds, error) {
+	query := selectHolds + " WHERE h.user_id = ? ORDER BY h.id DESC"
+
+	return r.query(ctx, query, userID)
+}
+
+func (r *HoldRepository) HasActiveHold(ctx context.Context, userID, bookID int64) (bool, error) {
+	query := "SELECT COUNT(*) FROM holds WHERE user_id = ? AND book_id = ? AND status IN (?, ?)"
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID, bookID, Waiting, Ready).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *HoldRepository) HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error) {
+	query := "SELECT COUNT(*) FROM holds WHERE book_id = ? AND user_id <> ? AND status IN (?, ?)"
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, bookID, exceptUserID, Waiting, Ready).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *HoldRepository) CountAvailableCopies(ctx context.Context, bookID int64) (int, error) {
+	query := "SELECT COUNT(*) FROM copies WHERE book_id = ? AND status = ?"
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, bookID, copies.Available).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *HoldRepository) query(ctx context.Context, query string, args ...any) ([]Holds, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []Holds
+
+	for rows.Next() {
+		var (
+			h                  Holds
+			copyID             sql.NullInt64
+			readyAt, expiresAt sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&h.ID, &h.BookID, &h.UserID, &copyID, &h.Status, &h.CreatedAt, &readyAt, &expiresAt, &h.Position,
+		); err != nil {
+			return nil, err
+		}
+
+		h.CopyID = copyID.Int64
+		if readyAt.Valid {
+			h.ReadyAt = &readyAt.Time
+		}
+		if expiresAt.Valid {
+			h.ExpiresAt = &expiresAt.Time
+		}
+
+		holds = append(holds, h)
+	}
+
+	return holds, rows.Err()
+}
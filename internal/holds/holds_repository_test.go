@@ -0,0 +1,270 @@
+package holds_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/holds"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/loans"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func seedHoldData(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	if err := authors.NewAuthorsRepository(db).Create(ctx, &authors.Authors{Name: "Autor X", Description: "Teste"}); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+
+	if err := books.NewBookRepository(db).Create(ctx, &books.Books{Title: "Go Lang", Description: "D1", Content: "C1", AuthorID: 1}); err != nil {
+		t.Fatalf("book: %v", err)
+	}
+
+	if err := copies.NewCopyRepository(db, holds.NewQueue()).Create(ctx, &copies.Copies{BookID: 1, Barcode: "0001", Condition: copies.Good, Status: copies.Available}); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+
+	userRepo := users.NewUsersRepository(db)
+	for _, email := range []string{"um@email.com", "dois@email.com", "tres@email.com"} {
+		if err := userRepo.Create(ctx, &users.Users{Name: "Leitor", Email: email, Username: email, Password: "hash", Role: users.User}); err != nil {
+			t.Fatalf("user: %v", err)
+		}
+	}
+}
+
+func copyStatus(t *testing.T, db *sql.DB) copies.Status {
+	found, err := copies.NewCopyRepository(db, holds.NewQueue()).GetById(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar exemplar: %v", err)
+	}
+	return found.Status
+}
+
+func TestHoldRepository_Queue(t *testing.T) {
+	db := database.SetupTestDB()
+	seedHoldData(t, db)
+	ctx := context.Background()
+
+	loanRepo := loans.NewLoanRepository(db)
+	repo := holds.NewHoldRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	loan := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := loanRepo.Create(ctx, loan); err != nil {
+		t.Fatalf("erro ao emprestar: %v", err)
+	}
+
+	second := &holds.Holds{BookID: 1, UserID: 2, Status: holds.Waiting, CreatedAt: now}
+	third := &holds.Holds{BookID: 1, UserID: 3, Status: holds.Waiting, CreatedAt: now}
+	for _, h := range []*holds.Holds{second, third} {
+		if err := repo.Create(ctx, h); err != nil {
+			t.Fatalf("erro ao reservar: %v", err)
+		}
+	}
+
+	queue, err := repo.GetByBook(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar fila: %v", err)
+	}
+	if len(queue) != 2 || queue[0].Position != 1 || queue[1].Position != 2 {
+		t.Fatalf("fila inesperada: %+v", queue)
+	}
+
+	if err := loanRepo.Return(ctx, loan.ID, now, nil); err != nil {
+		t.Fatalf("erro ao devolver: %v", err)
+	}
+
+	if status := copyStatus(t, db); status != copies.OnHold {
+		t.Fatalf("esperava exemplar separado, recebeu %s", status)
+	}
+
+	queue, err = repo.GetByBook(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar fila: %v", err)
+	}
+	if queue[0].Status != holds.Ready || queue[0].UserID != 2 || queue[1].Position != 1 {
+		t.Fatalf("esperava primeiro da fila pronto: %+v", queue)
+	}
+
+	other := &loans.Loans{BookID: 1, UserID: 3, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := loanRepo.Create(ctx, other); !errors.Is(err, loans.ErrBookUnavailable) {
+		t.Fatalf("esperava exemplar reservado para outro usuario, recebeu %v", err)
+	}
+
+	expired, err := repo.ExpireReady(ctx, now.Add(holds.DefaultPickupWindow+time.Hour))
+	if err != nil {
+		t.Fatalf("erro ao expirar: %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("esperava 1 reserva expirada, recebeu %d", expired)
+	}
+
+	queue, err = repo.GetByBook(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar fila: %v", err)
+	}
+	if len(queue) != 1 || queue[0].UserID != 3 || queue[0].Status != holds.Ready {
+		t.Fatalf("esperava fila avançar para o próximo: %+v", queue)
+	}
+
+	later := now.Add(holds.DefaultPickupWindow + 2*time.Hour)
+	pickup := &loans.Loans{BookID: 1, UserID: 3, LoanedAt: later, DueDate: later.Add(loans.DefaultLoanPeriod)}
+	if err := loanRepo.Create(ctx, pickup); err != nil {
+		t.Fatalf("esperava retirada da reserva, recebeu %v", err)
+	}
+
+	if status := copyStatus(t, db); status != copies.OnLoan {
+		t.Errorf("esperava exemplar emprestado, recebeu %s", status)
+	}
+
+	userHolds, err := repo.GetByUser(ctx, 3)
+	if err != nil {
+		t.Fatalf("erro ao buscar reservas: %v", err)
+	}
+	if len(userHolds) != 1 || userHolds[0].Status != holds.Fulfilled {
+		t.Errorf("esperava reserva concluída: %+v", userHolds)
+	}
+}
+
+func TestHoldRepository_ExemplarPerdido(t *testing.T) {
+	db := database.SetupTestDB()
+	seedHoldData(t, db)
+	ctx := context.Background()
+
+	loanRepo := loans.NewLoanRepository(db)
+	copyRepo := copies.NewCopyRepository(db, holds.NewQueue())
+	repo := holds.NewHoldRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	loan := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := loanRepo.Create(ctx, loan); err != nil {
+		t.Fatalf("erro ao emprestar: %v", err)
+	}
+
+	for _, userID := range []int64{2, 3} {
+		if err := repo.Create(ctx, &holds.Holds{BookID: 1, UserID: userID, Status: holds.Waiting, CreatedAt: now}); err != nil {
+			t.Fatalf("erro ao reservar: %v", err)
+		}
+	}
+
+	if err := loanRepo.Return(ctx, loan.ID, now, nil); err != nil {
+		t.Fatalf("erro ao devolver: %v", err)
+	}
+
+	// o exemplar separado para o usuario 2 some da estante
+	if err := copyRepo.Update(ctx, &copies.Copies{ID: 1, BookID: 1, Barcode: "0001", Condition: copies.Good, Status: copies.Lost}); err != nil {
+		t.Fatalf("erro ao marcar perdido: %v", err)
+	}
+
+	queue, err := repo.GetByBook(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar fila: %v", err)
+	}
+	if len(queue) != 2 || queue[0].UserID != 2 || queue[0].Status != holds.Waiting || queue[0].CopyID != 0 || queue[0].Position != 1 {
+		t.Fatalf("esperava reserva de volta à frente da fila: %+v", queue)
+	}
+
+	pickup := &loans.Loans{BookID: 1, UserID: 2, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := loanRepo.Create(ctx, pickup); !errors.Is(err, loans.ErrBookUnavailable) {
+		t.Fatalf("esperava livro indisponível, recebeu %v", err)
+	}
+
+	// o empréstimo antigo devolvido de novo não recoloca o exemplar perdido na fila
+	if err := holds.ReleaseCopy(ctx, db, 1, 1, now); err != nil {
+		t.Fatalf("erro ao liberar: %v", err)
+	}
+	if status := copyStatus(t, db); status != copies.Lost {
+		t.Errorf("esperava exemplar perdido, recebeu %s", status)
+	}
+
+	queue, err = repo.GetByBook(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar fila: %v", err)
+	}
+	if queue[0].Status != holds.Waiting || queue[1].Status != holds.Waiting {
+		t.Errorf("esperava fila em espera: %+v", queue)
+	}
+}
+
+func TestHoldRepository_ExemplarNovoNaFila(t *testing.T) {
+	db := database.SetupTestDB()
+	seedHoldData(t, db)
+	ctx := context.Background()
+
+	loanRepo := loans.NewLoanRepository(db)
+	copyRepo := copies.NewCopyRepository(db, holds.NewQueue())
+	repo := holds.NewHoldRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	loan := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := loanRepo.Create(ctx, loan); err != nil {
+		t.Fatalf("erro ao emprestar: %v", err)
+	}
+
+	for _, userID := range []int64{2, 3} {
+		if err := repo.Create(ctx, &holds.Holds{BookID: 1, UserID: userID, Status: holds.Waiting, CreatedAt: now}); err != nil {
+			t.Fatalf("erro ao reservar: %v", err)
+		}
+	}
+
+	if err := repo.Create(ctx, &holds.Holds{BookID: 1, UserID: 2, Status: holds.Waiting, CreatedAt: now}); !errors.Is(err, holds.ErrDuplicateHold) {
+		t.Fatalf("esperava ErrDuplicateHold, recebeu %v", err)
+	}
+
+	added := &copies.Copies{BookID: 1, Barcode: "0002", Condition: copies.Good, Status: copies.Available}
+	if err := copyRepo.Create(ctx, added); err != nil {
+		t.Fatalf("erro ao cadastrar exemplar: %v", err)
+	}
+	if added.Status != copies.OnHold {
+		t.Errorf("esperava exemplar novo separado, recebeu %s", added.Status)
+	}
+
+	repaired := &copies.Copies{BookID: 1, Barcode: "0003", Condition: copies.Good, Status: copies.InRepair}
+	if err := copyRepo.Create(ctx, repaired); err != nil {
+		t.Fatalf("erro ao cadastrar exemplar: %v", err)
+	}
+
+	repaired.Status = copies.Available
+	if err := copyRepo.Update(ctx, repaired); err != nil {
+		t.Fatalf("erro ao liberar exemplar: %v", err)
+	}
+	if repaired.Status != copies.OnHold {
+		t.Errorf("esperava exemplar consertado separado, recebeu %s", repaired.Status)
+	}
+
+	queue, err := repo.GetByBook(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar fila: %v", err)
+	}
+	if len(queue) != 2 || queue[0].CopyID != added.ID || queue[1].CopyID != repaired.ID ||
+		queue[0].Status != holds.Ready || queue[1].Status != holds.Ready {
+		t.Fatalf("esperava as duas reservas prontas: %+v", queue)
+	}
+
+	// sem fila, o exemplar novo fica disponível
+	spare := &copies.Copies{BookID: 1, Barcode: "0004", Condition: copies.Good, Status: copies.Available}
+	if err := copyRepo.Create(ctx, spare); err != nil {
+		t.Fatalf("erro ao cadastrar exemplar: %v", err)
+	}
+	if spare.Status != copies.Available {
+		t.Errorf("esperava exemplar disponível, recebeu %s", spare.Status)
+	}
+
+	// a reserva encerrada libera o usuario para reservar de novo
+	if err := repo.Cancel(ctx, 1, queue[0].ID, now); err != nil {
+		t.Fatalf("erro ao cancelar: %v", err)
+	}
+	if err := repo.Create(ctx, &holds.Holds{BookID: 1, UserID: 2, Status: holds.Waiting, CreatedAt: now}); err != nil {
+		t.Errorf("esperava nova reserva após cancelar, recebeu %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package holds
+
+import (
+	"context"
+	"time"
+)
+
+type HoldService interface {
+	Place(ctx context.Context, h *Holds) error
+	Cancel(ctx context.Context, bookID, id int64) error
+	GetById(ctx context.Context, id int64) (*Holds, error)
+	GetByBook(ctx context.Context, bookID int64) ([]Holds, error)
+	GetByUser(ctx context.Context, userID int64) ([]Holds, error)
+	ExpireHolds(ctx context.Context) (int, error)
+	HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error)
+}
+
+type serviceHold struct {
+	repo IHoldRepository
+	now  func() time.Time
+}
+
+func NewHoldService(repo IHoldRepository) *serviceHold {
+	return &serviceHold{
+		repo: repo,
+		now:  func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+// Place coloca o usuario no fim da fila do livro. Só é possível reservar
+// títulos sem exemplares disponíveis.
+func (s *serviceHold) Place(ctx context.Context, h *Holds) error {
+	if err := h.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.ExpireHolds(ctx); err != nil {
+		return err
+	}
+
+	available, err := s.repo.CountAvailableCopies(ctx, h.BookID)
+	if err != nil {
+		return err
+	}
+
+	if available > 0 {
+		return ErrCopyAvailable
+	}
+
+	active, err := s.repo.HasActiveHold(ctx, h.UserID, h.BookID)
+	if err != nil {
+		return err
+	}
+
+	if active {
+		return ErrDuplicateHold
+	}
+
+	h.Status = Waiting
+	h.CreatedAt = s.now()
+
+	return s.repo.Create(ctx, h)
+}
+
+func (s *serviceHold) GetById(ctx context.Context, id int64) (*Holds, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *serviceHold) Cancel(ctx context.Context, bookID, id int64) error {
+	return s.repo.Cancel(ctx, bookID, id, s.now())
+}
+
+func (s *serviceHold) GetByBook(ctx context.Context, bookID int64) ([]Holds, error) {
+	if _, err := s.ExpireHolds(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByBook(ctx, bookID)
+}
+
+func (s *serviceHold) GetByUser(ctx context.Context, userID int64) ([]Holds, error) {
+	if _, err := s.ExpireHolds(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByUser(ctx, userID)
+}
+
+func (s *serviceHold) ExpireHolds(ctx context.Context) (int, error) {
+	return s.repo.ExpireReady(ctx, s.now())
+}
+
+func (s *serviceHold) HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error) {
+	if _, err := s.ExpireHolds(ctx); err != nil {
+		return false, err
+	}
+
+	return s.repo.HasPendingHolds(ctx, bookID, exceptUserID)
+}
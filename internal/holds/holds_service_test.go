@@ -0,0 +1,62 @@
+package holds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_serviceHold_Place(t *testing.T) {
+	tests := []struct {
+		name      string
+		available int
+		active    bool
+		wantErr   error
+	}{
+		{
+			name: "sucesso",
+		},
+		{
+			name:      "exemplar disponível",
+			available: 1,
+			wantErr:   ErrCopyAvailable,
+		},
+		{
+			name:    "reserva duplicada",
+			active:  true,
+			wantErr: ErrDuplicateHold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &Holds{BookID: 1, UserID: 1}
+
+			mockRepo := new(MockHoldRepo)
+			mockRepo.On("ExpireReady", mock.Anything, mock.Anything).Return(0, nil)
+			mockRepo.On("CountAvailableCopies", mock.Anything, int64(1)).Return(tt.available, nil)
+
+			if tt.available == 0 {
+				mockRepo.On("HasActiveHold", mock.Anything, int64(1), int64(1)).Return(tt.active, nil)
+			}
+
+			if tt.wantErr == nil {
+				mockRepo.On("Create", mock.Anything, input).Return(nil)
+			}
+
+			svc := NewHoldService(mockRepo)
+
+			err := svc.Place(context.Background(), input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, Waiting, input.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package holds
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunExpirer expira periodicamente as reservas não retiradas, fazendo a fila
+// andar mesmo sem requisições às rotas de reservas. Encerra com o contexto.
+func RunExpirer(ctx context.Context, svc HoldService, interval time.Duration, logApp *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := svc.ExpireHolds(ctx)
+			if err != nil {
+				logApp.Error("falha ao expirar reservas", zap.Error(err))
+				continue
+			}
+
+			if count > 0 {
+				logApp.Info("reservas expiradas", zap.Int("total", count))
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package holds
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHoldRepo struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepo) Create(ctx context.Context, h *Holds) error {
+	args := m.Called(ctx, h)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepo) Cancel(ctx context.Context, bookID, id int64, now time.Time) error {
+	args := m.Called(ctx, bookID, id, now)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepo) ExpireReady(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockHoldRepo) GetByBook(ctx context.Context, bookID int64) ([]Holds, error) {
+	args := m.Called(ctx, bookID)
+	return args.Get(0).([]Holds), args.Error(1)
+}
+
+func (m *MockHoldRepo) GetById(ctx context.Context, id int64) (*Holds, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Holds), args.Error(1)
+}
+
+func (m *MockHoldRepo) GetByUser(ctx context.Context, userID int64) ([]Holds, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]Holds), args.Error(1)
+}
+
+func (m *MockHoldRepo) HasActiveHold(ctx context.Context, userID, bookID int64) (bool, error) {
+	args := m.Called(ctx, userID, bookID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepo) HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error) {
+	args := m.Called(ctx, bookID, exceptUserID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepo) CountAvailableCopies(ctx context.Context, bookID int64) (int, error) {
+	args := m.Called(ctx, bookID)
+	return args.Int(0), args.Error(1)
+}
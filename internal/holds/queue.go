@@ -0,0 +1,137 @@
+package holds
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+)
+
+// Querier é satisfeito por *sql.DB e *sql.Tx, permitindo que outros
+// repositórios movimentem a fila dentro das próprias transações.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ReleaseCopy devolve um exemplar ao acervo: ele é separado para a reserva
+// mais antiga em espera do livro ou, sem fila, fica disponível. Um exemplar
+// que saiu de circulação (perdido ou em reparo) não volta para a fila.
+func ReleaseCopy(ctx context.Context, q Querier, bookID, copyID int64, now time.Time) error {
+	var holdID int64
+
+	query := "SELECT id FROM holds WHERE book_id = ? AND status = ? ORDER BY id ASC LIMIT 1"
+
+	err := q.QueryRowContext(ctx, query, bookID, Waiting).Scan(&holdID)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err := setCopyStatus(ctx, q, copyID, copies.Available)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	released, err := setCopyStatus(ctx, q, copyID, copies.OnHold)
+	if err != nil || !released {
+		return err
+	}
+
+	query = "UPDATE holds SET status = ?, copy_id = ?, ready_at = ?, expires_at = ? WHERE id = ?"
+
+	_, err = q.ExecContext(ctx, query, Ready, copyID, now, now.Add(DefaultPickupWindow), holdID)
+	return err
+}
+
+// RequeueCopy desfaz a reserva pronta que aguardava o exemplar, quando ele
+// sai de circulação. A reserva volta a esperar na frente da fila e recebe
+// outro exemplar disponível do livro, se houver.
+func RequeueCopy(ctx context.Context, q Querier, bookID, copyID int64, now time.Time) error {
+	query := "UPDATE holds SET status = ?, copy_id = NULL, ready_at = NULL, expires_at = NULL WHERE copy_id = ? AND status = ?"
+
+	result, err := q.ExecContext(ctx, query, Waiting, copyID, Ready)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil || rowsAffected == 0 {
+		return err
+	}
+
+	var spareID int64
+
+	query = "SELECT id FROM copies WHERE book_id = ? AND status = ? ORDER BY id ASC LIMIT 1"
+
+	err = q.QueryRowContext(ctx, query, bookID, copies.Available).Scan(&spareID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return ReleaseCopy(ctx, q, bookID, spareID, now)
+}
+
+// ClaimReadyHold conclui a reserva pronta do usuario para o livro e retorna
+// o exemplar separado para ela.
+func ClaimReadyHold(ctx context.Context, q Querier, userID, bookID int64, now time.Time) (int64, bool, error) {
+	var holdID, copyID int64
+
+	query := `SELECT h.id, h.copy_id FROM holds h
+		JOIN copies c ON c.id = h.copy_id AND c.status = ?
+		WHERE h.user_id = ? AND h.book_id = ? AND h.status = ? AND h.expires_at >= ?
+		ORDER BY h.id ASC LIMIT 1`
+
+	err := q.QueryRowContext(ctx, query, copies.OnHold, userID, bookID, Ready, now).Scan(&holdID, &copyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	if _, err := q.ExecContext(ctx, "UPDATE holds SET status = ? WHERE id = ?", Fulfilled, holdID); err != nil {
+		return 0, false, err
+	}
+
+	return copyID, true, nil
+}
+
+// setCopyStatus só troca o status de exemplares em circulação e informa se
+// trocou; perdidos e em reparo ficam como estão.
+func setCopyStatus(ctx context.Context, q Querier, copyID int64, status copies.Status) (bool, error) {
+	query := "UPDATE copies SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND status IN (?, ?, ?)"
+
+	result, err := q.ExecContext(ctx, query, status, copyID, copies.OnLoan, copies.OnHold, copies.Available)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Queue dá ao cadastro de exemplares acesso à fila de reservas, já que o
+// pacote copies não pode importar este.
+type Queue struct {
+	now func() time.Time
+}
+
+func NewQueue() *Queue {
+	return &Queue{now: func() time.Time { return time.Now().UTC().Truncate(time.Second) }}
+}
+
+func (q *Queue) Release(ctx context.Context, tx *sql.Tx, bookID, copyID int64) error {
+	return ReleaseCopy(ctx, tx, bookID, copyID, q.now())
+}
+
+func (q *Queue) Withdraw(ctx context.Context, tx *sql.Tx, bookID, copyID int64) error {
+	return RequeueCopy(ctx, tx, bookID, copyID, q.now())
+}
@@ -0,0 +1,105 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+)
+
+// DefaultLoanPeriod é o prazo padrão de devolução de um empréstimo.
+const DefaultLoanPeriod = 14 * 24 * time.Hour
+
+type Status string
+
+const (
+	Active   Status = "active"
+	Returned Status = "returned"
+)
+
+var (
+	ErrLoanNotFound     = errors.New("empréstimo não encontrado")
+	ErrBookUnavailable  = errors.New("livro indisponível para empréstimo")
+	ErrAlreadyReturned  = errors.New("empréstimo já devolvido")
+	ErrFinesBlocked     = errors.New("usuario com multas acima do limite permitido")
+	ErrMaxRenewals      = errors.New("limite de renovações atingido")
+	ErrLoanOverdue      = errors.New("empréstimo em atraso não pode ser renovado")
+	ErrBookOnHold       = errors.New("livro possui reservas na fila")
+	ErrUserNotFound     = errors.New("usuario não encontrado")
+	ErrRenewalConflict  = errors.New("empréstimo foi devolvido ou renovado por outra requisição")
+	ErrEmailNotVerified = errors.New("usuario precisa confirmar o email antes de pegar livros emprestados")
+)
+
+type Loans struct {
+	ID         int64
+	BookID     int64
+	CopyID     int64
+	UserID     int64
+	LoanedAt   time.Time
+	DueDate    time.Time
+	ReturnedAt *time.Time
+	Renewals   int
+}
+
+type Filters struct {
+	Status Status
+}
+
+type LoanCreator interface {
+	// Create registra o empréstimo usando o exemplar separado pela reserva
+	// pronta do usuario, o exemplar informado em CopyID ou, quando vazio, o
+	// primeiro exemplar disponível do livro.
+	Create(ctx context.Context, l *Loans) error
+	Return(ctx context.Context, id int64, returnedAt time.Time, fine *fines.Entries) error
+	// Renew grava o novo prazo somente se o empréstimo seguir ativo e com o
+	// número de renovações lido antes da avaliação das regras.
+	Renew(ctx context.Context, id int64, dueDate time.Time, renewals int) error
+}
+
+type LoanRead interface {
+	GetById(ctx context.Context, id int64) (*Loans, error)
+	GetByUser(ctx context.Context, userID int64, filter *Filters) ([]Loans, error)
+	UserRole(ctx context.Context, userID int64) (string, error)
+	EmailVerified(ctx context.Context, userID int64) (bool, error)
+}
+
+type ILoanRepository interface {
+	LoanCreator
+	LoanRead
+}
+
+// FineLedger é a parte do controle de multas usada pela circulação: calcular a
+// multa de uma devolução atrasada e barrar empréstimos de quem deve demais.
+type FineLedger interface {
+	LateReturnEntry(userID, loanID int64, dueDate, returnedAt time.Time) (*fines.Entries, error)
+	IsBlocked(ctx context.Context, userID int64) (bool, error)
+}
+
+func (l *Loans) Validate() error {
+	if l.BookID <= 0 {
+		return errors.New("livro invalido")
+	}
+
+	if l.UserID <= 0 {
+		return errors.New("usuario invalido")
+	}
+
+	return nil
+}
+
+func (l *Loans) Status() Status {
+	if l.ReturnedAt != nil {
+		return Returned
+	}
+	return Active
+}
+
+// IsOverdue informa se o empréstimo passou do prazo, considerando a data de
+// devolução quando o livro já foi devolvido.
+func (l *Loans) IsOverdue(now time.Time) bool {
+	if l.ReturnedAt != nil {
+		return l.ReturnedAt.After(l.DueDate)
+	}
+	return now.After(l.DueDate)
+}
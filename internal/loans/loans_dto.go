@@ -0,0 +1,50 @@
+package loans
+
+import "time"
+
+// @Description Dados necessários para emprestar um livro
+type LoanRequest struct {
+	BookID int64 `json:"book_id" binding:"required" example:"1"`
+	CopyID int64 `json:"copy_id" example:"1"`
+	UserID int64 `json:"user_id" binding:"required" example:"1"`
+}
+
+type LoanResponse struct {
+	ID         int64  `json:"id"`
+	BookID     int64  `json:"book_id"`
+	CopyID     int64  `json:"copy_id"`
+	UserID     int64  `json:"user_id"`
+	LoanedAt   string `json:"loaned_at"`
+	DueDate    string `json:"due_date"`
+	ReturnedAt string `json:"returned_at"`
+	Status     Status `json:"status"`
+	Overdue    bool   `json:"overdue"`
+	Renewals   int    `json:"renewals"`
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02/01/06 15:04:05")
+}
+
+func ToResponse(l *Loans) LoanResponse {
+	resp := LoanResponse{
+		ID:       l.ID,
+		BookID:   l.BookID,
+		CopyID:   l.CopyID,
+		UserID:   l.UserID,
+		LoanedAt: formatTime(l.LoanedAt),
+		DueDate:  formatTime(l.DueDate),
+		Status:   l.Status(),
+		Overdue:  l.IsOverdue(time.Now()),
+		Renewals: l.Renewals,
+	}
+
+	if l.ReturnedAt != nil {
+		resp.ReturnedAt = formatTime(*l.ReturnedAt)
+	}
+
+	return resp
+}
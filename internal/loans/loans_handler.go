@@ -0,0 +1,250 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type LoanHandler struct {
+	svc    LoanService
+	logApp *zap.Logger
+}
+
+func NewLoanHandler(svc LoanService, logApp *zap.Logger) *LoanHandler {
+	return &LoanHandler{svc: svc, logApp: logApp}
+}
+
+func loanError(err error) error {
+	switch {
+	case errors.Is(err, ErrLoanNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrBookUnavailable):
+		return middleware.Conflict.Messager(ErrBookUnavailable.Error())
+	case errors.Is(err, ErrAlreadyReturned):
+		return middleware.Conflict.Messager(ErrAlreadyReturned.Error())
+	case errors.Is(err, ErrUserNotFound):
+		return middleware.NotFound.Messager(ErrUserNotFound.Error())
+	case errors.Is(err, ErrFinesBlocked), errors.Is(err, ErrMaxRenewals), errors.Is(err, ErrLoanOverdue),
+		errors.Is(err, ErrBookOnHold), errors.Is(err, ErrRenewalConflict), errors.Is(err, ErrEmailNotVerified):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Empresta um livro
+// @Description Recebe um objeto JSON LoanRequest e registra o empréstimo de um exemplar do livro para o usuario.
+// @Description Sem copy_id, o primeiro exemplar disponível é usado.
+// @Description O prazo de devolução depende do perfil do usuario.
+// @Tags loans
+// @Accept  json
+// @Produce json
+// @Param   loan body LoanRequest true "Livro e usuario do empréstimo"
+// @Success 201 {object} LoanResponse "Empréstimo criado com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 404 {object} middleware.APIError "Usuario não encontrado"
+// @Failure 409 {object} middleware.APIError "Livro indisponível ou usuario bloqueado por multas"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/loans [post]
+func (h *LoanHandler) CreateLoan(c *gin.Context) {
+	h.logApp.Info("Rota de emprestar livro")
+
+	var dto LoanRequest
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	loan := &Loans{
+		BookID: dto.BookID,
+		CopyID: dto.CopyID,
+		UserID: dto.UserID,
+	}
+
+	if err := h.svc.Checkout(c.Request.Context(), loan); err != nil {
+		h.logApp.Error("falha ao emprestar livro", zap.Error(err))
+		_ = c.Error(loanError(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(loan))
+}
+
+// @Summary Devolve um livro
+// @Description Registra a devolução de um empréstimo ativo. Devoluções atrasadas geram multa no extrato do usuario.
+// @Tags loans
+// @Accept  json
+// @Produce json
+// @Param   id path int true "ID do empréstimo"
+// @Success 200 {object} LoanResponse "Devolução registrada"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 403 {object} middleware.APIError "Empréstimo de outro usuario"
+// @Failure 404 {object} middleware.APIError "Empréstimo não encontrado"
+// @Failure 409 {object} middleware.APIError "Empréstimo já devolvido"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/loans/{id}/return [post]
+func (h *LoanHandler) ReturnLoan(c *gin.Context) {
+	h.logApp.Info("Rota de devolver livro")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	loan, err := h.svc.Return(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao devolver livro", zap.Error(err))
+		_ = c.Error(loanError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(loan))
+}
+
+// @Summary Renova um empréstimo
+// @Description Estende o prazo do empréstimo pelo período do perfil do usuario.
+// @Description A renovação é recusada ao atingir o limite de renovações, com o empréstimo em atraso,
+// @Description com reservas de outros usuarios para o livro ou com o usuario bloqueado por multas.
+// @Description Só o usuario do empréstimo ou um admin pode renová-lo.
+// @Tags loans
+// @Accept  json
+// @Produce json
+// @Param   id path int true "ID do empréstimo"
+// @Success 200 {object} LoanResponse "Renovação registrada"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 403 {object} middleware.APIError "Empréstimo de outro usuario"
+// @Failure 404 {object} middleware.APIError "Empréstimo não encontrado"
+// @Failure 409 {object} middleware.APIError "Renovação não permitida pela política"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/loans/{id}/renew [post]
+func (h *LoanHandler) RenewLoan(c *gin.Context) {
+	h.logApp.Info("Rota de renovar empréstimo")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	current, err := h.svc.GetById(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao obter empréstimo", zap.Error(err))
+		_ = c.Error(loanError(err))
+		return
+	}
+
+	if !middleware.SelfOrAdmin(c, current.UserID) {
+		_ = c.Error(middleware.Forbidden.Messager("Acesso negado ao empréstimo de outro usuario."))
+		return
+	}
+
+	loan, err := h.svc.Renew(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao renovar empréstimo", zap.Error(err))
+		_ = c.Error(loanError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(loan))
+}
+
+// @Summary Obter empréstimo
+// @Description Retorna um empréstimo específico. Só o usuario do empréstimo ou um admin.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do empréstimo"
+// @Success 200 {object} LoanResponse
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Empréstimo não encontrado"
+// @Security ApiKeyAuth
+// @Router /api/loans/{id} [get]
+func (h *LoanHandler) ReadLoan(c *gin.Context) {
+	h.logApp.Info("Rota de obter empréstimo")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*3)
+	defer cancel()
+
+	loan, err := h.svc.GetById(ctx, id)
+	if err != nil {
+		h.logApp.Error("falha ao obter empréstimo", zap.Error(err))
+		_ = c.Error(loanError(err))
+		return
+	}
+
+	if !middleware.SelfOrAdmin(c, loan.UserID) {
+		_ = c.Error(middleware.Forbidden.Messager("Acesso negado ao empréstimo de outro usuario."))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(loan))
+}
+
+// @Summary Listar empréstimos do usuario
+// @Description Retorna os empréstimos ativos e passados de um usuario. Só o próprio usuario ou um admin.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do usuario"
+// @Param status query string false "Filtrar por situação (active, returned)"
+// @Success 200 {array} LoanResponse
+// @Failure 400 {object} middleware.APIError "Parâmetros inválidos"
+// @Failure 403 {object} middleware.APIError "Empréstimos de outro usuario"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Security ApiKeyAuth
+// @Router /api/users/{id}/loans [get]
+func (h *LoanHandler) ReadUserLoans(c *gin.Context) {
+	h.logApp.Info("Rota de empréstimos do usuário")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	status := Status(c.Query("status"))
+	if status != "" && status != Active && status != Returned {
+		h.logApp.Error("status invalido", zap.String("status", string(status)))
+		_ = c.Error(middleware.BadRequest.Messager("Status invalido"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*3)
+	defer cancel()
+
+	loans, err := h.svc.GetByUser(ctx, id, &Filters{Status: status})
+	if err != nil {
+		h.logApp.Error("falha ao obter empréstimos", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	response := make([]LoanResponse, 0, len(loans))
+	for _, l := range loans {
+		response = append(response, ToResponse(&l))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
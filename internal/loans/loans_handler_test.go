@@ -0,0 +1,79 @@
+package loans
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestLoanHandler_RenewLoan(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		claims         *middleware.CustomClaims
+		expectedStatus int
+	}{
+		{
+			name:           "dono do empréstimo",
+			claims:         &middleware.CustomClaims{UserID: 1, Role: "user"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "admin",
+			claims:         &middleware.CustomClaims{UserID: 9, Role: "admin"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "outro usuario",
+			claims:         &middleware.CustomClaims{UserID: 2, Role: "user"},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			mockRepo := new(MockLoanRepo)
+			mockRepo.On("GetById", mock.Anything, int64(1)).
+				Return(&Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(time.Hour)}, nil)
+
+			mockHolds := new(MockHoldChecker)
+			mockHolds.On("HasPendingHolds", mock.Anything, int64(1), int64(1)).Return(false, nil).Maybe()
+
+			mockFines := new(MockFineLedger)
+			mockFines.On("IsBlocked", mock.Anything, int64(1)).Return(false, nil).Maybe()
+
+			if tt.expectedStatus == http.StatusOK {
+				mockRepo.On("UserRole", mock.Anything, int64(1)).Return("user", nil)
+				mockRepo.On("Renew", mock.Anything, int64(1), mock.Anything, 0).Return(nil)
+			}
+
+			handler := NewLoanHandler(NewLoanService(mockRepo, mockFines, mockHolds, DefaultPolicy()), zap.NewNop())
+
+			router := gin.New()
+			router.Use(middleware.ErrorHandler())
+			router.Use(func(c *gin.Context) {
+				c.Set(middleware.GinContextKeyClaims, tt.claims)
+				c.Next()
+			})
+			router.POST("/api/loans/:id/renew", handler.RenewLoan)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/loans/1/renew", nil)
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status esperado %d, mas veio %d. Body=%s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
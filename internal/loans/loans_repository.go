@@ -0,0 +1,272 @@
+package loans
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/holds"
+)
+
+type LoanRepository struct {
+	db *sql.DB
+}
+
+func NewLoanRepository(db *sql.DB) *LoanRepository {
+	return &LoanRepository{db: db}
+}
+
+func (r *LoanRepository) Create(ctx context.Context, l *Loans) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	heldCopyID, held, err := holds.ClaimReadyHold(ctx, tx, l.UserID, l.BookID, l.LoanedAt)
+	if err != nil {
+		return err
+	}
+
+	fromStatus := copies.Available
+	if held {
+		l.CopyID = heldCopyID
+		fromStatus = copies.OnHold
+	}
+
+	if l.CopyID == 0 {
+		query := "SELECT id FROM copies WHERE book_id = ? AND status = ? ORDER BY id ASC LIMIT 1"
+
+		err := tx.QueryRowContext(ctx, query, l.BookID, copies.Available).Scan(&l.CopyID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBookUnavailable
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := setCopyStatus(ctx, tx, l.BookID, l.CopyID, fromStatus, copies.OnLoan); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO loans (book_id, copy_id, user_id, loaned_at, due_date) VALUES (?, ?, ?, ?, ?)"
+
+	result, err := tx.ExecContext(ctx, query, l.BookID, l.CopyID, l.UserID, l.LoanedAt, l.DueDate)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	l.ID = id
+	return nil
+}
+
+// Return encerra o empréstimo, libera o exemplar e, se houver multa por
+// atraso, grava o lançamento na mesma transação.
+func (r *LoanRepository) Return(ctx context.Context, id int64, returnedAt time.Time, fine *fines.Entries) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		bookID int64
+		copyID sql.NullInt64
+	)
+
+	err = tx.QueryRowContext(ctx, "SELECT book_id, copy_id FROM loans WHERE id = ?", id).Scan(&bookID, &copyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLoanNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE loans SET returned_at = ? WHERE id = ? AND returned_at IS NULL"
+
+	result, err := tx.ExecContext(ctx, query, returnedAt, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrAlreadyReturned
+	}
+
+	if copyID.Valid {
+		// O exemplar devolvido vai para o primeiro da fila de reservas do
+		// livro ou volta a ficar disponível.
+		if err := holds.ReleaseCopy(ctx, tx, bookID, copyID.Int64, returnedAt); err != nil {
+			return err
+		}
+	}
+
+	if fine != nil {
+		if err := fines.InsertEntry(ctx, tx, fine); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *LoanRepository) Renew(ctx context.Context, id int64, dueDate time.Time, renewals int) error {
+	query := "UPDATE loans SET due_date = ?, renewals = renewals + 1 WHERE id = ? AND returned_at IS NULL AND renewals = ?"
+
+	result, err := r.db.ExecContext(ctx, query, dueDate, id, renewals)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRenewalConflict
+	}
+	return nil
+}
+
+func (r *LoanRepository) GetById(ctx context.Context, id int64) (*Loans, error) {
+	query := `SELECT id, book_id, copy_id, user_id, loaned_at, due_date, returned_at, renewals
+		FROM loans WHERE id = ?`
+
+	l, err := scanLoan(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLoanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (r *LoanRepository) GetByUser(ctx context.Context, userID int64, filter *Filters) ([]Loans, error) {
+	query := `SELECT id, book_id, copy_id, user_id, loaned_at, due_date, returned_at, renewals
+		FROM loans WHERE user_id = ?`
+
+	switch filter.Status {
+	case Active:
+		query += " AND returned_at IS NULL"
+	case Returned:
+		query += " AND returned_at IS NOT NULL"
+	}
+
+	query += " ORDER BY loaned_at DESC, id DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loans []Loans
+
+	for rows.Next() {
+		l, err := scanLoan(rows)
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, *l)
+	}
+
+	return loans, rows.Err()
+}
+
+func (r *LoanRepository) UserRole(ctx context.Context, userID int64) (string, error) {
+	var role string
+
+	err := r.db.QueryRowContext(ctx, "SELECT role FROM users WHERE id = ?", userID).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUserNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *LoanRepository) EmailVerified(ctx context.Context, userID int64) (bool, error) {
+	var verified bool
+
+	err := r.db.QueryRowContext(ctx, "SELECT email_verified FROM users WHERE id = ?", userID).Scan(&verified)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrUserNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return verified, nil
+}
+
+// setCopyStatus troca o status do exemplar somente se ele pertencer ao livro
+// e estiver no status esperado, evitando emprestar o mesmo exemplar duas vezes.
+func setCopyStatus(ctx context.Context, tx *sql.Tx, bookID, copyID int64, from, to copies.Status) error {
+	query := "UPDATE copies SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND book_id = ? AND status = ?"
+
+	result, err := tx.ExecContext(ctx, query, to, copyID, bookID, from)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBookUnavailable
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLoan(s scanner) (*Loans, error) {
+	var (
+		l          Loans
+		copyID     sql.NullInt64
+		returnedAt sql.NullTime
+	)
+
+	if err := s.Scan(&l.ID, &l.BookID, &copyID, &l.UserID, &l.LoanedAt, &l.DueDate, &returnedAt, &l.Renewals); err != nil {
+		return nil, err
+	}
+
+	l.CopyID = copyID.Int64
+
+	if returnedAt.Valid {
+		l.ReturnedAt = &returnedAt.Time
+	}
+
+	return &l, nil
+}
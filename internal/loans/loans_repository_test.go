@@ -0,0 +1,224 @@
+package loans_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/holds"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/loans"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func seedLoanData(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	if err := authors.NewAuthorsRepository(db).Create(ctx, &authors.Authors{Name: "Autor X", Description: "Teste"}); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+
+	if err := books.NewBookRepository(db).Create(ctx, &books.Books{Title: "Go Lang", Description: "D1", Content: "C1", AuthorID: 1}); err != nil {
+		t.Fatalf("book: %v", err)
+	}
+
+	copyRepo := copies.NewCopyRepository(db, holds.NewQueue())
+	for _, barcode := range []string{"0001", "0002"} {
+		if err := copyRepo.Create(ctx, &copies.Copies{BookID: 1, Barcode: barcode, Condition: copies.Good, Status: copies.Available}); err != nil {
+			t.Fatalf("copy: %v", err)
+		}
+	}
+
+	user := &users.Users{Name: "Leitor", Email: "leitor@email.com", Username: "leitor", Password: "hash", Role: users.User}
+	if err := users.NewUsersRepository(db).Create(ctx, user); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+}
+
+func TestLoanRepository_CreateAndReturn(t *testing.T) {
+	db := database.SetupTestDB()
+	seedLoanData(t, db)
+	repo := loans.NewLoanRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	loan := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+
+	if err := repo.Create(ctx, loan); err != nil {
+		t.Fatalf("não esperava erro ao criar: %v", err)
+	}
+
+	if loan.ID == 0 {
+		t.Fatalf("ID não foi gerado pela criação")
+	}
+
+	if loan.CopyID != 1 {
+		t.Errorf("esperava o primeiro exemplar disponível, recebeu %d", loan.CopyID)
+	}
+
+	second := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := repo.Create(ctx, second); err != nil {
+		t.Fatalf("não esperava erro ao criar: %v", err)
+	}
+
+	third := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := repo.Create(ctx, third); !errors.Is(err, loans.ErrBookUnavailable) {
+		t.Fatalf("esperava ErrBookUnavailable, recebeu %v", err)
+	}
+
+	fine := &fines.Entries{UserID: 1, LoanID: loan.ID, Kind: fines.Accrual, AmountCents: 150, CreatedBy: fines.SystemActor, CreatedAt: now}
+	if err := repo.Return(ctx, loan.ID, now.Add(time.Hour), fine); err != nil {
+		t.Fatalf("não esperava erro ao devolver: %v", err)
+	}
+
+	balance, err := fines.NewFineRepository(db).Balance(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao obter saldo: %v", err)
+	}
+
+	if balance != 150 {
+		t.Errorf("esperava a multa gravada na devolução, saldo=%d", balance)
+	}
+
+	if err := repo.Return(ctx, loan.ID, now.Add(time.Hour), nil); err != loans.ErrAlreadyReturned {
+		t.Errorf("esperava ErrAlreadyReturned, recebeu %v", err)
+	}
+
+	found, err := repo.GetById(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar: %v", err)
+	}
+
+	if found.ReturnedAt == nil {
+		t.Fatalf("esperava data de devolução")
+	}
+
+	copyRepo := copies.NewCopyRepository(db, holds.NewQueue())
+
+	returnedCopy, err := copyRepo.GetById(ctx, 1, loan.CopyID)
+	if err != nil {
+		t.Fatalf("erro ao buscar exemplar: %v", err)
+	}
+
+	if returnedCopy.Status != copies.Available {
+		t.Errorf("esperava exemplar disponível, recebeu %s", returnedCopy.Status)
+	}
+
+	if !found.DueDate.Equal(loan.DueDate) {
+		t.Errorf("esperava due_date=%v, recebeu=%v", loan.DueDate, found.DueDate)
+	}
+
+	// o exemplar com histórico pode ser apagado; o empréstimo fica sem ele
+	if err := copyRepo.Delete(ctx, 1, loan.CopyID); err != nil {
+		t.Fatalf("erro ao apagar exemplar: %v", err)
+	}
+
+	found, err = repo.GetById(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar: %v", err)
+	}
+	if found.CopyID != 0 {
+		t.Errorf("esperava empréstimo sem exemplar, recebeu copy_id=%d", found.CopyID)
+	}
+
+	if err := copyRepo.Delete(ctx, 1, second.CopyID); !errors.Is(err, copies.ErrCopyOnLoan) {
+		t.Errorf("esperava ErrCopyOnLoan, recebeu %v", err)
+	}
+}
+
+func TestLoanRepository_GetByUser(t *testing.T) {
+	db := database.SetupTestDB()
+	seedLoanData(t, db)
+	repo := loans.NewLoanRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	returned := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now.Add(-48 * time.Hour), DueDate: now}
+	if err := repo.Create(ctx, returned); err != nil {
+		t.Fatalf("erro ao criar: %v", err)
+	}
+	if err := repo.Return(ctx, returned.ID, now, nil); err != nil {
+		t.Fatalf("erro ao devolver: %v", err)
+	}
+
+	active := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+	if err := repo.Create(ctx, active); err != nil {
+		t.Fatalf("erro ao criar: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		filter    *loans.Filters
+		wantCount int
+		wantID    int64
+	}{
+		{name: "todos", filter: &loans.Filters{}, wantCount: 2, wantID: active.ID},
+		{name: "ativos", filter: &loans.Filters{Status: loans.Active}, wantCount: 1, wantID: active.ID},
+		{name: "devolvidos", filter: &loans.Filters{Status: loans.Returned}, wantCount: 1, wantID: returned.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetByUser(ctx, 1, tt.filter)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			if len(got) != tt.wantCount {
+				t.Fatalf("qtde errada: esperava %d, veio %d", tt.wantCount, len(got))
+			}
+
+			if got[0].ID != tt.wantID {
+				t.Errorf("ID errado. esperado %d, veio %d", tt.wantID, got[0].ID)
+			}
+		})
+	}
+}
+
+func TestLoanRepository_Renew(t *testing.T) {
+	db := database.SetupTestDB()
+	seedLoanData(t, db)
+	repo := loans.NewLoanRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	loan := &loans.Loans{BookID: 1, UserID: 1, LoanedAt: now, DueDate: now.Add(loans.DefaultLoanPeriod)}
+
+	if err := repo.Create(ctx, loan); err != nil {
+		t.Fatalf("não esperava erro ao criar: %v", err)
+	}
+
+	newDue := loan.DueDate.Add(loans.DefaultLoanPeriod)
+	if err := repo.Renew(ctx, loan.ID, newDue, 0); err != nil {
+		t.Fatalf("não esperava erro ao renovar: %v", err)
+	}
+
+	if err := repo.Renew(ctx, loan.ID, newDue, 0); !errors.Is(err, loans.ErrRenewalConflict) {
+		t.Errorf("esperava ErrRenewalConflict com contagem desatualizada, recebeu %v", err)
+	}
+
+	found, err := repo.GetById(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar: %v", err)
+	}
+
+	if found.Renewals != 1 || !found.DueDate.Equal(newDue) {
+		t.Errorf("esperava 1 renovação até %v, recebeu %d até %v", newDue, found.Renewals, found.DueDate)
+	}
+
+	role, err := repo.UserRole(ctx, 1)
+	if err != nil || role != "user" {
+		t.Errorf("esperava perfil user, recebeu %q (%v)", role, err)
+	}
+
+	if _, err := repo.UserRole(ctx, 99); !errors.Is(err, loans.ErrUserNotFound) {
+		t.Errorf("esperava ErrUserNotFound, recebeu %v", err)
+	}
+}
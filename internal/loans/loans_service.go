@@ -0,0 +1,144 @@
+package loans
+
+import (
+	"context"
+	"time"
+)
+
+type LoanService interface {
+	Checkout(ctx context.Context, l *Loans) error
+	Return(ctx context.Context, id int64) (*Loans, error)
+	Renew(ctx context.Context, id int64) (*Loans, error)
+	GetById(ctx context.Context, id int64) (*Loans, error)
+	GetByUser(ctx context.Context, userID int64, filter *Filters) ([]Loans, error)
+}
+
+type serviceLoan struct {
+	repo   ILoanRepository
+	fines  FineLedger
+	policy Policy
+	rules  []RenewalRule
+	now    func() time.Time
+}
+
+func NewLoanService(repo ILoanRepository, fines FineLedger, holds HoldChecker, policy Policy) *serviceLoan {
+	return &serviceLoan{
+		repo:   repo,
+		fines:  fines,
+		policy: policy,
+		rules:  policy.RenewalRules(holds, fines),
+		now:    func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+func (s *serviceLoan) Checkout(ctx context.Context, l *Loans) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+
+	blocked, err := s.fines.IsBlocked(ctx, l.UserID)
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return ErrFinesBlocked
+	}
+
+	if s.policy.RequireVerifiedEmail {
+		verified, err := s.repo.EmailVerified(ctx, l.UserID)
+		if err != nil {
+			return err
+		}
+		if !verified {
+			return ErrEmailNotVerified
+		}
+	}
+
+	role, err := s.repo.UserRole(ctx, l.UserID)
+	if err != nil {
+		return err
+	}
+
+	l.LoanedAt = s.now()
+	l.DueDate = l.LoanedAt.Add(s.policy.PeriodFor(role))
+	l.ReturnedAt = nil
+	l.Renewals = 0
+
+	return s.repo.Create(ctx, l)
+}
+
+func (s *serviceLoan) Return(ctx context.Context, id int64) (*Loans, error) {
+	loan, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.ReturnedAt != nil {
+		return nil, ErrAlreadyReturned
+	}
+
+	returnedAt := s.now()
+
+	// a multa é gravada junto com a devolução: ou as duas acontecem ou
+	// nenhuma, e uma nova tentativa continua possível
+	fine, err := s.fines.LateReturnEntry(loan.UserID, loan.ID, loan.DueDate, returnedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Return(ctx, id, returnedAt, fine); err != nil {
+		return nil, err
+	}
+
+	loan.ReturnedAt = &returnedAt
+	return loan, nil
+}
+
+// Renew estende o prazo a partir da data da renovação pelo período do perfil
+// do usuario, desde que todas as regras da política permitam. O prazo nunca
+// é encurtado.
+func (s *serviceLoan) Renew(ctx context.Context, id int64) (*Loans, error) {
+	loan, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.ReturnedAt != nil {
+		return nil, ErrAlreadyReturned
+	}
+
+	now := s.now()
+
+	for _, rule := range s.rules {
+		if err := rule(ctx, loan, now); err != nil {
+			return nil, err
+		}
+	}
+
+	role, err := s.repo.UserRole(ctx, loan.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate := now.Add(s.policy.PeriodFor(role))
+	if dueDate.Before(loan.DueDate) {
+		dueDate = loan.DueDate
+	}
+
+	if err := s.repo.Renew(ctx, id, dueDate, loan.Renewals); err != nil {
+		return nil, err
+	}
+
+	loan.DueDate = dueDate
+	loan.Renewals++
+	return loan, nil
+}
+
+func (s *serviceLoan) GetById(ctx context.Context, id int64) (*Loans, error) {
+	return s.repo.GetById(ctx, id)
+}
+
+func (s *serviceLoan) GetByUser(ctx context.Context, userID int64, filter *Filters) ([]Loans, error) {
+	return s.repo.GetByUser(ctx, userID, filter)
+}
@@ -0,0 +1,212 @@
+package loans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_serviceLoan_Checkout(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      *Loans
+		blocked    bool
+		verify     bool
+		unverified bool
+		repoErr    error
+		wantErr    error
+	}{
+		{
+			name:  "sucesso",
+			input: &Loans{BookID: 1, UserID: 1},
+		},
+		{
+			name:    "sem exemplar disponível",
+			input:   &Loans{BookID: 1, UserID: 1},
+			repoErr: ErrBookUnavailable,
+			wantErr: ErrBookUnavailable,
+		},
+		{
+			name:    "bloqueado por multas",
+			input:   &Loans{BookID: 1, UserID: 1},
+			blocked: true,
+			wantErr: ErrFinesBlocked,
+		},
+		{
+			name:   "email confirmado exigido",
+			input:  &Loans{BookID: 1, UserID: 1},
+			verify: true,
+		},
+		{
+			name:       "email não confirmado",
+			input:      &Loans{BookID: 1, UserID: 1},
+			verify:     true,
+			unverified: true,
+			wantErr:    ErrEmailNotVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFines := new(MockFineLedger)
+			mockFines.On("IsBlocked", mock.Anything, int64(1)).Return(tt.blocked, nil)
+
+			mockRepo := new(MockLoanRepo)
+			if tt.verify {
+				mockRepo.On("EmailVerified", mock.Anything, int64(1)).Return(!tt.unverified, nil)
+			}
+			if !tt.blocked && !tt.unverified {
+				mockRepo.On("UserRole", mock.Anything, int64(1)).Return("user", nil)
+				mockRepo.On("Create", mock.Anything, tt.input).Return(tt.repoErr)
+			}
+
+			policy := DefaultPolicy()
+			policy.RequireVerifiedEmail = tt.verify
+
+			svc := NewLoanService(mockRepo, mockFines, new(MockHoldChecker), policy)
+
+			err := svc.Checkout(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, DefaultLoanPeriod, tt.input.DueDate.Sub(tt.input.LoanedAt))
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockFines.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_serviceLoan_Return(t *testing.T) {
+	returnedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		loan    *Loans
+		wantErr error
+	}{
+		{
+			name: "sucesso",
+			loan: &Loans{ID: 1, BookID: 1, UserID: 1},
+		},
+		{
+			name:    "já devolvido",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, ReturnedAt: &returnedAt},
+			wantErr: ErrAlreadyReturned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockLoanRepo)
+			mockRepo.On("GetById", mock.Anything, int64(1)).Return(tt.loan, nil)
+
+			mockFines := new(MockFineLedger)
+
+			if tt.wantErr == nil {
+				mockFines.On("LateReturnEntry", int64(1), int64(1), tt.loan.DueDate, mock.Anything).Return(nil, nil)
+				mockRepo.On("Return", mock.Anything, int64(1), mock.Anything, (*fines.Entries)(nil)).Return(nil)
+			}
+
+			svc := NewLoanService(mockRepo, mockFines, new(MockHoldChecker), DefaultPolicy())
+
+			got, err := svc.Return(context.Background(), 1)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, Returned, got.Status())
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockFines.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_serviceLoan_Renew(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		loan    *Loans
+		role    string
+		pending bool
+		blocked bool
+		wantDue time.Duration
+		wantErr error
+	}{
+		{
+			name:    "sucesso usuario",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(time.Hour)},
+			role:    "user",
+			wantDue: DefaultLoanPeriod,
+		},
+		{
+			name:    "sucesso admin com prazo maior",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(time.Hour)},
+			role:    "admin",
+			wantDue: 2 * DefaultLoanPeriod,
+		},
+		{
+			name:    "limite de renovações",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(time.Hour), Renewals: 2},
+			wantErr: ErrMaxRenewals,
+		},
+		{
+			name:    "em atraso",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(-time.Hour)},
+			wantErr: ErrLoanOverdue,
+		},
+		{
+			name:    "livro reservado",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(time.Hour)},
+			pending: true,
+			wantErr: ErrBookOnHold,
+		},
+		{
+			name:    "usuario bloqueado",
+			loan:    &Loans{ID: 1, BookID: 1, UserID: 1, DueDate: now.Add(time.Hour)},
+			blocked: true,
+			wantErr: ErrFinesBlocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockLoanRepo)
+			mockRepo.On("GetById", mock.Anything, int64(1)).Return(tt.loan, nil)
+
+			mockHolds := new(MockHoldChecker)
+			mockHolds.On("HasPendingHolds", mock.Anything, int64(1), int64(1)).Return(tt.pending, nil).Maybe()
+
+			mockFines := new(MockFineLedger)
+			mockFines.On("IsBlocked", mock.Anything, int64(1)).Return(tt.blocked, nil).Maybe()
+
+			if tt.wantErr == nil {
+				mockRepo.On("UserRole", mock.Anything, int64(1)).Return(tt.role, nil)
+				mockRepo.On("Renew", mock.Anything, int64(1), now.Add(tt.wantDue), 0).Return(nil)
+			}
+
+			svc := NewLoanService(mockRepo, mockFines, mockHolds, DefaultPolicy())
+			svc.now = func() time.Time { return now }
+
+			got, err := svc.Renew(context.Background(), 1)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, got.Renewals)
+				assert.Equal(t, now.Add(tt.wantDue), got.DueDate)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package loans
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoanRepo struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepo) Create(ctx context.Context, l *Loans) error {
+	args := m.Called(ctx, l)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepo) Return(ctx context.Context, id int64, returnedAt time.Time, fine *fines.Entries) error {
+	args := m.Called(ctx, id, returnedAt, fine)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepo) Renew(ctx context.Context, id int64, dueDate time.Time, renewals int) error {
+	args := m.Called(ctx, id, dueDate, renewals)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepo) UserRole(ctx context.Context, userID int64) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLoanRepo) EmailVerified(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLoanRepo) GetById(ctx context.Context, id int64) (*Loans, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	loan, ok := args.Get(0).(*Loans)
+	if !ok {
+		return nil, args.Error(1)
+	}
+
+	return loan, args.Error(1)
+}
+
+func (m *MockLoanRepo) GetByUser(ctx context.Context, userID int64, filter *Filters) ([]Loans, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).([]Loans), args.Error(1)
+}
+
+type MockFineLedger struct {
+	mock.Mock
+}
+
+func (m *MockFineLedger) LateReturnEntry(userID, loanID int64, dueDate, returnedAt time.Time) (*fines.Entries, error) {
+	args := m.Called(userID, loanID, dueDate, returnedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fines.Entries), args.Error(1)
+}
+
+func (m *MockFineLedger) IsBlocked(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockHoldChecker struct {
+	mock.Mock
+}
+
+func (m *MockHoldChecker) HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error) {
+	args := m.Called(ctx, bookID, exceptUserID)
+	return args.Bool(0), args.Error(1)
+}
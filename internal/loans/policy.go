@@ -0,0 +1,128 @@
+package loans
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Policy reúne as regras de circulação configuráveis: prazo de empréstimo por
+// perfil do usuario, limite de renovações por empréstimo e se só usuarios com
+// email confirmado podem pegar livros.
+type Policy struct {
+	MaxRenewals          int
+	DefaultPeriod        time.Duration
+	Periods              map[string]time.Duration
+	RequireVerifiedEmail bool
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxRenewals:   2,
+		DefaultPeriod: DefaultLoanPeriod,
+		Periods: map[string]time.Duration{
+			"user":  DefaultLoanPeriod,
+			"admin": 2 * DefaultLoanPeriod,
+		},
+	}
+}
+
+// PolicyFromEnv lê LOAN_MAX_RENEWALS, LOAN_PERIOD_DAYS_USER e
+// LOAN_PERIOD_DAYS_ADMIN, usando o padrão para valores ausentes ou inválidos.
+// EMAIL_VERIFICATION_REQUIRED com "loans" ou "login" exige email confirmado
+// para emprestar.
+func PolicyFromEnv() Policy {
+	p := DefaultPolicy()
+
+	switch os.Getenv("EMAIL_VERIFICATION_REQUIRED") {
+	case "loans", "login":
+		p.RequireVerifiedEmail = true
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("LOAN_MAX_RENEWALS")); err == nil && value >= 0 {
+		p.MaxRenewals = value
+	}
+
+	for role, key := range map[string]string{"user": "LOAN_PERIOD_DAYS_USER", "admin": "LOAN_PERIOD_DAYS_ADMIN"} {
+		if days, err := strconv.Atoi(os.Getenv(key)); err == nil && days > 0 {
+			p.Periods[role] = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	return p
+}
+
+// PeriodFor devolve o prazo de empréstimo do perfil, ou o prazo padrão para
+// perfis sem configuração própria.
+func (p Policy) PeriodFor(role string) time.Duration {
+	if period, ok := p.Periods[role]; ok {
+		return period
+	}
+	return p.DefaultPeriod
+}
+
+// RenewalRule avalia se o empréstimo pode ser renovado e devolve o motivo da
+// recusa, ou nil para permitir.
+type RenewalRule func(ctx context.Context, l *Loans, now time.Time) error
+
+// HoldChecker informa se outros usuarios aguardam o título na fila de reservas.
+type HoldChecker interface {
+	HasPendingHolds(ctx context.Context, bookID, exceptUserID int64) (bool, error)
+}
+
+func MaxRenewalsRule(max int) RenewalRule {
+	return func(_ context.Context, l *Loans, _ time.Time) error {
+		if l.Renewals >= max {
+			return ErrMaxRenewals
+		}
+		return nil
+	}
+}
+
+func NotOverdueRule() RenewalRule {
+	return func(_ context.Context, l *Loans, now time.Time) error {
+		if l.IsOverdue(now) {
+			return ErrLoanOverdue
+		}
+		return nil
+	}
+}
+
+func NoPendingHoldsRule(checker HoldChecker) RenewalRule {
+	return func(ctx context.Context, l *Loans, _ time.Time) error {
+		pending, err := checker.HasPendingHolds(ctx, l.BookID, l.UserID)
+		if err != nil {
+			return err
+		}
+
+		if pending {
+			return ErrBookOnHold
+		}
+		return nil
+	}
+}
+
+func NotBlockedRule(fines FineLedger) RenewalRule {
+	return func(ctx context.Context, l *Loans, _ time.Time) error {
+		blocked, err := fines.IsBlocked(ctx, l.UserID)
+		if err != nil {
+			return err
+		}
+
+		if blocked {
+			return ErrFinesBlocked
+		}
+		return nil
+	}
+}
+
+// RenewalRules monta as regras padrão de renovação da política.
+func (p Policy) RenewalRules(holds HoldChecker, fines FineLedger) []RenewalRule {
+	return []RenewalRule{
+		MaxRenewalsRule(p.MaxRenewals),
+		NotOverdueRule(),
+		NoPendingHoldsRule(holds),
+		NotBlockedRule(fines),
+	}
+}
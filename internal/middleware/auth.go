@@ -1,17 +1,27 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 const GinContextKeyEmail = "email"
 const GinContextKeyRole = "role"
+const GinContextKeyClaims = "claims"
 
-func AuthMiddleware() gin.HandlerFunc {
+// RevocationChecker informa se um token de acesso foi revogado antes de
+// expirar, seja ele próprio (logout) ou todos os do usuário emitidos antes de
+// um momento (logout de todas as sessões, troca de senha, exclusão da conta).
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, tokenID, sessionID string, userID int64, issuedAt time.Time) (bool, error)
+}
+
+func AuthMiddleware(revocations RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokeString := c.GetHeader("Authorization")
 
@@ -24,12 +34,69 @@ func AuthMiddleware() gin.HandlerFunc {
 
 		calims, err := VerifyToken(tokeString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, "Erro ao verificar token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Erro ao verificar token"})
+			return
+		}
+
+		revoked, err := revocations.IsRevoked(c.Request.Context(), calims.ID, calims.SessionID, calims.UserID, calims.IssuedAt.Time)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar token"})
+			return
+		}
+
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token revogado."})
 			return
 		}
 
 		c.Set(GinContextKeyEmail, calims.Email)
 		c.Set(GinContextKeyRole, calims.Role)
+		c.Set(GinContextKeyClaims, calims)
+
+		c.Next()
+	}
+}
+
+// ClaimsFrom devolve as claims do token validado por AuthMiddleware.
+func ClaimsFrom(c *gin.Context) (*CustomClaims, bool) {
+	value, ok := c.Get(GinContextKeyClaims)
+	if !ok {
+		return nil, false
+	}
+
+	claims, ok := value.(*CustomClaims)
+	return claims, ok
+}
+
+// IsAdmin informa se o usuario autenticado tem o perfil admin.
+func IsAdmin(c *gin.Context) bool {
+	claims, ok := ClaimsFrom(c)
+	return ok && claims.Role == "admin"
+}
+
+// SelfOrAdmin informa se o usuario autenticado pode ver ou alterar os dados
+// de userID: só o próprio dono ou um admin.
+func SelfOrAdmin(c *gin.Context, userID int64) bool {
+	claims, ok := ClaimsFrom(c)
+	return ok && (claims.Role == "admin" || claims.UserID == userID)
+}
+
+// RequireSelfOrAdmin protege as rotas /api/users/:id/...: só passa o dono do
+// id ou um admin.
+func RequireSelfOrAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := GetIdParam(c)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if !SelfOrAdmin(c, id) {
+			_ = c.Error(Forbidden.Messager("Acesso negado aos dados de outro usuario."))
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
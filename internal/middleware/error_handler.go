@@ -74,7 +74,12 @@ func ErrorHandler() gin.HandlerFunc {
 }
 
 var (
-	NotFound    = NewApiError(http.StatusNotFound, "NOT_FOUND", "Resource not found.", nil)
-	BadRequest  = NewApiError(http.StatusBadRequest, "BAD_REQUEST", "Invalid request", nil)
-	InternalErr = NewApiError(http.StatusInternalServerError, "INTERNAL_ERROR", "Internal error occurred.", nil)
+	NotFound     = NewApiError(http.StatusNotFound, "NOT_FOUND", "Resource not found.", nil)
+	BadRequest   = NewApiError(http.StatusBadRequest, "BAD_REQUEST", "Invalid request", nil)
+	InternalErr  = NewApiError(http.StatusInternalServerError, "INTERNAL_ERROR", "Internal error occurred.", nil)
+	Conflict     = NewApiError(http.StatusConflict, "CONFLICT", "Resource state conflict.", nil)
+	Unauthorized = NewApiError(http.StatusUnauthorized, "UNAUTHORIZED", "Authentication failed.", nil)
+	Forbidden    = NewApiError(http.StatusForbidden, "FORBIDDEN", "Access denied.", nil)
+	TooLarge     = NewApiError(http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "Payload too large.", nil)
+	Unsupported  = NewApiError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Unsupported media type.", nil)
 )
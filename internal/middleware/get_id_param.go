@@ -7,7 +7,13 @@ import (
 )
 
 func GetIdParam(c *gin.Context) (int64, error) {
-	idParam := c.Param("id")
+	return GetIdParamByName(c, "id")
+}
+
+// GetIdParamByName lê um ID numérico de um parâmetro de rota com outro nome,
+// como ":copyId" em rotas aninhadas.
+func GetIdParamByName(c *gin.Context, name string) (int64, error) {
+	idParam := c.Param(name)
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
 		return 0, BadRequest.Messager("Id invalido")
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"log"
 	"os"
@@ -12,9 +14,20 @@ import (
 
 var secretKey []byte
 
+var ErrTokenNotRevocable = errors.New("token sem identificador; faça login novamente")
+
+// AccessTokenTTL é a validade do token de acesso. Depois dela o cliente pede
+// outro com o refresh token, sem repetir o login.
+const AccessTokenTTL = 2 * time.Hour
+
+// CustomClaims são as claims do token de acesso. Além das padrão, o token
+// leva o ID (jti), a data de emissão (iat), o usuário (uid) e a sessão (sid)
+// que o emitiu, para que possa ser revogado antes de expirar.
 type CustomClaims struct {
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	UserID    int64  `json:"uid"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -45,11 +58,24 @@ func getSecretKey() []byte {
 	return secretKey
 }
 
-func GenerateToken(email, role string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"email": email,
-		"role":  role,
-		"exp":   time.Now().Add(time.Hour * 2).Unix(),
+// GenerateToken emite o token de acesso do usuário na sessão informada.
+func GenerateToken(userID int64, email, role, sessionID string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, CustomClaims{
+		Email:     email,
+		Role:      role,
+		UserID:    userID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(id),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+		},
 	})
 
 	tokenString, err := token.SignedString(getSecretKey())
@@ -67,12 +93,17 @@ func VerifyToken(tokenString string) (*CustomClaims, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return "", errors.New("metodo invalido")
 		}
-		return secretKey, nil
+		return getSecretKey(), nil
 	})
 
 	if err != nil || !token.Valid {
 		return nil, err
 	}
 
+	// tokens sem jti são anteriores à revogação e não poderiam ser revogados
+	if claims.ID == "" || claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return nil, ErrTokenNotRevocable
+	}
+
 	return claims, nil
 }
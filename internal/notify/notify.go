@@ -0,0 +1,92 @@
+// Package notify entrega mensagens aos usuários, como o token de redefinição
+// de senha ou de confirmação de email. Quem envia depende só de Notifier; o
+// meio de entrega (SMTP, arquivo ou log) é escolhido na montagem da aplicação.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+// LogNotifier escreve as mensagens no log da aplicação. Serve para
+// desenvolvimento: o conteúdo, tokens inclusive, fica visível no log.
+type LogNotifier struct {
+	log *zap.Logger
+}
+
+func NewLogNotifier(log *zap.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, m Message) error {
+	n.log.Info("mensagem para o usuário",
+		zap.String("to", m.To), zap.String("subject", m.Subject), zap.String("body", m.Body))
+	return nil
+}
+
+// FileNotifier acrescenta as mensagens a um arquivo, uma depois da outra,
+// como uma caixa de saída local.
+type FileNotifier struct {
+	path string
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path, now: time.Now}
+}
+
+func (n *FileNotifier) Notify(ctx context.Context, m Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(n.path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "Data: %s\nPara: %s\nAssunto: %s\n\n%s\n\n---\n",
+		n.now().UTC().Format(time.RFC3339), m.To, m.Subject, m.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// FromEnv envia por SMTP quando SMTP_HOST está definido (com SMTP_PORT,
+// SMTP_USERNAME, SMTP_PASSWORD e SMTP_FROM). Sem ele, grava em
+// NOTIFY_OUTBOX_FILE quando definido e, por último, no log.
+func FromEnv(log *zap.Logger) Notifier {
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		return NewSMTPNotifier(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("SMTP_FROM"))
+	}
+
+	if path := os.Getenv("NOTIFY_OUTBOX_FILE"); path != "" {
+		return NewFileNotifier(path)
+	}
+	return NewLogNotifier(log)
+}
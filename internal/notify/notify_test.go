@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileNotifier_Notify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saida", "mensagens.txt")
+
+	n := NewFileNotifier(path)
+	n.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	for _, m := range []Message{
+		{To: "um@email.com", Subject: "Primeira", Body: "corpo um"},
+		{To: "dois@email.com", Subject: "Segunda", Body: "corpo dois"},
+	} {
+		if err := n.Notify(context.Background(), m); err != nil {
+			t.Fatalf("erro ao gravar mensagem: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("erro ao ler caixa de saída: %v", err)
+	}
+
+	want := "Data: 2026-03-01T12:00:00Z\nPara: um@email.com\nAssunto: Primeira\n\ncorpo um\n\n---\n" +
+		"Data: 2026-03-01T12:00:00Z\nPara: dois@email.com\nAssunto: Segunda\n\ncorpo dois\n\n---\n"
+	if got := string(data); got != want {
+		t.Errorf("conteúdo inesperado:\n%s", got)
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	n := NewSMTPNotifier("smtp.exemplo.com", "587", "", "", "biblioteca@exemplo.com")
+	n.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	var gotAddr string
+	var gotTo []string
+	var gotMsg []byte
+	n.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, msg
+		return nil
+	}
+
+	err := n.Notify(context.Background(), Message{To: "um@email.com", Subject: "Confirmação de email", Body: "linha um\nlinha dois"})
+	if err != nil {
+		t.Fatalf("erro ao enviar: %v", err)
+	}
+
+	if gotAddr != "smtp.exemplo.com:587" || len(gotTo) != 1 || gotTo[0] != "um@email.com" {
+		t.Errorf("destino inesperado: %s %v", gotAddr, gotTo)
+	}
+
+	want := "From: biblioteca@exemplo.com\r\n" +
+		"To: um@email.com\r\n" +
+		"Subject: =?utf-8?q?Confirma=C3=A7=C3=A3o_de_email?=\r\n" +
+		"Date: Sun, 01 Mar 2026 12:00:00 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: 8bit\r\n" +
+		"\r\n" +
+		"linha um\r\nlinha dois\r\n"
+	if string(gotMsg) != want {
+		t.Errorf("mensagem inesperada:\n%q", gotMsg)
+	}
+}
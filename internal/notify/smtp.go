@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier envia as mensagens como email em texto puro. O servidor deve
+// aceitar STARTTLS quando houver usuario e senha; net/smtp recusa enviar a
+// senha por uma conexão sem criptografia.
+type SMTPNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+	now  func() time.Time
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPNotifier(host, port, username, password, from string) *SMTPNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPNotifier{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+		now:  time.Now,
+		send: smtp.SendMail,
+	}
+}
+
+// Notify não usa ctx: net/smtp não aceita cancelamento.
+func (n *SMTPNotifier) Notify(ctx context.Context, m Message) error {
+	return n.send(n.addr, n.auth, n.from, []string{m.To}, n.build(m))
+}
+
+func (n *SMTPNotifier) build(m Message) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String())
+}
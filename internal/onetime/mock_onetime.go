@@ -0,0 +1,20 @@
+package onetime
+
+import (
+	"context"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserAccounts struct {
+	mock.Mock
+}
+
+func (m *MockUserAccounts) GetUserDetails(ctx context.Context, email string) (*users.Users, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*users.Users), args.Error(1)
+}
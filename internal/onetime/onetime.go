@@ -0,0 +1,47 @@
+package onetime
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+// ErrDelivery indica que o token foi gravado mas o email não saiu.
+var ErrDelivery = errors.New("falha ao enviar o email com o token")
+
+// Token é um token de uso único enviado por email, como os de redefinição de
+// senha e de confirmação de email. Como nos refresh tokens, só o hash é
+// gravado.
+type Token struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type TokenCreator interface {
+	Create(ctx context.Context, t *Token) error
+}
+
+type TokenRead interface {
+	GetByHash(ctx context.Context, tokenHash string) (*Token, error)
+}
+
+type ITokenStore interface {
+	TokenCreator
+	TokenRead
+}
+
+// UserAccounts é a parte do cadastro de usuários usada para achar o dono do
+// email que pede um token.
+type UserAccounts interface {
+	GetUserDetails(ctx context.Context, email string) (*users.Users, error)
+}
+
+func (t *Token) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
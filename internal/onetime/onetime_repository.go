@@ -0,0 +1,101 @@
+package onetime
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Store grava os tokens de uma tabela com as colunas de Token. invalid é o
+// erro devolvido para tokens desconhecidos ou já gastos, com a mensagem do
+// fluxo que usa a tabela.
+type Store struct {
+	db      *sql.DB
+	table   string
+	invalid error
+}
+
+func NewStore(db *sql.DB, table string, invalid error) *Store {
+	return &Store{db: db, table: table, invalid: invalid}
+}
+
+func (s *Store) Create(ctx context.Context, t *Token) error {
+	query := "INSERT INTO " + s.table + " (user_id, token_hash, expires_at) VALUES (?, ?, ?)"
+
+	result, err := s.db.ExecContext(ctx, query, t.UserID, t.TokenHash, t.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	t.ID = id
+	return nil
+}
+
+func (s *Store) GetByHash(ctx context.Context, tokenHash string) (*Token, error) {
+	query := "SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM " + s.table + " WHERE token_hash = ?"
+
+	var t Token
+	var usedAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.TokenHash, &t.ExpiresAt, &usedAt, &t.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, s.invalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	return &t, nil
+}
+
+// Spend marca o token como usado, junto com os outros ainda pendentes do
+// mesmo usuário, e roda apply na mesma transação para gravar o efeito do
+// token. Devolve o erro invalid se outro pedido gastou o token antes ou se
+// ele expirou; se apply falhar, o token continua valendo.
+func (s *Store) Spend(ctx context.Context, t *Token, now time.Time, apply func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// a condição em used_at garante que dois pedidos com o mesmo token não
+	// apliquem o efeito duas vezes
+	result, err := tx.ExecContext(ctx,
+		"UPDATE "+s.table+" SET used_at = ? WHERE id = ? AND used_at IS NULL AND expires_at > ?", now, t.ID, now)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return s.invalid
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE "+s.table+" SET used_at = ? WHERE user_id = ? AND used_at IS NULL", now, t.UserID); err != nil {
+		return err
+	}
+
+	if err := apply(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,66 @@
+package onetime_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func TestStore_Spend(t *testing.T) {
+	db := database.SetupTestDB()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	errInvalid := errors.New("token invalido")
+	store := onetime.NewStore(db, "email_verifications", errInvalid)
+
+	if err := users.NewUsersRepository(db).Create(ctx, &users.Users{Name: "Leitor", Email: "um@email.com", Username: "um", Password: "hash", Role: users.User}); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+
+	token := &onetime.Token{UserID: 1, TokenHash: sessions.HashToken("token"), ExpiresAt: now.Add(time.Hour)}
+	if err := store.Create(ctx, token); err != nil {
+		t.Fatalf("erro ao criar token: %v", err)
+	}
+
+	if _, err := store.GetByHash(ctx, sessions.HashToken("outro")); !errors.Is(err, errInvalid) {
+		t.Errorf("esperava o erro da tabela, recebeu %v", err)
+	}
+
+	// se o efeito falha, o token não é gasto
+	errApply := errors.New("falha ao aplicar")
+	if err := store.Spend(ctx, token, now, func(*sql.Tx) error { return errApply }); !errors.Is(err, errApply) {
+		t.Fatalf("esperava a falha do efeito, recebeu %v", err)
+	}
+
+	found, err := store.GetByHash(ctx, token.TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if !found.Usable(now) {
+		t.Fatalf("esperava o token ainda valendo: %+v", found)
+	}
+
+	applied := 0
+	apply := func(*sql.Tx) error {
+		applied++
+		return nil
+	}
+
+	if err := store.Spend(ctx, token, now, apply); err != nil {
+		t.Fatalf("erro ao gastar token: %v", err)
+	}
+	if err := store.Spend(ctx, token, now, apply); !errors.Is(err, errInvalid) {
+		t.Errorf("token gasto não deveria valer de novo: %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("esperava o efeito aplicado uma vez, recebeu %d", applied)
+	}
+}
@@ -0,0 +1,153 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("cursor invalido")
+
+// Cursor marca a posição de uma linha na ordenação: o valor da coluna de
+// ordenação e a chave de desempate. Backward pede as linhas anteriores a ela.
+// Vai ao cliente codificado, sem que ele precise conhecer o formato.
+type Cursor struct {
+	Sort     string `json:"s"`
+	Order    Order  `json:"o"`
+	Value    any    `json:"v"`
+	Key      int64  `json:"k"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Row é a posição de uma linha lida, usada para montar os cursores vizinhos.
+type Row struct {
+	Value any
+	Key   int64
+}
+
+func (c Cursor) Encode() string {
+	c.Value = cursorValue(c.Value)
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+
+	var c Cursor
+	if err := decoder.Decode(&c); err != nil || decoder.More() {
+		return nil, ErrInvalidCursor
+	}
+
+	// Só valores simples chegam ao SQL; objetos e listas vêm de cursor adulterado.
+	switch value := c.Value.(type) {
+	case nil, string, bool:
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			c.Value = i
+		} else if f, err := value.Float64(); err == nil {
+			c.Value = f
+		} else {
+			return nil, ErrInvalidCursor
+		}
+	default:
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Order != Asc && c.Order != Desc {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// cursorValue deixa o valor lido do banco num formato que volte igual na
+// comparação: textos do driver MySQL chegam como []byte e datas como
+// time.Time, que o SQLite compara como texto.
+func cursorValue(v any) any {
+	switch value := v.(type) {
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.UTC().Format("2006-01-02 15:04:05")
+	}
+	return v
+}
+
+// Seek devolve a condição que posiciona a consulta depois (ou antes) do
+// cursor, sem o WHERE, ou "" quando não há cursor.
+func (p Params) Seek(sorts Sorts) (string, []any) {
+	if p.Cursor == nil {
+		return "", nil
+	}
+
+	column := sorts.Column(p)
+
+	op := ">"
+	if (p.Order == Desc) != p.Cursor.Backward {
+		op = "<"
+	}
+
+	if column == sorts.Key {
+		return column + " " + op + " ?", []any{p.Cursor.Key}
+	}
+	return "(" + column + " " + op + " ? OR (" + column + " = ? AND " + sorts.Key + " " + op + " ?))",
+		[]any{p.Cursor.Value, p.Cursor.Value, p.Cursor.Key}
+}
+
+// Limit é o tamanho da página mais uma linha, que só indica se há outra
+// página adiante.
+func (p Params) Limit() int {
+	return p.Size() + 1
+}
+
+// Window recorta as linhas lidas com Limit à página pedida, desfaz a ordem
+// invertida das páginas anteriores e monta os cursores vizinhos. rows traz a
+// posição de cada item, na mesma ordem.
+func Window[T any](p Params, total int, items []T, rows []Row) ([]T, Meta) {
+	meta := Meta{Total: total}
+
+	more := len(items) > p.Size()
+	if more {
+		items, rows = items[:p.Size()], rows[:p.Size()]
+	}
+
+	backward := p.Cursor != nil && p.Cursor.Backward
+	if backward {
+		items, rows = slices.Clone(items), slices.Clone(rows)
+		slices.Reverse(items)
+		slices.Reverse(rows)
+	}
+
+	if len(rows) == 0 {
+		return items, meta
+	}
+
+	hasNext := more || backward
+	hasPrev := (more && backward) || (!backward && (p.Cursor != nil || p.Number() > 1))
+
+	order := p.Order
+	if order == "" {
+		order = Asc
+	}
+	if hasNext {
+		last := rows[len(rows)-1]
+		meta.NextCursor = Cursor{Sort: p.Sort, Order: order, Value: last.Value, Key: last.Key}.Encode()
+	}
+	if hasPrev {
+		first := rows[0]
+		meta.PrevCursor = Cursor{Sort: p.Sort, Order: order, Value: first.Value, Key: first.Key, Backward: true}.Encode()
+	}
+
+	return items, meta
+}
@@ -0,0 +1,162 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+		want   any
+	}{
+		{name: "texto", cursor: Cursor{Sort: "name", Order: Asc, Value: []byte("joao"), Key: 3}, want: "joao"},
+		{name: "número", cursor: Cursor{Sort: "id", Order: Desc, Value: int64(42), Key: 42, Backward: true}, want: int64(42)},
+		{name: "data", cursor: Cursor{Order: Asc, Value: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), Key: 1}, want: "2024-05-01 10:30:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			want := tt.cursor
+			want.Value = tt.want
+			if !reflect.DeepEqual(*got, want) {
+				t.Errorf("esperava %+v, recebeu %+v", want, *got)
+			}
+		})
+	}
+
+	invalid := []string{
+		"%%%",
+		"bm90IGpzb24",
+		Cursor{Order: "up"}.Encode(),
+		Cursor{Order: Asc, Value: map[string]any{"a": 1}}.Encode(),
+		Cursor{Order: Asc, Value: []any{1, 2}}.Encode(),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"o":"asc","x":1}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"o":"asc"}{"o":"asc"}`)),
+	}
+	for _, raw := range invalid {
+		if _, err := DecodeCursor(raw); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%q: esperava ErrInvalidCursor, recebeu %v", raw, err)
+		}
+	}
+}
+
+func TestParams_Seek(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   Params
+		want     string
+		wantArgs []any
+	}{
+		{name: "sem cursor", params: Params{}},
+		{
+			name:     "adiante pela chave",
+			params:   Params{Cursor: &Cursor{Order: Asc, Value: int64(5), Key: 5}},
+			want:     "t.id > ?",
+			wantArgs: []any{int64(5)},
+		},
+		{
+			name:     "adiante decrescente",
+			params:   Params{Sort: "name", Order: Desc, Cursor: &Cursor{Sort: "name", Order: Desc, Value: "m", Key: 5}},
+			want:     "(t.name < ? OR (t.name = ? AND t.id < ?))",
+			wantArgs: []any{"m", "m", int64(5)},
+		},
+		{
+			name:     "para trás",
+			params:   Params{Sort: "name", Order: Asc, Cursor: &Cursor{Sort: "name", Order: Asc, Value: "m", Key: 5, Backward: true}},
+			want:     "(t.name < ? OR (t.name = ? AND t.id < ?))",
+			wantArgs: []any{"m", "m", int64(5)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := tt.params.Seek(testSorts)
+			if got != tt.want || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("esperava %q %v, recebeu %q %v", tt.want, tt.wantArgs, got, args)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	rows := func(keys ...int64) ([]int64, []Row) {
+		positions := make([]Row, len(keys))
+		for i, k := range keys {
+			positions[i] = Row{Value: k, Key: k}
+		}
+		return keys, positions
+	}
+
+	tests := []struct {
+		name     string
+		params   Params
+		read     []int64
+		want     []int64
+		wantNext bool
+		wantPrev bool
+	}{
+		{name: "primeira página", params: Params{PageSize: 2}, read: []int64{1, 2, 3}, want: []int64{1, 2}, wantNext: true},
+		{name: "última página", params: Params{Page: 2, PageSize: 2}, read: []int64{3}, want: []int64{3}, wantPrev: true},
+		{
+			name:     "depois do cursor",
+			params:   Params{PageSize: 2, Cursor: &Cursor{Order: Asc, Key: 2}},
+			read:     []int64{3, 4, 5},
+			want:     []int64{3, 4},
+			wantNext: true,
+			wantPrev: true,
+		},
+		{
+			name:     "antes do cursor, no início",
+			params:   Params{PageSize: 2, Cursor: &Cursor{Order: Asc, Key: 3, Backward: true}},
+			read:     []int64{2, 1},
+			want:     []int64{1, 2},
+			wantNext: true,
+		},
+		{name: "vazia", params: Params{Cursor: &Cursor{Order: Asc, Key: 9}}, read: []int64{}, want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, positions := rows(tt.read...)
+
+			got, meta := Window(tt.params, 5, items, positions)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("esperava %v, recebeu %v", tt.want, got)
+			}
+			if (meta.NextCursor != "") != tt.wantNext || (meta.PrevCursor != "") != tt.wantPrev {
+				t.Errorf("cursores inesperados: next=%q prev=%q", meta.NextCursor, meta.PrevCursor)
+			}
+			if meta.Total != 5 {
+				t.Errorf("esperava total 5, recebeu %d", meta.Total)
+			}
+		})
+	}
+}
+
+func TestParse_Cursor(t *testing.T) {
+	raw := Cursor{Sort: "name", Order: Desc, Value: "m", Key: 5}.Encode()
+
+	p, err := Parse(map[string][]string{"cursor": {raw}, "sort": {"id"}, "page_size": {"5"}}, testSorts)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if p.Sort != "name" || p.Order != Desc || p.PageSize != 5 || p.Cursor == nil || p.Number() != 0 {
+		t.Errorf("o cursor deve definir a ordenação: %+v", p)
+	}
+
+	if _, err := Parse(map[string][]string{"cursor": {raw}, "page": {"2"}}, testSorts); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor com page: esperava ErrInvalidCursor, recebeu %v", err)
+	}
+
+	other := Cursor{Sort: "password", Order: Asc, Key: 1}.Encode()
+	if _, err := Parse(map[string][]string{"cursor": {other}}, testSorts); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("cursor de outro campo: esperava ErrInvalidSort, recebeu %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+var (
+	ErrInvalidPage  = errors.New("page e page_size devem ser inteiros positivos")
+	ErrInvalidSort  = errors.New("campo de ordenação invalido")
+	ErrInvalidOrder = errors.New("order deve ser asc ou desc")
+)
+
+type Order string
+
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// Sorts relaciona os campos aceitos em sort às colunas SQL de uma listagem.
+// Key é uma coluna única usada no desempate, para que a ordem seja estável
+// entre as páginas.
+type Sorts struct {
+	Fields  map[string]string
+	Default string
+	Key     string
+}
+
+// Params são a página e a ordenação pedidas. O valor zero equivale à
+// primeira página, com o tamanho e a ordenação padrão. Com Cursor, a página
+// é a que vem depois (ou antes) dele e Page é ignorado.
+type Params struct {
+	Page     int
+	PageSize int
+	Sort     string
+	Order    Order
+	Cursor   *Cursor
+}
+
+// Parse lê page, page_size, sort, order e cursor da query string, aceitando
+// em sort apenas os campos de sorts. page_size acima de MaxPageSize é
+// reduzido. O cursor já traz sort e order, que prevalecem sobre os da query.
+func Parse(query url.Values, sorts Sorts) (Params, error) {
+	p := Params{Sort: query.Get("sort"), Order: Order(strings.ToLower(query.Get("order")))}
+
+	var err error
+	if p.Page, err = positive(query.Get("page")); err != nil {
+		return Params{}, err
+	}
+	if p.PageSize, err = positive(query.Get("page_size")); err != nil {
+		return Params{}, err
+	}
+	p.PageSize = min(p.PageSize, MaxPageSize)
+
+	if raw := query.Get("cursor"); raw != "" {
+		if p.Page != 0 {
+			return Params{}, ErrInvalidCursor
+		}
+
+		if p.Cursor, err = DecodeCursor(raw); err != nil {
+			return Params{}, err
+		}
+		p.Sort, p.Order = p.Cursor.Sort, p.Cursor.Order
+	}
+
+	if _, ok := sorts.Fields[p.Sort]; p.Sort != "" && !ok {
+		return Params{}, ErrInvalidSort
+	}
+	if p.Order != "" && p.Order != Asc && p.Order != Desc {
+		return Params{}, ErrInvalidOrder
+	}
+
+	return p, nil
+}
+
+func positive(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, ErrInvalidPage
+	}
+	return n, nil
+}
+
+func (p Params) Size() int {
+	if p.PageSize < 1 {
+		return DefaultPageSize
+	}
+	return min(p.PageSize, MaxPageSize)
+}
+
+// Number é a página pedida, ou 0 quando a página vem de um cursor.
+func (p Params) Number() int {
+	if p.Cursor != nil {
+		return 0
+	}
+	return max(p.Page, 1)
+}
+
+func (p Params) Offset() int {
+	if p.Cursor != nil {
+		return 0
+	}
+	return (p.Number() - 1) * p.Size()
+}
+
+// Column devolve a coluna SQL do campo pedido. Campos fora da lista caem na
+// ordenação padrão.
+func (s Sorts) Column(p Params) string {
+	if column, ok := s.Fields[p.Sort]; ok {
+		return column
+	}
+	return s.Fields[s.Default]
+}
+
+// OrderBy monta a cláusula ORDER BY com a coluna pedida e o desempate por
+// sorts.Key. Ao voltar de um cursor a ordem é invertida, e Window a desfaz.
+func (p Params) OrderBy(sorts Sorts) string {
+	column := sorts.Column(p)
+
+	direction := "ASC"
+	if (p.Order == Desc) != (p.Cursor != nil && p.Cursor.Backward) {
+		direction = "DESC"
+	}
+
+	clause := " ORDER BY " + column + " " + direction
+	if column != sorts.Key {
+		clause += ", " + sorts.Key + " " + direction
+	}
+	return clause
+}
+
+// Meta é o que a listagem sabe além dos itens: o total sob os filtros e os
+// cursores das páginas vizinhas, vazios quando não há página naquele sentido.
+type Meta struct {
+	Total      int
+	NextCursor string
+	PrevCursor string
+}
+
+// Page é o envelope comum das listagens. page só vem na paginação por
+// número; next_cursor e prev_cursor vêm nos dois modos.
+type Page[T any] struct {
+	Results    []T    `json:"results"`
+	Total      int    `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+func NewPage[T any](results []T, meta Meta, p Params) Page[T] {
+	if results == nil {
+		results = []T{}
+	}
+
+	size := p.Size()
+	return Page[T]{
+		Results:    results,
+		Total:      meta.Total,
+		Page:       p.Number(),
+		PageSize:   size,
+		TotalPages: (meta.Total + size - 1) / size,
+		NextCursor: meta.NextCursor,
+		PrevCursor: meta.PrevCursor,
+	}
+}
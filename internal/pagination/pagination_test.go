@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var testSorts = Sorts{
+	Fields:  map[string]string{"id": "t.id", "name": "t.name"},
+	Default: "id",
+	Key:     "t.id",
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Params
+		wantErr error
+	}{
+		{name: "padrão", query: "", want: Params{}},
+		{name: "completo", query: "page=3&page_size=20&sort=name&order=DESC", want: Params{Page: 3, PageSize: 20, Sort: "name", Order: Desc}},
+		{name: "page_size acima do máximo", query: "page_size=1000", want: Params{PageSize: MaxPageSize}},
+		{name: "page inválida", query: "page=abc", wantErr: ErrInvalidPage},
+		{name: "page zero", query: "page=0", wantErr: ErrInvalidPage},
+		{name: "page_size negativo", query: "page_size=-5", wantErr: ErrInvalidPage},
+		{name: "campo fora da lista", query: "sort=password", wantErr: ErrInvalidSort},
+		{name: "direção inválida", query: "order=up", wantErr: ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+
+			got, err := Parse(query, testSorts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("esperava %+v, recebeu %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParams_OrderBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+		want   string
+	}{
+		{name: "padrão", params: Params{}, want: " ORDER BY t.id ASC"},
+		{name: "com desempate", params: Params{Sort: "name", Order: Desc}, want: " ORDER BY t.name DESC, t.id DESC"},
+		{name: "campo desconhecido", params: Params{Sort: "x"}, want: " ORDER BY t.id ASC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.params.OrderBy(testSorts); got != tt.want {
+				t.Errorf("esperava %q, recebeu %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		p     Params
+		want  Page[int]
+	}{
+		{name: "sem resultados", total: 0, p: Params{}, want: Page[int]{Results: []int{}, Page: 1, PageSize: 10}},
+		{name: "última página incompleta", total: 21, p: Params{Page: 3}, want: Page[int]{Results: []int{}, Total: 21, Page: 3, PageSize: 10, TotalPages: 3}},
+		{name: "páginas exatas", total: 40, p: Params{PageSize: 20}, want: Page[int]{Results: []int{}, Total: 40, Page: 1, PageSize: 20, TotalPages: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPage[int](nil, Meta{Total: tt.total}, tt.p)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("esperava %+v, recebeu %+v", tt.want, got)
+			}
+		})
+	}
+}
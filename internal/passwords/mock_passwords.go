@@ -0,0 +1,40 @@
+package passwords
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockResetRepo struct {
+	mock.Mock
+}
+
+func (m *MockResetRepo) Create(ctx context.Context, t *onetime.Token) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockResetRepo) Consume(ctx context.Context, t *onetime.Token, passwordHash string, now time.Time) error {
+	args := m.Called(ctx, t, passwordHash, now)
+	return args.Error(0)
+}
+
+func (m *MockResetRepo) GetByHash(ctx context.Context, tokenHash string) (*onetime.Token, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*onetime.Token), args.Error(1)
+}
+
+type MockSessionRevoker struct {
+	mock.Mock
+}
+
+func (m *MockSessionRevoker) Record(userID int64, cutoff sessions.Cutoff) {
+	m.Called(userID, cutoff)
+}
@@ -0,0 +1,44 @@
+package passwords
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+)
+
+// DefaultResetTTL é por quanto tempo o token enviado por email vale.
+const DefaultResetTTL = time.Hour
+
+var ErrInvalidToken = errors.New("token de redefinição invalido ou expirado")
+
+// IResetRepository guarda os pedidos de redefinição de senha, tokens de uso
+// único da tabela password_resets.
+type IResetRepository interface {
+	onetime.ITokenStore
+	// Consume marca o token como usado, junto com os outros pedidos ainda
+	// pendentes do mesmo usuário, e, na mesma transação, grava a nova senha e
+	// encerra as sessões abertas até now. Devolve ErrInvalidToken se outro
+	// pedido usou o token antes ou se ele expirou.
+	Consume(ctx context.Context, t *onetime.Token, passwordHash string, now time.Time) error
+}
+
+// SessionRevoker leva para a cópia em memória das revogações o corte que
+// Consume gravou, para que os tokens de acesso antigos caiam na hora.
+type SessionRevoker interface {
+	Record(userID int64, cutoff sessions.Cutoff)
+}
+
+// ResetTTLFromEnv lê PASSWORD_RESET_TTL_MINUTES, usando o padrão para valores
+// ausentes ou inválidos.
+func ResetTTLFromEnv() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("PASSWORD_RESET_TTL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		return DefaultResetTTL
+	}
+	return time.Duration(minutes) * time.Minute
+}
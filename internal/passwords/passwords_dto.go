@@ -0,0 +1,12 @@
+package passwords
+
+// @Description Email da conta que vai receber o token de redefinição
+type ForgotRequest struct {
+	Email string `json:"email" binding:"required" example:"joaquim@email.com"`
+}
+
+// @Description Token recebido por email e a nova senha
+type ResetRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required" example:"novaSenha123"`
+}
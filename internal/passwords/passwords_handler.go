@@ -0,0 +1,95 @@
+package passwords
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PasswordHandler struct {
+	svc    PasswordService
+	logApp *zap.Logger
+}
+
+func NewPasswordHandler(svc PasswordService, logApp *zap.Logger) *PasswordHandler {
+	return &PasswordHandler{svc: svc, logApp: logApp}
+}
+
+func passwordError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidToken), errors.Is(err, users.ErrWeakPassword):
+		return middleware.BadRequest.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Pede a redefinição de senha
+// @Description Envia ao email informado um token de uso único para escolher uma nova senha. A resposta é a mesma exista ou não conta com o email.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param data body ForgotRequest true "Email da conta"
+// @Success 202 "Pedido aceito"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /public/api/users/password/forgot [post]
+func (h *PasswordHandler) Forgot(c *gin.Context) {
+	h.logApp.Info("Rota de pedir redefinição de senha")
+
+	var dtoReq ForgotRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	err := h.svc.Forgot(c.Request.Context(), dtoReq.Email)
+	if errors.Is(err, onetime.ErrDelivery) {
+		// só um email com conta chega ao envio; o 202 de sempre esconde isso
+		// de quem digitou o email, e o dono da conta pede a redefinição de novo
+		h.logApp.Error("falha ao enviar email de redefinição", zap.Error(err))
+		err = nil
+	}
+	if err != nil {
+		h.logApp.Error("falha ao pedir redefinição de senha", zap.Error(err))
+		_ = c.Error(passwordError(err))
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Redefine a senha
+// @Description Troca a senha usando o token recebido por email e encerra todas as sessões do usuario.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param data body ResetRequest true "Token e nova senha"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "Token invalido, expirado ou já usado, ou senha fora da política"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /public/api/users/password/reset [post]
+func (h *PasswordHandler) Reset(c *gin.Context) {
+	h.logApp.Info("Rota de redefinir senha")
+
+	var dtoReq ResetRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	if err := h.svc.Reset(c.Request.Context(), dtoReq.Token, dtoReq.Password); err != nil {
+		h.logApp.Error("falha ao redefinir senha", zap.Error(err))
+		_ = c.Error(passwordError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
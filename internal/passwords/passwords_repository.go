@@ -0,0 +1,37 @@
+package passwords
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+)
+
+type ResetRepository struct {
+	*onetime.Store
+}
+
+func NewResetRepository(db *sql.DB) *ResetRepository {
+	return &ResetRepository{Store: onetime.NewStore(db, "password_resets", ErrInvalidToken)}
+}
+
+func (r *ResetRepository) Consume(ctx context.Context, t *onetime.Token, passwordHash string, now time.Time) error {
+	return r.Spend(ctx, t, now, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "UPDATE users SET password = ? WHERE id = ?", passwordHash, t.UserID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return sql.ErrNoRows
+		}
+
+		return sessions.InsertCutoff(ctx, tx, t.UserID, sessions.Cutoff{Before: now})
+	})
+}
@@ -0,0 +1,87 @@
+package passwords_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/passwords"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func TestResetRepository_Consume(t *testing.T) {
+	db := database.SetupTestDB()
+	repo := passwords.NewResetRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if err := users.NewUsersRepository(db).Create(ctx, &users.Users{Name: "Leitor", Email: "um@email.com", Username: "um", Password: "hash", Role: users.User}); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+
+	tokens := map[string]*onetime.Token{}
+	for _, name := range []string{"primeiro", "segundo", "expirado"} {
+		expiresAt := now.Add(time.Hour)
+		if name == "expirado" {
+			expiresAt = now.Add(-time.Minute)
+		}
+
+		tokens[name] = &onetime.Token{UserID: 1, TokenHash: sessions.HashToken(name), ExpiresAt: expiresAt}
+		if err := repo.Create(ctx, tokens[name]); err != nil {
+			t.Fatalf("erro ao criar token: %v", err)
+		}
+	}
+
+	if _, err := repo.GetByHash(ctx, sessions.HashToken("outro")); !errors.Is(err, passwords.ErrInvalidToken) {
+		t.Errorf("esperava ErrInvalidToken, recebeu %v", err)
+	}
+
+	if err := repo.Consume(ctx, tokens["expirado"], "expirada", now); !errors.Is(err, passwords.ErrInvalidToken) {
+		t.Errorf("token expirado não deveria ser aceito: %v", err)
+	}
+
+	cutoffs := func() int {
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM token_cutoffs WHERE user_id = 1").Scan(&count); err != nil {
+			t.Fatalf("erro ao contar cortes: %v", err)
+		}
+		return count
+	}
+	if got := cutoffs(); got != 0 {
+		t.Errorf("token recusado não deveria encerrar as sessões, cortes=%d", got)
+	}
+
+	if err := repo.Consume(ctx, tokens["primeiro"], "nova", now); err != nil {
+		t.Fatalf("erro ao usar token: %v", err)
+	}
+	if err := repo.Consume(ctx, tokens["primeiro"], "repetida", now); !errors.Is(err, passwords.ErrInvalidToken) {
+		t.Errorf("token usado não deveria valer de novo: %v", err)
+	}
+
+	// só o pedido que gastou o token troca a senha
+	var password string
+	if err := db.QueryRow("SELECT password FROM users WHERE id = 1").Scan(&password); err != nil {
+		t.Fatalf("erro ao buscar senha: %v", err)
+	}
+	if password != "nova" {
+		t.Errorf("esperava a senha do token usado, recebeu %q", password)
+	}
+
+	// as sessões são encerradas na mesma transação da troca
+	if got := cutoffs(); got != 1 {
+		t.Errorf("esperava as sessões encerradas, cortes=%d", got)
+	}
+
+	// usar um token encerra os outros pedidos pendentes do usuário
+	pending, err := repo.GetByHash(ctx, tokens["segundo"].TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if pending.UsedAt == nil || pending.Usable(now) {
+		t.Errorf("esperava pedido pendente encerrado: %+v", pending)
+	}
+}
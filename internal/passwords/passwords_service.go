@@ -0,0 +1,109 @@
+package passwords
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/notify"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+type PasswordService interface {
+	// Forgot envia um token de redefinição ao email informado. Um email sem
+	// conta não é erro, para a rota não revelar quem está cadastrado; falhas
+	// no envio voltam como onetime.ErrDelivery.
+	Forgot(ctx context.Context, email string) error
+	// Reset troca a senha do dono do token e encerra as sessões dele.
+	Reset(ctx context.Context, token, password string) error
+}
+
+type servicePassword struct {
+	repo     IResetRepository
+	users    onetime.UserAccounts
+	sessions SessionRevoker
+	notifier notify.Notifier
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+func NewPasswordService(repo IResetRepository, accounts onetime.UserAccounts, sessions SessionRevoker,
+	notifier notify.Notifier, ttl time.Duration) *servicePassword {
+	return &servicePassword{
+		repo:     repo,
+		users:    accounts,
+		sessions: sessions,
+		notifier: notifier,
+		ttl:      ttl,
+		now:      func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+func (s *servicePassword) Forgot(ctx context.Context, email string) error {
+	user, err := s.users.GetUserDetails(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := sessions.NewToken()
+	if err != nil {
+		return err
+	}
+
+	t := &onetime.Token{UserID: user.ID, TokenHash: sessions.HashToken(token), ExpiresAt: s.now().Add(s.ttl)}
+	if err := s.repo.Create(ctx, t); err != nil {
+		return err
+	}
+
+	err = s.notifier.Notify(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Redefinição de senha",
+		Body: fmt.Sprintf("Use o token abaixo em POST /public/api/users/password/reset para escolher uma nova senha. "+
+			"Ele vale por %s e uma única vez. Se você não pediu a redefinição, ignore esta mensagem.\n\n%s",
+			s.ttl, token),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", onetime.ErrDelivery, err)
+	}
+	return nil
+}
+
+func (s *servicePassword) Reset(ctx context.Context, token, password string) error {
+	// a senha é conferida antes de gastar o token, para que o usuário possa
+	// tentar de novo com outra
+	if err := users.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	now := s.now()
+
+	t, err := s.repo.GetByHash(ctx, sessions.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if !t.Usable(now) {
+		return ErrInvalidToken
+	}
+
+	hash, err := middleware.HashPassowrd(password)
+	if err != nil {
+		return err
+	}
+
+	// token, senha e sessões mudam juntos: se algo falhar, nada muda e o
+	// token continua valendo para uma nova tentativa
+	if err := s.repo.Consume(ctx, t, hash, now); err != nil {
+		return err
+	}
+
+	s.sessions.Record(t.UserID, sessions.Cutoff{Before: now})
+	return nil
+}
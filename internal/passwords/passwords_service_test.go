@@ -0,0 +1,158 @@
+package passwords
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/notify"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_servicePassword_Forgot(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		findErr   error
+		notify    bool
+		notifyErr error
+		wantErr   error
+	}{
+		{name: "sucesso", notify: true},
+		{name: "falha no envio", notify: true, notifyErr: errors.New("smtp"), wantErr: onetime.ErrDelivery},
+		{name: "email sem conta", findErr: sql.ErrNoRows},
+		{name: "erro no banco", findErr: errors.New("db"), wantErr: errors.New("db")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockResetRepo)
+			mockUsers := new(onetime.MockUserAccounts)
+			mockNotifier := new(notify.MockNotifier)
+
+			var user *users.Users
+			if tt.findErr == nil {
+				user = &users.Users{ID: 7, Email: "um@email.com"}
+			}
+			mockUsers.On("GetUserDetails", mock.Anything, "um@email.com").Return(user, tt.findErr)
+
+			var sent notify.Message
+			var created *onetime.Token
+			if tt.notify {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*onetime.Token")).
+					Run(func(args mock.Arguments) { created = args.Get(1).(*onetime.Token) }).Return(nil)
+				mockNotifier.On("Notify", mock.Anything, mock.AnythingOfType("notify.Message")).
+					Run(func(args mock.Arguments) { sent = args.Get(1).(notify.Message) }).Return(tt.notifyErr)
+			}
+
+			svc := NewPasswordService(mockRepo, mockUsers, new(MockSessionRevoker), mockNotifier, DefaultResetTTL)
+			svc.now = func() time.Time { return now }
+
+			err := svc.Forgot(context.Background(), "um@email.com")
+
+			if errors.Is(tt.wantErr, onetime.ErrDelivery) {
+				assert.ErrorIs(t, err, onetime.ErrDelivery)
+			} else if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.notify {
+				assert.Equal(t, int64(7), created.UserID)
+				assert.True(t, created.ExpiresAt.Equal(now.Add(DefaultResetTTL)))
+				assert.Equal(t, "um@email.com", sent.To)
+
+				// a mensagem leva o token, e o banco só o hash dele
+				lines := strings.Split(sent.Body, "\n")
+				token := lines[len(lines)-1]
+				assert.Equal(t, sessions.HashToken(token), created.TokenHash)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockUsers.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_servicePassword_Reset(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		password   string
+		current    *onetime.Token
+		findErr    error
+		consume    bool
+		consumeErr error
+		wantErr    error
+	}{
+		{
+			name:     "sucesso",
+			password: "novaSenha",
+			current:  &onetime.Token{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Hour)},
+			consume:  true,
+		},
+		{name: "senha curta", password: "123", wantErr: users.ErrWeakPassword},
+		{name: "token desconhecido", password: "novaSenha", findErr: ErrInvalidToken, wantErr: ErrInvalidToken},
+		{
+			name:     "token expirado",
+			password: "novaSenha",
+			current:  &onetime.Token{ID: 1, UserID: 7, ExpiresAt: earlier},
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name:     "token já usado",
+			password: "novaSenha",
+			current:  &onetime.Token{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Hour), UsedAt: &earlier},
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name:       "usado por outro pedido ao mesmo tempo",
+			password:   "novaSenha",
+			current:    &onetime.Token{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Hour)},
+			consume:    true,
+			consumeErr: ErrInvalidToken,
+			wantErr:    ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockResetRepo)
+			mockUsers := new(onetime.MockUserAccounts)
+			mockSessions := new(MockSessionRevoker)
+
+			if tt.current != nil || tt.findErr != nil {
+				mockRepo.On("GetByHash", mock.Anything, sessions.HashToken("token")).Return(tt.current, tt.findErr)
+			}
+			if tt.consume {
+				mockRepo.On("Consume", mock.Anything, tt.current, mock.MatchedBy(func(hash string) bool {
+					return middleware.VerifyPassword(hash, tt.password)
+				}), now).Return(tt.consumeErr)
+			}
+			if tt.consume && tt.consumeErr == nil {
+				mockSessions.On("Record", int64(7), sessions.Cutoff{Before: now})
+			}
+
+			svc := NewPasswordService(mockRepo, mockUsers, mockSessions, new(notify.MockNotifier), DefaultResetTTL)
+			svc.now = func() time.Time { return now }
+
+			err := svc.Reset(context.Background(), "token", tt.password)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertExpectations(t)
+			mockUsers.AssertExpectations(t)
+			mockSessions.AssertExpectations(t)
+		})
+	}
+}
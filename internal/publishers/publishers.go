@@ -0,0 +1,40 @@
+package publishers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+var ErrPublisherNotFound = errors.New("editora não encontrada")
+
+type Publishers struct {
+	ID      int64
+	Name    string
+	Country string
+}
+
+type PublishersCreator interface {
+	Create(ctx context.Context, p *Publishers) error
+	Update(ctx context.Context, p *Publishers) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type PublishersRead interface {
+	GetAll(ctx context.Context, p pagination.Params) ([]Publishers, pagination.Meta, error)
+	GetByID(ctx context.Context, id int64) (*Publishers, error)
+}
+
+type IPublisherRepository interface {
+	PublishersCreator
+	PublishersRead
+}
+
+func (p *Publishers) Validate() error {
+	if p.Name == "" {
+		return errors.New("nome invalido")
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package publishers
+
+// @Description Dados para adicionar uma editora
+type PublisherRequest struct {
+	Name    string `json:"name" binding:"required" example:"Companhia das Letras"`
+	Country string `json:"country" example:"BR"`
+}
+
+type PublisherResponse struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
+func ToResponse(p *Publishers) PublisherResponse {
+	return PublisherResponse(*p)
+}
@@ -0,0 +1,207 @@
+package publishers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PublisherHandler struct {
+	svc    PublishersService
+	logApp *zap.Logger
+}
+
+func NewPublishersHandler(svc PublishersService, log *zap.Logger) *PublisherHandler {
+	return &PublisherHandler{svc: svc, logApp: log}
+}
+
+func publisherError(err error) error {
+	if errors.Is(err, ErrPublisherNotFound) {
+		return middleware.NotFound
+	}
+	return middleware.InternalErr
+}
+
+// @Summary Cria uma nova editora
+// @Description Recebe um objeto JSON PublisherRequest e salva a editora no banco de dados.
+// @Tags publishers
+// @Accept  json
+// @Produce json
+// @Param   publisher body PublisherRequest true "Dados da nova editora"
+// @Success 201 {object} PublisherResponse "Editora criada com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/publishers [post]
+func (h *PublisherHandler) CreatePublisher(c *gin.Context) {
+	h.logApp.Info("Rota de criar editora")
+
+	var dto PublisherRequest
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	publisher := &Publishers{
+		Name:    dto.Name,
+		Country: dto.Country,
+	}
+
+	if err := h.svc.Create(c.Request.Context(), publisher); err != nil {
+		h.logApp.Error("falha ao criar editora", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(publisher))
+}
+
+// @Summary Listar editoras
+// @Description Retorna uma lista de editoras
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 10, máximo 100)"
+// @Param sort query string false "Campo de ordenação" Enums(id, name, country)
+// @Param order query string false "Direção da ordenação" Enums(asc, desc)
+// @Param cursor query string false "Cursor de next_cursor ou prev_cursor; substitui page, sort e order"
+// @Success 200 {object} pagination.Page[PublisherResponse]
+// @Failure 400 {object} middleware.APIError "Paginação ou ordenação inválida"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/publishers [get]
+func (h *PublisherHandler) ReadPublishers(c *gin.Context) {
+	h.logApp.Info("Rota de obter editoras")
+
+	params, err := pagination.Parse(c.Request.URL.Query(), PublisherSorts)
+	if err != nil {
+		h.logApp.Error("falha ao ler paginação", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	publishers, meta, err := h.svc.GetAll(c.Request.Context(), params)
+	if err != nil {
+		h.logApp.Error("falha ao obter editoras", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	response := make([]PublisherResponse, 0, len(publishers))
+	for _, p := range publishers {
+		response = append(response, ToResponse(&p))
+	}
+
+	c.JSON(http.StatusOK, pagination.NewPage(response, meta, params))
+}
+
+// @Summary Obter editora
+// @Description Retorna uma editora
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param id path int true "Recebe o id da editora"
+// @Success 200 {object} PublisherResponse
+// @Failure 404 {object} middleware.APIError "Editora não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/publishers/{id} [get]
+func (h *PublisherHandler) ReadPublisher(c *gin.Context) {
+	h.logApp.Info("Rota de obter editora")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	publisher, err := h.svc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao obter editora", zap.Error(err))
+		_ = c.Error(publisherError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(publisher))
+}
+
+// @Summary Atualiza uma editora
+// @Description Recebe um objeto JSON PublisherRequest e atualiza a editora no banco de dados.
+// @Tags publishers
+// @Accept  json
+// @Produce json
+// @Param id path int true "Recebe o id da editora"
+// @Param   publisher body PublisherRequest true "Dados da editora a ser atualizada"
+// @Success 204  "Editora atualizada com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 404 {object} middleware.APIError "Editora não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/publishers/{id} [put]
+func (h *PublisherHandler) UpdatePublisher(c *gin.Context) {
+	h.logApp.Info("Rota de atualizar editora")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	var dto PublisherRequest
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	publisher := &Publishers{
+		ID:      id,
+		Name:    dto.Name,
+		Country: dto.Country,
+	}
+
+	if err := h.svc.Update(c.Request.Context(), publisher); err != nil {
+		h.logApp.Error("erro ao atualizar editora", zap.Error(err))
+		_ = c.Error(publisherError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Exclui uma editora pelo ID
+// @Description Exclui uma editora; os livros dela ficam sem editora.
+// @Tags publishers
+// @Accept  json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param   id path int true "ID da editora a ser excluída"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (ID com formato incorreto)"
+// @Failure 404 {object} middleware.APIError "Editora não encontrada"
+// @Router /api/publishers/{id} [delete]
+func (h *PublisherHandler) DeletePublisher(c *gin.Context) {
+	h.logApp.Info("Rota de apagar editora")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
+		h.logApp.Error("falha ao apagar editora", zap.Error(err))
+		_ = c.Error(publisherError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package publishers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+type PublishersRepository struct {
+	db *sql.DB
+}
+
+func NewPublishersRepository(db *sql.DB) *PublishersRepository {
+	return &PublishersRepository{db: db}
+}
+
+func (r *PublishersRepository) Create(ctx context.Context, p *Publishers) error {
+	query := "INSERT INTO publishers (name, country) VALUES (?, ?)"
+	result, err := r.db.ExecContext(ctx, query, p.Name, p.Country)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	p.ID = id
+	return nil
+}
+
+// PublisherSorts são os campos aceitos em sort na listagem de editoras.
+var PublisherSorts = pagination.Sorts{
+	Fields:  map[string]string{"id": "id", "name": "name", "country": "country"},
+	Default: "name",
+	Key:     "id",
+}
+
+func (r *PublishersRepository) GetAll(ctx context.Context, page pagination.Params) ([]Publishers, pagination.Meta, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM publishers").Scan(&total); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	seek, args := page.Seek(PublisherSorts)
+	if seek != "" {
+		seek = " WHERE " + seek
+	}
+
+	query := "SELECT id, name, country, " + PublisherSorts.Column(page) + " FROM publishers" + seek + page.OrderBy(PublisherSorts) + " LIMIT ? OFFSET ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, page.Limit(), page.Offset())...)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	defer rows.Close()
+
+	var publishersAll []Publishers
+	var positions []pagination.Row
+
+	for rows.Next() {
+		var p Publishers
+		var position pagination.Row
+		if err := rows.Scan(&p.ID, &p.Name, &p.Country, &position.Value); err != nil {
+			return nil, pagination.Meta{}, err
+		}
+		position.Key = p.ID
+
+		publishersAll = append(publishersAll, p)
+		positions = append(positions, position)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	publishersAll, meta := pagination.Window(page, total, publishersAll, positions)
+	return publishersAll, meta, nil
+}
+
+func (r *PublishersRepository) GetByID(ctx context.Context, id int64) (*Publishers, error) {
+	var p Publishers
+
+	err := r.db.QueryRowContext(ctx, "SELECT id, name, country FROM publishers WHERE id = ?", id).
+		Scan(&p.ID, &p.Name, &p.Country)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPublisherNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *PublishersRepository) Update(ctx context.Context, p *Publishers) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE publishers SET name = ?, country = ? WHERE id = ?",
+		p.Name, p.Country, p.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrPublisherNotFound
+	}
+	return nil
+}
+
+func (r *PublishersRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM publishers WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrPublisherNotFound
+	}
+	return nil
+}
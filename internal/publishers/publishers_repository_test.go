@@ -0,0 +1,56 @@
+package publishers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/publishers"
+)
+
+func TestPublishersRepository_CRUD(t *testing.T) {
+	db := database.SetupTestDB()
+	r := publishers.NewPublishersRepository(db)
+	ctx := context.Background()
+
+	p := &publishers.Publishers{Name: "Editora A", Country: "BR"}
+	if err := r.Create(ctx, p); err != nil {
+		t.Fatalf("não esperava erro ao criar: %v", err)
+	}
+
+	if err := r.Create(ctx, &publishers.Publishers{Name: ""}); err == nil {
+		t.Errorf("esperava erro ao criar editora sem nome")
+	}
+
+	p.Name = "Editora B"
+	if err := r.Update(ctx, p); err != nil {
+		t.Fatalf("não esperava erro ao atualizar: %v", err)
+	}
+
+	found, err := r.GetByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar: %v", err)
+	}
+	if found.Name != "Editora B" || found.Country != "BR" {
+		t.Errorf("editora inesperada: %+v", found)
+	}
+
+	all, _, err := r.GetAll(ctx, pagination.Params{})
+	if err != nil || len(all) != 1 {
+		t.Fatalf("esperava 1 editora, recebeu %d (%v)", len(all), err)
+	}
+
+	if err := r.Delete(ctx, p.ID); err != nil {
+		t.Fatalf("não esperava erro ao apagar: %v", err)
+	}
+
+	if _, err := r.GetByID(ctx, p.ID); !errors.Is(err, publishers.ErrPublisherNotFound) {
+		t.Errorf("esperava ErrPublisherNotFound, recebeu %v", err)
+	}
+
+	if err := r.Delete(ctx, p.ID); !errors.Is(err, publishers.ErrPublisherNotFound) {
+		t.Errorf("esperava ErrPublisherNotFound ao apagar de novo, recebeu %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package publishers
+
+import (
+	"context"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+type PublishersService interface {
+	IPublisherRepository
+}
+
+type servicePublishers struct {
+	repo IPublisherRepository
+}
+
+func NewPublishersService(repo IPublisherRepository) *servicePublishers {
+	return &servicePublishers{repo: repo}
+}
+
+func (s *servicePublishers) Create(ctx context.Context, p *Publishers) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, p)
+}
+
+func (s *servicePublishers) GetAll(ctx context.Context, p pagination.Params) ([]Publishers, pagination.Meta, error) {
+	return s.repo.GetAll(ctx, p)
+}
+
+func (s *servicePublishers) GetByID(ctx context.Context, id int64) (*Publishers, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *servicePublishers) Update(ctx context.Context, p *Publishers) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, p)
+}
+
+func (s *servicePublishers) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
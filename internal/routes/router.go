@@ -1,22 +1,47 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/copies"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/covers"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/fines"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/holds"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/loans"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/notify"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/passwords"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/publishers"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/series"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/verifications"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type App struct {
-	BookHandler     *books.BookHandler
-	AuthorHandler   *authors.AuthorHandler
-	CategoryHandler *categories.CategoryHandler
-	UserHandler     *users.UserHandler
+	BookHandler      *books.BookHandler
+	AuthorHandler    *authors.AuthorHandler
+	CategoryHandler  *categories.CategoryHandler
+	PublisherHandler *publishers.PublisherHandler
+	SeriesHandler    *series.SeriesHandler
+	UserHandler      *users.UserHandler
+	PasswordHandler  *passwords.PasswordHandler
+	VerifyHandler    *verifications.VerificationHandler
+	LoanHandler      *loans.LoanHandler
+	CopyHandler      *copies.CopyHandler
+	HoldHandler      *holds.HoldHandler
+	HoldService      holds.HoldService
+	FineHandler      *fines.FineHandler
+	CoverHandler     *covers.CoverHandler
+	CoverStorage     covers.Storage
+	Revocations      *sessions.Revocations
 }
 
 func NewApp(db *sql.DB, logApp *zap.Logger) *App {
@@ -32,15 +57,70 @@ func NewApp(db *sql.DB, logApp *zap.Logger) *App {
 	catSvc := categories.NewCategoryService(catRepo)
 	catHanlder := categories.NewCategoryHandler(catSvc, logApp)
 
+	pubRepo := publishers.NewPublishersRepository(db)
+	pubSvc := publishers.NewPublishersService(pubRepo)
+	pubHandler := publishers.NewPublishersHandler(pubSvc, logApp)
+
+	seriesRepo := series.NewSeriesRepository(db)
+	seriesSvc := series.NewSeriesService(seriesRepo)
+	seriesHandler := series.NewSeriesHandler(seriesSvc, logApp)
+
+	sessionRepo := sessions.NewSessionRepository(db)
+	revocations := sessions.NewRevocations(sessionRepo, sessions.DefaultReloadInterval)
+	sessionSvc := sessions.NewSessionService(sessionRepo, revocations, sessions.RefreshTTLFromEnv())
+
+	notifier := notify.FromEnv(logApp)
+
 	userRepo := users.NewUsersRepository(db)
-	userSvc := users.NewUsersService(userRepo)
-	userHandler := users.NewUsersHandler(userSvc, logApp)
+	verifyRepo := verifications.NewVerifyRepository(db)
+	verifySvc := verifications.NewVerificationService(verifyRepo, userRepo, notifier, verifications.VerifyTTLFromEnv())
+	verifyHandler := verifications.NewVerificationHandler(verifySvc, logApp)
+
+	userSvc := users.NewUsersService(userRepo, users.RequireVerifiedLoginFromEnv())
+	userHandler := users.NewUsersHandler(userSvc, sessionSvc, verifySvc, logApp)
+
+	resetRepo := passwords.NewResetRepository(db)
+	passwordSvc := passwords.NewPasswordService(resetRepo, userRepo, revocations, notifier, passwords.ResetTTLFromEnv())
+	passwordHandler := passwords.NewPasswordHandler(passwordSvc, logApp)
+
+	fineRepo := fines.NewFineRepository(db)
+	fineSvc := fines.NewFineService(fineRepo, fines.PolicyFromEnv())
+	fineHandler := fines.NewFineHandler(fineSvc, logApp)
+
+	holdRepo := holds.NewHoldRepository(db)
+	holdSvc := holds.NewHoldService(holdRepo)
+	holdHandler := holds.NewHoldHandler(holdSvc, logApp)
+
+	loanRepo := loans.NewLoanRepository(db)
+	loanSvc := loans.NewLoanService(loanRepo, fineSvc, holdSvc, loans.PolicyFromEnv())
+	loanHandler := loans.NewLoanHandler(loanSvc, logApp)
+
+	copyRepo := copies.NewCopyRepository(db, holds.NewQueue())
+	copySvc := copies.NewCopyService(copyRepo)
+	copyHandler := copies.NewCopyHandler(copySvc, logApp)
+
+	coverStorage := covers.LocalStorageFromEnv()
+	coverRepo := covers.NewCoverRepository(db)
+	coverSvc := covers.NewCoverService(coverRepo, coverStorage, covers.PolicyFromEnv())
+	coverHandler := covers.NewCoverHandler(coverSvc, logApp)
 
 	return &App{
-		BookHandler:     bookHandler,
-		AuthorHandler:   authHandler,
-		CategoryHandler: catHanlder,
-		UserHandler:     userHandler,
+		BookHandler:      bookHandler,
+		AuthorHandler:    authHandler,
+		CategoryHandler:  catHanlder,
+		PublisherHandler: pubHandler,
+		SeriesHandler:    seriesHandler,
+		UserHandler:      userHandler,
+		PasswordHandler:  passwordHandler,
+		VerifyHandler:    verifyHandler,
+		LoanHandler:      loanHandler,
+		CopyHandler:      copyHandler,
+		HoldHandler:      holdHandler,
+		HoldService:      holdSvc,
+		FineHandler:      fineHandler,
+		CoverHandler:     coverHandler,
+		CoverStorage:     coverStorage,
+		Revocations:      revocations,
 	}
 }
 
@@ -49,6 +129,8 @@ func Routers(db *sql.DB, logApp *zap.Logger) *gin.Engine {
 
 	app := NewApp(db, logApp)
 
+	go holds.RunExpirer(context.Background(), app.HoldService, 15*time.Minute, logApp)
+
 	r.Use(middleware.ErrorHandler())
 	r.Use(middleware.Cors())
 
@@ -56,15 +138,30 @@ func Routers(db *sql.DB, logApp *zap.Logger) *gin.Engine {
 		c.JSON(200, gin.H{"status": "OK"})
 	})
 
+	// O armazenamento local é servido pela própria API; outros armazenamentos
+	// devolvem URLs de fora.
+	if local, ok := app.CoverStorage.(*covers.LocalStorage); ok {
+		r.Static(covers.PublicRoute, local.Dir())
+	}
+
 	public := r.Group("/public")
 
 	protected := r.Group("/")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(middleware.AuthMiddleware(app.Revocations))
 
 	routersBook(protected, public, app.BookHandler)
 	routesUsers(protected, public, app.UserHandler)
+	routesPasswords(public, app.PasswordHandler)
+	routesVerifications(public, app.VerifyHandler)
 	routersAuthors(protected, public, app.AuthorHandler)
 	routersCategories(protected, public, app.CategoryHandler)
+	routersPublishers(protected, public, app.PublisherHandler)
+	routersSeries(protected, public, app.SeriesHandler)
+	routersLoans(protected, app.LoanHandler)
+	routersCopies(protected, public, app.CopyHandler)
+	routersHolds(protected, app.HoldHandler)
+	routersFines(protected, app.FineHandler)
+	routersCovers(protected, public, app.CoverHandler)
 
 	return r
 }
@@ -77,9 +174,13 @@ func routersBook(pr *gin.RouterGroup, pl *gin.RouterGroup, h *books.BookHandler)
 	booksPr.POST("/relation", middleware.RequireRole("admin"), h.RelationBookCategory)
 	booksPr.PUT("/:id", middleware.RequireRole("admin"), h.UpdateBook)
 	booksPr.DELETE("/:id", middleware.RequireRole("admin"), h.DeleteBook)
+	booksPr.PUT("/:id/categories", middleware.RequireRole("admin"), h.ReplaceCategories)
+	booksPr.DELETE("/:id/categories/:categoryId", middleware.RequireRole("admin"), h.RemoveCategory)
 
 	booksPl.GET("/", h.ReadAllBooks)
 	booksPl.GET("/:id", h.ReadBook)
+	booksPl.GET("/isbn/:isbn", h.ReadBookByISBN)
+	booksPl.GET("/search", h.SearchBooks)
 }
 
 func routersAuthors(pr *gin.RouterGroup, pl *gin.RouterGroup, h *authors.AuthorHandler) {
@@ -103,18 +204,117 @@ func routersCategories(pr *gin.RouterGroup, pl *gin.RouterGroup, h *categories.C
 	categoriesPr.DELETE("/:id", middleware.RequireRole("admin"), h.DeleteCategory)
 
 	categoriesPl.GET("/", h.ReadCategories)
+	categoriesPl.GET("/tree", h.ReadTree)
 	categoriesPl.GET("/:id", h.ReadCategory)
+	categoriesPl.GET("/:id/ancestors", h.ReadAncestors)
+	categoriesPl.GET("/:id/descendants", h.ReadDescendants)
+}
+
+func routersPublishers(pr *gin.RouterGroup, pl *gin.RouterGroup, h *publishers.PublisherHandler) {
+	publishersPr := pr.Group("/api/publishers")
+	publishersPl := pl.Group("/api/publishers")
+
+	publishersPr.POST("/", middleware.RequireRole("admin"), h.CreatePublisher)
+	publishersPr.PUT("/:id", middleware.RequireRole("admin"), h.UpdatePublisher)
+	publishersPr.DELETE("/:id", middleware.RequireRole("admin"), h.DeletePublisher)
+
+	publishersPl.GET("/", h.ReadPublishers)
+	publishersPl.GET("/:id", h.ReadPublisher)
+}
+
+func routersSeries(pr *gin.RouterGroup, pl *gin.RouterGroup, h *series.SeriesHandler) {
+	seriesPr := pr.Group("/api/series")
+	seriesPl := pl.Group("/api/series")
+
+	seriesPr.POST("/", middleware.RequireRole("admin"), h.CreateSeries)
+	seriesPr.PUT("/:id", middleware.RequireRole("admin"), h.UpdateSeries)
+	seriesPr.DELETE("/:id", middleware.RequireRole("admin"), h.DeleteSeries)
+	seriesPr.POST("/:id/books", middleware.RequireRole("admin"), h.AddBook)
+	seriesPr.DELETE("/:id/books/:bookId", middleware.RequireRole("admin"), h.RemoveBook)
+
+	seriesPl.GET("/", h.ReadSeriesAll)
+	seriesPl.GET("/:id", h.ReadSeries)
+	seriesPl.GET("/:id/books", h.ReadSeriesBooks)
 }
 
 func routesUsers(pr *gin.RouterGroup, pl *gin.RouterGroup, h *users.UserHandler) {
 	usersPr := pr.Group("/api/users")
 	usersPl := pl.Group("/api/users")
 
+	usersPr.POST("/logout", h.Logout)
+	usersPr.POST("/logout/all", h.LogoutAll)
+	usersPr.PUT("/me/password", h.ChangePassword)
 	usersPr.PUT("/:id", middleware.RequireRole("user"), h.UpdateUser)
 	usersPr.DELETE("/:id", middleware.RequireRole("admin"), h.DeleteUser)
+	usersPr.PUT("/:id/role", middleware.RequireRole("admin"), h.ChangeRole)
 
 	usersPl.GET("/", h.ReadAllUsers)
 	usersPl.POST("/login", h.LoginUser)
+	usersPl.POST("/refresh", h.RefreshToken)
 	usersPl.POST("/", h.CreateUser)
 	usersPl.GET("/:id", h.ReadUser)
 }
+
+func routesPasswords(pl *gin.RouterGroup, h *passwords.PasswordHandler) {
+	passwordsPl := pl.Group("/api/users/password")
+
+	passwordsPl.POST("/forgot", h.Forgot)
+	passwordsPl.POST("/reset", h.Reset)
+}
+
+func routesVerifications(pl *gin.RouterGroup, h *verifications.VerificationHandler) {
+	emailPl := pl.Group("/api/users/email")
+
+	emailPl.POST("/confirm", h.Confirm)
+	emailPl.POST("/resend", h.Resend)
+}
+
+func routersLoans(pr *gin.RouterGroup, h *loans.LoanHandler) {
+	loansPr := pr.Group("/api/loans")
+
+	loansPr.POST("/", middleware.RequireRole("admin"), h.CreateLoan)
+	loansPr.POST("/:id/return", middleware.RequireRole("admin"), h.ReturnLoan)
+	loansPr.POST("/:id/renew", h.RenewLoan)
+	loansPr.GET("/:id", h.ReadLoan)
+
+	pr.GET("/api/users/:id/loans", middleware.RequireSelfOrAdmin(), h.ReadUserLoans)
+}
+
+func routersCopies(pr *gin.RouterGroup, pl *gin.RouterGroup, h *copies.CopyHandler) {
+	copiesPr := pr.Group("/api/books/:id/copies")
+	copiesPl := pl.Group("/api/books/:id/copies")
+
+	copiesPr.POST("/", middleware.RequireRole("admin"), h.CreateCopy)
+	copiesPr.PUT("/:copyId", middleware.RequireRole("admin"), h.UpdateCopy)
+	copiesPr.DELETE("/:copyId", middleware.RequireRole("admin"), h.DeleteCopy)
+
+	copiesPl.GET("/", h.ReadCopies)
+	copiesPl.GET("/:copyId", h.ReadCopy)
+}
+
+func routersHolds(pr *gin.RouterGroup, h *holds.HoldHandler) {
+	holdsPr := pr.Group("/api/books/:id/holds")
+
+	holdsPr.POST("/", h.PlaceHold)
+	holdsPr.GET("/", middleware.RequireRole("admin"), h.ReadBookHolds)
+	holdsPr.DELETE("/:holdId", h.CancelHold)
+
+	pr.GET("/api/users/:id/holds", middleware.RequireSelfOrAdmin(), h.ReadUserHolds)
+}
+
+func routersFines(pr *gin.RouterGroup, h *fines.FineHandler) {
+	finesPr := pr.Group("/api/users/:id/fines")
+
+	finesPr.GET("/", middleware.RequireSelfOrAdmin(), h.ReadUserFines)
+	finesPr.POST("/", middleware.RequireRole("admin"), h.CreateFineEntry)
+}
+
+func routersCovers(pr *gin.RouterGroup, pl *gin.RouterGroup, h *covers.CoverHandler) {
+	coversPr := pr.Group("/api/books/:id/cover")
+	coversPl := pl.Group("/api/books/:id/cover")
+
+	coversPr.PUT("", middleware.RequireRole("admin"), h.UploadCover)
+	coversPr.DELETE("", middleware.RequireRole("admin"), h.DeleteCover)
+
+	coversPl.GET("", h.ReadCover)
+}
@@ -0,0 +1,57 @@
+package series
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+var (
+	ErrSeriesNotFound = errors.New("série não encontrada")
+	ErrBookNotFound   = errors.New("livro não encontrado")
+	ErrVolumeTaken    = errors.New("volume já ocupado por outro livro da série")
+	ErrInvalidVolume  = errors.New("número do volume deve ser maior que zero")
+)
+
+type Series struct {
+	ID          int64
+	Name        string
+	Description string
+}
+
+// Volume é um livro da série com sua posição na ordem de leitura.
+type Volume struct {
+	BookID int64
+	Title  string
+	Number int
+}
+
+type SeriesCreator interface {
+	Create(ctx context.Context, s *Series) error
+	Update(ctx context.Context, s *Series) error
+	Delete(ctx context.Context, id int64) error
+	// SetVolume liga o livro à série no volume informado, substituindo
+	// qualquer série anterior do livro.
+	SetVolume(ctx context.Context, seriesID, bookID int64, number int) error
+	RemoveVolume(ctx context.Context, seriesID, bookID int64) error
+}
+
+type SeriesRead interface {
+	GetAll(ctx context.Context, p pagination.Params) ([]Series, pagination.Meta, error)
+	GetByID(ctx context.Context, id int64) (*Series, error)
+	GetVolumes(ctx context.Context, seriesID int64) ([]Volume, error)
+}
+
+type ISeriesRepository interface {
+	SeriesCreator
+	SeriesRead
+}
+
+func (s *Series) Validate() error {
+	if s.Name == "" {
+		return errors.New("nome invalido")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package series
+
+// @Description Dados para adicionar uma série
+type SeriesRequest struct {
+	Name        string `json:"name" binding:"required" example:"Fundação"`
+	Description string `json:"description" example:"Ciclo de ficção científica"`
+}
+
+// @Description Livro a ser incluído na série e o número do volume
+type VolumeRequest struct {
+	BookID int64 `json:"book_id" binding:"required" example:"1"`
+	Volume int   `json:"volume" binding:"required" example:"1"`
+}
+
+type SeriesResponse struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type VolumeResponse struct {
+	BookID int64  `json:"book_id"`
+	Title  string `json:"title"`
+	Volume int    `json:"volume"`
+}
+
+func ToResponse(s *Series) SeriesResponse {
+	return SeriesResponse(*s)
+}
+
+func ToVolumeResponse(v *Volume) VolumeResponse {
+	return VolumeResponse{
+		BookID: v.BookID,
+		Title:  v.Title,
+		Volume: v.Number,
+	}
+}
@@ -0,0 +1,325 @@
+package series
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SeriesHandler struct {
+	svc    SeriesService
+	logApp *zap.Logger
+}
+
+func NewSeriesHandler(svc SeriesService, log *zap.Logger) *SeriesHandler {
+	return &SeriesHandler{svc: svc, logApp: log}
+}
+
+func seriesError(err error) error {
+	switch {
+	case errors.Is(err, ErrSeriesNotFound), errors.Is(err, ErrBookNotFound):
+		return middleware.NotFound
+	case errors.Is(err, ErrInvalidVolume):
+		return middleware.BadRequest.Messager(err.Error())
+	case errors.Is(err, ErrVolumeTaken):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Cria uma nova série
+// @Description Recebe um objeto JSON SeriesRequest e salva a série no banco de dados.
+// @Tags series
+// @Accept  json
+// @Produce json
+// @Param   series body SeriesRequest true "Dados da nova série"
+// @Success 201 {object} SeriesResponse "Série criada com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/series [post]
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	h.logApp.Info("Rota de criar série")
+
+	var dto SeriesRequest
+
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	series := &Series{
+		Name:        dto.Name,
+		Description: dto.Description,
+	}
+
+	if err := h.svc.Create(c.Request.Context(), series); err != nil {
+		h.logApp.Error("falha ao criar série", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(series))
+}
+
+// @Summary Listar séries
+// @Description Retorna uma lista de séries
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 10, máximo 100)"
+// @Param sort query string false "Campo de ordenação" Enums(id, name)
+// @Param order query string false "Direção da ordenação" Enums(asc, desc)
+// @Param cursor query string false "Cursor de next_cursor ou prev_cursor; substitui page, sort e order"
+// @Success 200 {object} pagination.Page[SeriesResponse]
+// @Failure 400 {object} middleware.APIError "Paginação ou ordenação inválida"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/series [get]
+func (h *SeriesHandler) ReadSeriesAll(c *gin.Context) {
+	h.logApp.Info("Rota de obter séries")
+
+	params, err := pagination.Parse(c.Request.URL.Query(), SeriesSorts)
+	if err != nil {
+		h.logApp.Error("falha ao ler paginação", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	seriesAll, meta, err := h.svc.GetAll(c.Request.Context(), params)
+	if err != nil {
+		h.logApp.Error("falha ao obter séries", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	response := make([]SeriesResponse, 0, len(seriesAll))
+	for _, s := range seriesAll {
+		response = append(response, ToResponse(&s))
+	}
+
+	c.JSON(http.StatusOK, pagination.NewPage(response, meta, params))
+}
+
+// @Summary Obter série
+// @Description Retorna uma série
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Recebe o id da série"
+// @Success 200 {object} SeriesResponse
+// @Failure 404 {object} middleware.APIError "Série não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/series/{id} [get]
+func (h *SeriesHandler) ReadSeries(c *gin.Context) {
+	h.logApp.Info("Rota de obter série")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	series, err := h.svc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao obter série", zap.Error(err))
+		_ = c.Error(seriesError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(series))
+}
+
+// @Summary Livros da série
+// @Description Retorna os livros da série na ordem dos volumes.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Recebe o id da série"
+// @Success 200 {array} VolumeResponse
+// @Failure 404 {object} middleware.APIError "Série não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno"
+// @Router /public/api/series/{id}/books [get]
+func (h *SeriesHandler) ReadSeriesBooks(c *gin.Context) {
+	h.logApp.Info("Rota de obter livros da série")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	volumes, err := h.svc.GetVolumes(c.Request.Context(), id)
+	if err != nil {
+		h.logApp.Error("falha ao obter livros da série", zap.Error(err))
+		_ = c.Error(seriesError(err))
+		return
+	}
+
+	response := make([]VolumeResponse, 0, len(volumes))
+	for _, v := range volumes {
+		response = append(response, ToVolumeResponse(&v))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Atualiza uma série
+// @Description Recebe um objeto JSON SeriesRequest e atualiza a série no banco de dados.
+// @Tags series
+// @Accept  json
+// @Produce json
+// @Param id path int true "Recebe o id da série"
+// @Param   series body SeriesRequest true "Dados da série a ser atualizada"
+// @Success 204  "Série atualizada com sucesso"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 404 {object} middleware.APIError "Série não encontrada"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Security ApiKeyAuth
+// @Router /api/series/{id} [put]
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	h.logApp.Info("Rota de atualizar série")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	var dto SeriesRequest
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	series := &Series{
+		ID:          id,
+		Name:        dto.Name,
+		Description: dto.Description,
+	}
+
+	if err := h.svc.Update(c.Request.Context(), series); err != nil {
+		h.logApp.Error("erro ao atualizar série", zap.Error(err))
+		_ = c.Error(seriesError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Exclui uma série pelo ID
+// @Description Exclui uma série; os livros dela continuam no acervo, sem série.
+// @Tags series
+// @Accept  json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param   id path int true "ID da série a ser excluída"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (ID com formato incorreto)"
+// @Failure 404 {object} middleware.APIError "Série não encontrada"
+// @Router /api/series/{id} [delete]
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	h.logApp.Info("Rota de apagar série")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
+		h.logApp.Error("falha ao apagar série", zap.Error(err))
+		_ = c.Error(seriesError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Inclui um livro na série
+// @Description Liga o livro à série no volume informado. Um livro pertence a uma série só; incluir em outra move o livro.
+// @Tags series
+// @Accept  json
+// @Produce json
+// @Param id path int true "Recebe o id da série"
+// @Param   volume body VolumeRequest true "Livro e número do volume"
+// @Success 204 "Livro incluído na série"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida"
+// @Failure 404 {object} middleware.APIError "Série ou livro não encontrado"
+// @Failure 409 {object} middleware.APIError "Volume já ocupado"
+// @Security ApiKeyAuth
+// @Router /api/series/{id}/books [post]
+func (h *SeriesHandler) AddBook(c *gin.Context) {
+	h.logApp.Info("Rota de incluir livro na série")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	var dto VolumeRequest
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	if err := h.svc.SetVolume(c.Request.Context(), id, dto.BookID, dto.Volume); err != nil {
+		h.logApp.Error("falha ao incluir livro na série", zap.Error(err))
+		_ = c.Error(seriesError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Remove um livro da série
+// @Description Desliga o livro da série; o livro continua no acervo.
+// @Tags series
+// @Accept  json
+// @Produce json
+// @Param id path int true "Recebe o id da série"
+// @Param bookId path int true "ID do livro"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "ID inválido"
+// @Failure 404 {object} middleware.APIError "Livro não pertence à série"
+// @Security ApiKeyAuth
+// @Router /api/series/{id}/books/{bookId} [delete]
+func (h *SeriesHandler) RemoveBook(c *gin.Context) {
+	h.logApp.Info("Rota de remover livro da série")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	bookID, err := middleware.GetIdParamByName(c, "bookId")
+	if err != nil {
+		h.logApp.Error("falha ao verificar id do livro", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.svc.RemoveVolume(c.Request.Context(), id, bookID); err != nil {
+		h.logApp.Error("falha ao remover livro da série", zap.Error(err))
+		_ = c.Error(seriesError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,225 @@
+package series
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+type SeriesRepository struct {
+	db *sql.DB
+}
+
+func NewSeriesRepository(db *sql.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+func (r *SeriesRepository) Create(ctx context.Context, s *Series) error {
+	result, err := r.db.ExecContext(ctx, "INSERT INTO series (name, description) VALUES (?, ?)", s.Name, s.Description)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	s.ID = id
+	return nil
+}
+
+// SeriesSorts são os campos aceitos em sort na listagem de séries.
+var SeriesSorts = pagination.Sorts{
+	Fields:  map[string]string{"id": "id", "name": "name"},
+	Default: "name",
+	Key:     "id",
+}
+
+func (r *SeriesRepository) GetAll(ctx context.Context, p pagination.Params) ([]Series, pagination.Meta, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM series").Scan(&total); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	seek, args := p.Seek(SeriesSorts)
+	if seek != "" {
+		seek = " WHERE " + seek
+	}
+
+	query := "SELECT id, name, description, " + SeriesSorts.Column(p) + " FROM series" + seek + p.OrderBy(SeriesSorts) + " LIMIT ? OFFSET ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, p.Limit(), p.Offset())...)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	defer rows.Close()
+
+	var seriesAll []Series
+	var positions []pagination.Row
+
+	for rows.Next() {
+		var s Series
+		var position pagination.Row
+		if err := rows.Scan(&s.ID, &s.Name, &s.Description, &position.Value); err != nil {
+			return nil, pagination.Meta{}, err
+		}
+		position.Key = s.ID
+
+		seriesAll = append(seriesAll, s)
+		positions = append(positions, position)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	seriesAll, meta := pagination.Window(p, total, seriesAll, positions)
+	return seriesAll, meta, nil
+}
+
+func (r *SeriesRepository) GetByID(ctx context.Context, id int64) (*Series, error) {
+	var s Series
+
+	err := r.db.QueryRowContext(ctx, "SELECT id, name, description FROM series WHERE id = ?", id).
+		Scan(&s.ID, &s.Name, &s.Description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSeriesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SeriesRepository) GetVolumes(ctx context.Context, seriesID int64) ([]Volume, error) {
+	query := `SELECT id, title, series_volume FROM books
+		WHERE series_id = ? ORDER BY series_volume ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	volumes := []Volume{}
+
+	for rows.Next() {
+		var v Volume
+		if err := rows.Scan(&v.BookID, &v.Title, &v.Number); err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, v)
+	}
+	return volumes, rows.Err()
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, s *Series) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE series SET name = ?, description = ? WHERE id = ?",
+		s.Name, s.Description, s.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+// Delete remove a série e desliga os livros dela, que continuam no acervo.
+func (r *SeriesRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE books SET series_id = NULL, series_volume = NULL WHERE series_id = ?", id); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM series WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSeriesNotFound
+	}
+	return tx.Commit()
+}
+
+func (r *SeriesRepository) SetVolume(ctx context.Context, seriesID, bookID int64, number int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM series WHERE id = ?", seriesID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return ErrSeriesNotFound
+	}
+
+	var taken int
+	query := "SELECT COUNT(*) FROM books WHERE series_id = ? AND series_volume = ? AND id <> ?"
+	if err := tx.QueryRowContext(ctx, query, seriesID, number, bookID).Scan(&taken); err != nil {
+		return err
+	}
+	if taken > 0 {
+		return ErrVolumeTaken
+	}
+
+	result, err := tx.ExecContext(ctx, "UPDATE books SET series_id = ?, series_volume = ? WHERE id = ?", seriesID, number, bookID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBookNotFound
+	}
+	return tx.Commit()
+}
+
+func (r *SeriesRepository) RemoveVolume(ctx context.Context, seriesID, bookID int64) error {
+	query := "UPDATE books SET series_id = NULL, series_volume = NULL WHERE id = ? AND series_id = ?"
+
+	result, err := r.db.ExecContext(ctx, query, bookID, seriesID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBookNotFound
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package series_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/authors"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/books"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/categories"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/series"
+)
+
+func seedBooks(t *testing.T, db *sql.DB, titles ...string) []int64 {
+	ctx := context.Background()
+
+	authorRepo := authors.NewAuthorsRepository(db)
+	categoryRepo := categories.NewCategoryRepository(db)
+	bookRepo := books.NewBookRepository(db)
+
+	author := &authors.Authors{Name: "Autor X"}
+	if err := authorRepo.Create(ctx, author); err != nil {
+		t.Fatalf("author: %v", err)
+	}
+
+	cat := &categories.Category{Name: "Ficção"}
+	if err := categoryRepo.Create(ctx, cat); err != nil {
+		t.Fatalf("category: %v", err)
+	}
+
+	var ids []int64
+	for _, title := range titles {
+		b := &books.Books{Title: title, Description: "D", Content: "C", AuthorID: author.ID}
+		if err := bookRepo.Create(ctx, b); err != nil {
+			t.Fatalf("book: %v", err)
+		}
+		if err := bookRepo.RelationBookCategory(ctx, b.ID, cat.ID); err != nil {
+			t.Fatalf("relation: %v", err)
+		}
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func TestSeriesRepository_CRUD(t *testing.T) {
+	db := database.SetupTestDB()
+	r := series.NewSeriesRepository(db)
+	ctx := context.Background()
+
+	s := &series.Series{Name: "Fundação", Description: "Ciclo"}
+	if err := r.Create(ctx, s); err != nil {
+		t.Fatalf("não esperava erro ao criar: %v", err)
+	}
+
+	s.Name = "Trilogia da Fundação"
+	if err := r.Update(ctx, s); err != nil {
+		t.Fatalf("não esperava erro ao atualizar: %v", err)
+	}
+
+	found, err := r.GetByID(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("erro ao buscar: %v", err)
+	}
+	if found.Name != "Trilogia da Fundação" || found.Description != "Ciclo" {
+		t.Errorf("série inesperada: %+v", found)
+	}
+
+	if err := r.Delete(ctx, s.ID); err != nil {
+		t.Fatalf("não esperava erro ao apagar: %v", err)
+	}
+
+	if _, err := r.GetByID(ctx, s.ID); !errors.Is(err, series.ErrSeriesNotFound) {
+		t.Errorf("esperava ErrSeriesNotFound, recebeu %v", err)
+	}
+}
+
+func TestSeriesRepository_Volumes(t *testing.T) {
+	db := database.SetupTestDB()
+	r := series.NewSeriesRepository(db)
+	bookRepo := books.NewBookRepository(db)
+	ctx := context.Background()
+
+	ids := seedBooks(t, db, "Fundação", "Fundação e Império", "Segunda Fundação")
+
+	s := &series.Series{Name: "Fundação"}
+	if err := r.Create(ctx, s); err != nil {
+		t.Fatalf("série: %v", err)
+	}
+
+	// volumes fora da ordem de cadastro
+	for i, volume := range []int{1, 3, 2} {
+		if err := r.SetVolume(ctx, s.ID, ids[i], volume); err != nil {
+			t.Fatalf("volume %d: %v", volume, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		series  int64
+		book    int64
+		volume  int
+		wantErr error
+	}{
+		{name: "volume ocupado", series: s.ID, book: ids[0], volume: 2, wantErr: series.ErrVolumeTaken},
+		{name: "mesmo livro no mesmo volume", series: s.ID, book: ids[0], volume: 1},
+		{name: "série inexistente", series: 99, book: ids[0], volume: 5, wantErr: series.ErrSeriesNotFound},
+		{name: "livro inexistente", series: s.ID, book: 99, volume: 5, wantErr: series.ErrBookNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.SetVolume(ctx, tt.series, tt.book, tt.volume)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("esperava %v, recebeu %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	volumes, err := r.GetVolumes(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("erro ao listar volumes: %v", err)
+	}
+
+	want := []int64{ids[0], ids[2], ids[1]}
+	if len(volumes) != len(want) {
+		t.Fatalf("esperava %d volumes, recebeu %d", len(want), len(volumes))
+	}
+	for i, v := range volumes {
+		if v.BookID != want[i] || v.Number != i+1 {
+			t.Errorf("posição %d: esperava livro %d, recebeu %+v", i, want[i], v)
+		}
+	}
+
+	middle, err := bookRepo.GetById(ctx, ids[2])
+	if err != nil {
+		t.Fatalf("erro ao buscar livro: %v", err)
+	}
+	if middle.SeriesID != s.ID || middle.SeriesVolume != 2 {
+		t.Errorf("série inesperada no livro: %+v", middle)
+	}
+	if middle.PreviousVolumeID != ids[0] || middle.NextVolumeID != ids[1] {
+		t.Errorf("vizinhos inesperados: anterior %d, próximo %d", middle.PreviousVolumeID, middle.NextVolumeID)
+	}
+
+	if err := r.RemoveVolume(ctx, s.ID, ids[2]); err != nil {
+		t.Fatalf("erro ao remover volume: %v", err)
+	}
+
+	first, err := bookRepo.GetById(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("erro ao buscar livro: %v", err)
+	}
+	if first.PreviousVolumeID != 0 || first.NextVolumeID != ids[1] {
+		t.Errorf("vizinhos após remoção: anterior %d, próximo %d", first.PreviousVolumeID, first.NextVolumeID)
+	}
+
+	if err := r.Delete(ctx, s.ID); err != nil {
+		t.Fatalf("erro ao apagar série: %v", err)
+	}
+
+	orphan, err := bookRepo.GetById(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("erro ao buscar livro: %v", err)
+	}
+	if orphan.SeriesID != 0 || orphan.SeriesVolume != 0 {
+		t.Errorf("livro continuou na série apagada: %+v", orphan)
+	}
+}
@@ -0,0 +1,69 @@
+package series
+
+import (
+	"context"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+type SeriesService interface {
+	ISeriesRepository
+}
+
+type serviceSeries struct {
+	repo ISeriesRepository
+}
+
+func NewSeriesService(repo ISeriesRepository) *serviceSeries {
+	return &serviceSeries{repo: repo}
+}
+
+func (s *serviceSeries) Create(ctx context.Context, se *Series) error {
+	if err := se.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, se)
+}
+
+func (s *serviceSeries) GetAll(ctx context.Context, p pagination.Params) ([]Series, pagination.Meta, error) {
+	return s.repo.GetAll(ctx, p)
+}
+
+func (s *serviceSeries) GetByID(ctx context.Context, id int64) (*Series, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetVolumes confere a existência da série para diferenciar série vazia de
+// série inexistente.
+func (s *serviceSeries) GetVolumes(ctx context.Context, seriesID int64) ([]Volume, error) {
+	if _, err := s.repo.GetByID(ctx, seriesID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetVolumes(ctx, seriesID)
+}
+
+func (s *serviceSeries) Update(ctx context.Context, se *Series) error {
+	if err := se.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, se)
+}
+
+func (s *serviceSeries) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *serviceSeries) SetVolume(ctx context.Context, seriesID, bookID int64, number int) error {
+	if number <= 0 {
+		return ErrInvalidVolume
+	}
+
+	return s.repo.SetVolume(ctx, seriesID, bookID, number)
+}
+
+func (s *serviceSeries) RemoveVolume(ctx context.Context, seriesID, bookID int64) error {
+	return s.repo.RemoveVolume(ctx, seriesID, bookID)
+}
@@ -0,0 +1,50 @@
+package sessions
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionRepo struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepo) Create(ctx context.Context, t *RefreshToken) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) Rotate(ctx context.Context, usedID int64, next *RefreshToken, now time.Time) error {
+	args := m.Called(ctx, usedID, next, now)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) RevokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	args := m.Called(ctx, familyID, now)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RefreshToken), args.Error(1)
+}
+
+func (m *MockSessionRepo) RevokeAccessToken(ctx context.Context, t AccessToken) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) RevokeUser(ctx context.Context, userID int64, cutoff Cutoff) error {
+	args := m.Called(ctx, userID, cutoff)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) Revocations(ctx context.Context, now time.Time) (RevocationList, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(RevocationList), args.Error(1)
+}
@@ -0,0 +1,140 @@
+package sessions
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval é de quanto em quanto tempo o cache de revogações é
+// recarregado do banco, para enxergar o que outras instâncias revogaram.
+const DefaultReloadInterval = time.Minute
+
+// AccessToken identifica um token de acesso emitido, para revogá-lo.
+type AccessToken struct {
+	ID        string
+	UserID    int64
+	SessionID string
+	ExpiresAt time.Time
+}
+
+// Cutoff invalida os tokens do usuário emitidos até Before, inclusive, menos
+// os da sessão KeepSessionID, aberta junto com o corte. O iat tem precisão de
+// segundos, então o segundo do corte inteiro fica revogado.
+type Cutoff struct {
+	Before        time.Time
+	KeepSessionID string
+}
+
+// Covers informa se o corte alcança um token emitido em issuedAt na sessão.
+func (c Cutoff) Covers(sessionID string, issuedAt time.Time) bool {
+	if c.KeepSessionID != "" && sessionID == c.KeepSessionID {
+		return false
+	}
+	return !issuedAt.After(c.Before)
+}
+
+// RevocationList é o que está revogado e ainda importa: tokens de acesso que
+// não expiraram e, por usuário, o corte dos tokens emitidos até um momento.
+type RevocationList struct {
+	Tokens  map[string]time.Time
+	Cutoffs map[int64]Cutoff
+}
+
+// Revocations guarda as revogações no banco e responde a AuthMiddleware pela
+// cópia em memória, sem consultar o banco a cada requisição. As revogações
+// feitas por esta instância entram na cópia na hora; as de outras instâncias,
+// na recarga seguinte.
+type Revocations struct {
+	repo     ISessionRepository
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	list     RevocationList
+	loadedAt time.Time
+}
+
+func NewRevocations(repo ISessionRepository, interval time.Duration) *Revocations {
+	return &Revocations{repo: repo, interval: interval, now: time.Now}
+}
+
+// IsRevoked atende middleware.RevocationChecker.
+func (r *Revocations) IsRevoked(ctx context.Context, tokenID, sessionID string, userID int64, issuedAt time.Time) (bool, error) {
+	if err := r.reload(ctx); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.list.Tokens[tokenID]; ok {
+		return true, nil
+	}
+
+	cutoff, ok := r.list.Cutoffs[userID]
+	return ok && cutoff.Covers(sessionID, issuedAt), nil
+}
+
+// Revoke revoga um único token de acesso até ele expirar.
+func (r *Revocations) Revoke(ctx context.Context, t AccessToken) error {
+	if err := r.repo.RevokeAccessToken(ctx, t); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.list.Tokens != nil {
+		r.list.Tokens[t.ID] = t.ExpiresAt
+	}
+	return nil
+}
+
+// RevokeUser revoga todos os tokens de acesso e refresh tokens que o usuário
+// recebeu até agora, menos os da sessão keepSessionID, se informada.
+func (r *Revocations) RevokeUser(ctx context.Context, userID int64, keepSessionID string) error {
+	cutoff := Cutoff{Before: r.now().Truncate(time.Second), KeepSessionID: keepSessionID}
+
+	if err := r.repo.RevokeUser(ctx, userID, cutoff); err != nil {
+		return err
+	}
+
+	r.Record(userID, cutoff)
+	return nil
+}
+
+// Record leva para a cópia em memória um corte que outro repositório gravou
+// com InsertCutoff na própria transação, já confirmada.
+func (r *Revocations) Record(userID int64, cutoff Cutoff) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.list.Cutoffs != nil {
+		r.list.Cutoffs[userID] = cutoff
+	}
+}
+
+func (r *Revocations) reload(ctx context.Context) error {
+	now := r.now()
+
+	r.mu.RLock()
+	fresh := r.list.Tokens != nil && now.Sub(r.loadedAt) < r.interval
+	r.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	list, err := r.repo.Revocations(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.list = list
+	r.loadedAt = now
+	return nil
+}
@@ -0,0 +1,74 @@
+package sessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevocations_IsRevoked(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 500, time.UTC)
+	cutoff := now.Truncate(time.Second)
+
+	mockRepo := new(MockSessionRepo)
+	mockRepo.On("Revocations", mock.Anything, now).Return(RevocationList{
+		Tokens:  map[string]time.Time{"revogado": now.Add(time.Hour)},
+		Cutoffs: map[int64]Cutoff{},
+	}, nil).Once()
+	mockRepo.On("RevokeAccessToken", mock.Anything, AccessToken{ID: "sair", UserID: 7}).Return(nil)
+	mockRepo.On("RevokeUser", mock.Anything, int64(8), Cutoff{Before: cutoff}).Return(nil)
+	mockRepo.On("RevokeUser", mock.Anything, int64(9), Cutoff{Before: cutoff, KeepSessionID: "nova"}).Return(nil)
+
+	r := NewRevocations(mockRepo, DefaultReloadInterval)
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		before    func()
+		tokenID   string
+		sessionID string
+		userID    int64
+		issuedAt  time.Time
+		want      bool
+	}{
+		{name: "token revogado", tokenID: "revogado", userID: 7, issuedAt: now, want: true},
+		{name: "token valido", tokenID: "outro", userID: 7, issuedAt: now},
+		{
+			name:    "revogado por esta instância",
+			before:  func() { assert.NoError(t, r.Revoke(ctx, AccessToken{ID: "sair", UserID: 7})) },
+			tokenID: "sair", userID: 7, issuedAt: now, want: true,
+		},
+		{
+			name:    "emitido antes do corte do usuário",
+			before:  func() { assert.NoError(t, r.RevokeUser(ctx, 8, "")) },
+			tokenID: "antigo", userID: 8, issuedAt: cutoff.Add(-time.Second), want: true,
+		},
+		{name: "emitido no segundo do corte", tokenID: "mesmo segundo", userID: 8, issuedAt: cutoff, want: true},
+		{name: "emitido depois do corte", tokenID: "depois", userID: 8, issuedAt: cutoff.Add(time.Second)},
+		{
+			name:    "sessão mantida pelo corte",
+			before:  func() { assert.NoError(t, r.RevokeUser(ctx, 9, "nova")) },
+			tokenID: "mantido", sessionID: "nova", userID: 9, issuedAt: cutoff,
+		},
+		{name: "outra sessão no segundo do corte", tokenID: "outra", sessionID: "velha", userID: 9, issuedAt: cutoff, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.before != nil {
+				tt.before()
+			}
+
+			got, err := r.IsRevoked(ctx, tt.tokenID, tt.sessionID, tt.userID, tt.issuedAt)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// dentro do intervalo de recarga o banco é lido uma única vez
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,101 @@
+package sessions
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultRefreshTTL é a validade de cada refresh token. Como cada renovação
+// emite um token novo, o usuário só precisa entrar de novo depois de passar
+// esse tempo sem usar a API.
+const DefaultRefreshTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidToken = errors.New("refresh token invalido ou expirado")
+	ErrTokenReused  = errors.New("refresh token já utilizado; a sessão foi encerrada")
+)
+
+// RefreshToken é um refresh token guardado no servidor; do token em si só o
+// hash é gravado. Cada login abre uma família, e cada renovação troca o token
+// usado por outro da mesma família. Apresentar de novo um token já trocado
+// indica que ele vazou, e a família inteira é revogada.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+type SessionCreator interface {
+	Create(ctx context.Context, t *RefreshToken) error
+	// Rotate marca o token como usado e grava o sucessor na mesma transação.
+	// Devolve ErrTokenReused se outro pedido usou o token antes.
+	Rotate(ctx context.Context, usedID int64, next *RefreshToken, now time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, now time.Time) error
+	RevokeAccessToken(ctx context.Context, t AccessToken) error
+	// RevokeUser encerra os refresh tokens do usuário, menos os da sessão
+	// mantida pelo corte, e grava o corte dos tokens de acesso.
+	RevokeUser(ctx context.Context, userID int64, cutoff Cutoff) error
+}
+
+type SessionRead interface {
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	// Revocations descarta os tokens de acesso já expirados e devolve as
+	// revogações restantes.
+	Revocations(ctx context.Context, now time.Time) (RevocationList, error)
+}
+
+type ISessionRepository interface {
+	SessionCreator
+	SessionRead
+}
+
+// Usable informa se o token ainda pode ser trocado por outro.
+func (t *RefreshToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// RefreshTTLFromEnv lê REFRESH_TOKEN_TTL_DAYS, usando o padrão para valores
+// ausentes ou inválidos.
+func RefreshTTLFromEnv() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("REFRESH_TOKEN_TTL_DAYS"))
+	if err != nil || days <= 0 {
+		return DefaultRefreshTTL
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// HashToken é o que vai ao banco no lugar do token.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewToken gera um token aleatório para enviar ao cliente; ao banco vai só
+// HashToken dele.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func newFamilyID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,198 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type SessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, t *RefreshToken) error {
+	return insertToken(ctx, r.db, t)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertToken(ctx context.Context, db execer, t *RefreshToken) error {
+	query := "INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES (?, ?, ?, ?)"
+
+	result, err := db.ExecContext(ctx, query, t.UserID, t.FamilyID, t.TokenHash, t.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	t.ID = id
+	return nil
+}
+
+func (r *SessionRepository) GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	query := `SELECT id, user_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM refresh_tokens WHERE token_hash = ?`
+
+	var t RefreshToken
+	var usedAt, revokedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &usedAt, &revokedAt, &t.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		t.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+
+	return &t, nil
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, usedID int64, next *RefreshToken, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// a condição em used_at faz de dois pedidos simultâneos com o mesmo token
+	// um só vencedor
+	result, err := tx.ExecContext(ctx,
+		"UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL", now, usedID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrTokenReused
+	}
+
+	if err := insertToken(ctx, tx, next); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SessionRepository) RevokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", now, familyID)
+	return err
+}
+
+func (r *SessionRepository) RevokeAccessToken(ctx context.Context, t AccessToken) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO revoked_tokens (jti, user_id, expires_at) VALUES (?, ?, ?)", t.ID, t.UserID, t.ExpiresAt)
+	return err
+}
+
+func (r *SessionRepository) RevokeUser(ctx context.Context, userID int64, cutoff Cutoff) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := InsertCutoff(ctx, tx, userID, cutoff); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Execer é satisfeito por *sql.DB e *sql.Tx, permitindo que outros
+// repositórios encerrem as sessões dentro das próprias transações.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// InsertCutoff encerra os refresh tokens do usuário, menos os da sessão
+// mantida, e grava o corte dos tokens de acesso no lugar do anterior.
+func InsertCutoff(ctx context.Context, q Execer, userID int64, cutoff Cutoff) error {
+	if _, err := q.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND family_id <> ? AND revoked_at IS NULL",
+		cutoff.Before, userID, cutoff.KeepSessionID); err != nil {
+		return err
+	}
+
+	if _, err := q.ExecContext(ctx, "DELETE FROM token_cutoffs WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+
+	_, err := q.ExecContext(ctx,
+		"INSERT INTO token_cutoffs (user_id, revoked_before, kept_session_id) VALUES (?, ?, ?)",
+		userID, cutoff.Before, cutoff.KeepSessionID)
+	return err
+}
+
+func (r *SessionRepository) Revocations(ctx context.Context, now time.Time) (RevocationList, error) {
+	list := RevocationList{Tokens: map[string]time.Time{}, Cutoffs: map[int64]Cutoff{}}
+
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM revoked_tokens WHERE expires_at <= ?", now); err != nil {
+		return RevocationList{}, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT jti, expires_at FROM revoked_tokens")
+	if err != nil {
+		return RevocationList{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var expiresAt time.Time
+		if err := rows.Scan(&id, &expiresAt); err != nil {
+			return RevocationList{}, err
+		}
+		list.Tokens[id] = expiresAt
+	}
+	if err := rows.Err(); err != nil {
+		return RevocationList{}, err
+	}
+	rows.Close()
+
+	rows, err = r.db.QueryContext(ctx, "SELECT user_id, revoked_before, kept_session_id FROM token_cutoffs")
+	if err != nil {
+		return RevocationList{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		var cutoff Cutoff
+		if err := rows.Scan(&userID, &cutoff.Before, &cutoff.KeepSessionID); err != nil {
+			return RevocationList{}, err
+		}
+		list.Cutoffs[userID] = cutoff
+	}
+
+	return list, rows.Err()
+}
@@ -0,0 +1,140 @@
+package sessions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func TestSessionRepository_Rotate(t *testing.T) {
+	db := database.SetupTestDB()
+	repo := sessions.NewSessionRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if err := users.NewUsersRepository(db).Create(ctx, &users.Users{Name: "Leitor", Email: "um@email.com", Username: "um", Password: "hash", Role: users.User}); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+
+	first := &sessions.RefreshToken{UserID: 1, FamilyID: "familia", TokenHash: sessions.HashToken("primeiro"), ExpiresAt: now.Add(time.Hour)}
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("erro ao criar token: %v", err)
+	}
+
+	found, err := repo.GetByHash(ctx, sessions.HashToken("primeiro"))
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if found.ID != first.ID || found.FamilyID != "familia" || !found.Usable(now) {
+		t.Fatalf("token inesperado: %+v", found)
+	}
+
+	if _, err := repo.GetByHash(ctx, sessions.HashToken("outro")); !errors.Is(err, sessions.ErrInvalidToken) {
+		t.Errorf("esperava ErrInvalidToken, recebeu %v", err)
+	}
+
+	second := &sessions.RefreshToken{UserID: 1, FamilyID: "familia", TokenHash: sessions.HashToken("segundo"), ExpiresAt: now.Add(time.Hour)}
+	if err := repo.Rotate(ctx, first.ID, second, now); err != nil {
+		t.Fatalf("erro ao trocar token: %v", err)
+	}
+
+	third := &sessions.RefreshToken{UserID: 1, FamilyID: "familia", TokenHash: sessions.HashToken("terceiro"), ExpiresAt: now.Add(time.Hour)}
+	if err := repo.Rotate(ctx, first.ID, third, now); !errors.Is(err, sessions.ErrTokenReused) {
+		t.Fatalf("esperava ErrTokenReused, recebeu %v", err)
+	}
+	if _, err := repo.GetByHash(ctx, third.TokenHash); !errors.Is(err, sessions.ErrInvalidToken) {
+		t.Errorf("o sucessor da troca recusada não deveria ter sido gravado: %v", err)
+	}
+
+	used, err := repo.GetByHash(ctx, first.TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if used.UsedAt == nil || used.Usable(now) {
+		t.Errorf("esperava token marcado como usado: %+v", used)
+	}
+
+	if err := repo.RevokeFamily(ctx, "familia", now); err != nil {
+		t.Fatalf("erro ao revogar família: %v", err)
+	}
+	revoked, err := repo.GetByHash(ctx, second.TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if revoked.RevokedAt == nil || revoked.Usable(now) {
+		t.Errorf("esperava token revogado: %+v", revoked)
+	}
+}
+
+func TestSessionRepository_Revocations(t *testing.T) {
+	db := database.SetupTestDB()
+	repo := sessions.NewSessionRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if err := users.NewUsersRepository(db).Create(ctx, &users.Users{Name: "Leitor", Email: "um@email.com", Username: "um", Password: "hash", Role: users.User}); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+
+	refresh := &sessions.RefreshToken{UserID: 1, FamilyID: "familia", TokenHash: sessions.HashToken("refresh"), ExpiresAt: now.Add(time.Hour)}
+	if err := repo.Create(ctx, refresh); err != nil {
+		t.Fatalf("erro ao criar token: %v", err)
+	}
+
+	for _, tok := range []sessions.AccessToken{
+		{ID: "valendo", UserID: 1, ExpiresAt: now.Add(time.Hour)},
+		{ID: "expirado", UserID: 1, ExpiresAt: now.Add(-time.Minute)},
+	} {
+		if err := repo.RevokeAccessToken(ctx, tok); err != nil {
+			t.Fatalf("erro ao revogar token de acesso: %v", err)
+		}
+	}
+
+	if err := repo.RevokeUser(ctx, 1, sessions.Cutoff{Before: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("erro ao revogar usuário: %v", err)
+	}
+
+	// a segunda revogação substitui o corte da primeira e poupa a sessão
+	// aberta junto com ela
+	kept := &sessions.RefreshToken{UserID: 1, FamilyID: "mantida", TokenHash: sessions.HashToken("mantido"), ExpiresAt: now.Add(time.Hour)}
+	if err := repo.Create(ctx, kept); err != nil {
+		t.Fatalf("erro ao criar token: %v", err)
+	}
+
+	if err := repo.RevokeUser(ctx, 1, sessions.Cutoff{Before: now, KeepSessionID: "mantida"}); err != nil {
+		t.Fatalf("erro ao revogar usuário: %v", err)
+	}
+
+	list, err := repo.Revocations(ctx, now)
+	if err != nil {
+		t.Fatalf("erro ao carregar revogações: %v", err)
+	}
+
+	if _, ok := list.Tokens["valendo"]; !ok || len(list.Tokens) != 1 {
+		t.Errorf("esperava só o token ainda válido, recebeu %v", list.Tokens)
+	}
+	if cutoff, ok := list.Cutoffs[1]; !ok || !cutoff.Before.Equal(now) || cutoff.KeepSessionID != "mantida" {
+		t.Errorf("esperava corte em %v, recebeu %v", now, list.Cutoffs)
+	}
+
+	revoked, err := repo.GetByHash(ctx, refresh.TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if revoked.Usable(now) {
+		t.Errorf("esperava refresh token revogado: %+v", revoked)
+	}
+
+	survivor, err := repo.GetByHash(ctx, kept.TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if !survivor.Usable(now) {
+		t.Errorf("esperava o refresh token da sessão mantida válido: %+v", survivor)
+	}
+}
@@ -0,0 +1,140 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Grant é o resultado de um login ou renovação: o dono, a sessão (família de
+// refresh tokens) que vai no token de acesso e o refresh token novo.
+type Grant struct {
+	UserID       int64
+	SessionID    string
+	RefreshToken string
+}
+
+type SessionService interface {
+	// Issue abre uma família nova para o usuário e devolve o primeiro token.
+	Issue(ctx context.Context, userID int64) (*Grant, error)
+	// Refresh troca o token por outro da mesma família.
+	Refresh(ctx context.Context, token string) (*Grant, error)
+	// Logout revoga o token de acesso e encerra a sessão a que ele pertence.
+	Logout(ctx context.Context, t AccessToken) error
+	// LogoutAll encerra todas as sessões do usuário, em qualquer dispositivo.
+	LogoutAll(ctx context.Context, userID int64) error
+	// Reissue encerra todas as sessões do usuário e abre uma nova, a única
+	// que continua valendo.
+	Reissue(ctx context.Context, userID int64) (*Grant, error)
+}
+
+type serviceSession struct {
+	repo        ISessionRepository
+	revocations *Revocations
+	ttl         time.Duration
+	now         func() time.Time
+}
+
+func NewSessionService(repo ISessionRepository, revocations *Revocations, ttl time.Duration) *serviceSession {
+	return &serviceSession{repo: repo, revocations: revocations, ttl: ttl, now: time.Now}
+}
+
+func (s *serviceSession) Issue(ctx context.Context, userID int64) (*Grant, error) {
+	familyID, err := newFamilyID()
+	if err != nil {
+		return nil, err
+	}
+
+	token, t, err := s.newRefreshToken(userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	return &Grant{UserID: userID, SessionID: familyID, RefreshToken: token}, nil
+}
+
+func (s *serviceSession) Refresh(ctx context.Context, token string) (*Grant, error) {
+	now := s.now()
+
+	current, err := s.repo.GetByHash(ctx, HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if current.UsedAt != nil {
+		return nil, s.reused(ctx, current, now)
+	}
+
+	if !current.Usable(now) {
+		return nil, ErrInvalidToken
+	}
+
+	next, t, err := s.newRefreshToken(current.UserID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Rotate(ctx, current.ID, t, now)
+	if errors.Is(err, ErrTokenReused) {
+		return nil, s.reused(ctx, current, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Grant{UserID: current.UserID, SessionID: current.FamilyID, RefreshToken: next}, nil
+}
+
+func (s *serviceSession) Logout(ctx context.Context, t AccessToken) error {
+	if err := s.revocations.Revoke(ctx, t); err != nil {
+		return err
+	}
+
+	// tokens emitidos antes das sessões existirem não têm família
+	if t.SessionID == "" {
+		return nil
+	}
+	return s.repo.RevokeFamily(ctx, t.SessionID, s.now())
+}
+
+func (s *serviceSession) LogoutAll(ctx context.Context, userID int64) error {
+	return s.revocations.RevokeUser(ctx, userID, "")
+}
+
+func (s *serviceSession) Reissue(ctx context.Context, userID int64) (*Grant, error) {
+	grant, err := s.Issue(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.revocations.RevokeUser(ctx, userID, grant.SessionID); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// reused encerra a família de um token apresentado depois de trocado: quem
+// o apresentou pode não ser o dono, e o sucessor também não é mais confiável.
+func (s *serviceSession) reused(ctx context.Context, t *RefreshToken, now time.Time) error {
+	if err := s.repo.RevokeFamily(ctx, t.FamilyID, now); err != nil {
+		return err
+	}
+	return ErrTokenReused
+}
+
+func (s *serviceSession) newRefreshToken(userID int64, familyID string) (string, *RefreshToken, error) {
+	token, err := NewToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashToken(token),
+		ExpiresAt: s.now().Add(s.ttl),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package sessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_serviceSession_Refresh(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		current *RefreshToken
+		findErr error
+		rotate  bool
+		rotErr  error
+		revoke  bool
+		wantErr error
+	}{
+		{
+			name:    "sucesso",
+			current: &RefreshToken{ID: 1, UserID: 7, FamilyID: "f", ExpiresAt: now.Add(time.Hour)},
+			rotate:  true,
+		},
+		{
+			name:    "token desconhecido",
+			findErr: ErrInvalidToken,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "token expirado",
+			current: &RefreshToken{ID: 1, UserID: 7, FamilyID: "f", ExpiresAt: earlier},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "família revogada",
+			current: &RefreshToken{ID: 1, UserID: 7, FamilyID: "f", ExpiresAt: now.Add(time.Hour), RevokedAt: &earlier},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "token reutilizado",
+			current: &RefreshToken{ID: 1, UserID: 7, FamilyID: "f", ExpiresAt: now.Add(time.Hour), UsedAt: &earlier},
+			revoke:  true,
+			wantErr: ErrTokenReused,
+		},
+		{
+			name:    "usado por outro pedido ao mesmo tempo",
+			current: &RefreshToken{ID: 1, UserID: 7, FamilyID: "f", ExpiresAt: now.Add(time.Hour)},
+			rotate:  true,
+			rotErr:  ErrTokenReused,
+			revoke:  true,
+			wantErr: ErrTokenReused,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSessionRepo)
+			mockRepo.On("GetByHash", mock.Anything, HashToken("atual")).Return(tt.current, tt.findErr)
+
+			if tt.rotate {
+				mockRepo.On("Rotate", mock.Anything, int64(1), mock.MatchedBy(func(next *RefreshToken) bool {
+					return next.UserID == 7 && next.FamilyID == "f" && next.ExpiresAt.Equal(now.Add(DefaultRefreshTTL))
+				}), now).Return(tt.rotErr)
+			}
+			if tt.revoke {
+				mockRepo.On("RevokeFamily", mock.Anything, "f", now).Return(nil)
+			}
+
+			svc := NewSessionService(mockRepo, NewRevocations(mockRepo, DefaultReloadInterval), DefaultRefreshTTL)
+			svc.now = func() time.Time { return now }
+
+			grant, err := svc.Refresh(context.Background(), "atual")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, int64(7), grant.UserID)
+				assert.Equal(t, "f", grant.SessionID)
+				assert.NotEmpty(t, grant.RefreshToken)
+				assert.NotEqual(t, "atual", grant.RefreshToken)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_serviceSession_Reissue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 500, time.UTC)
+
+	var familyID string
+
+	mockRepo := new(MockSessionRepo)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(next *RefreshToken) bool {
+		familyID = next.FamilyID
+		return next.UserID == 7
+	})).Return(nil)
+	mockRepo.On("RevokeUser", mock.Anything, int64(7), mock.MatchedBy(func(c Cutoff) bool {
+		return c.Before.Equal(now.Truncate(time.Second)) && c.KeepSessionID == familyID
+	})).Return(nil)
+
+	revocations := NewRevocations(mockRepo, DefaultReloadInterval)
+	revocations.now = func() time.Time { return now }
+
+	svc := NewSessionService(mockRepo, revocations, DefaultRefreshTTL)
+	svc.now = func() time.Time { return now }
+
+	grant, err := svc.Reissue(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, familyID, grant.SessionID)
+	assert.NotEmpty(t, grant.RefreshToken)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,141 @@
+package textsearch
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions limita quantas alternativas de consulta são sugeridas.
+const maxSuggestions = 3
+
+// Distance calcula a distância de edição (Levenshtein) entre duas palavras:
+// o número de inserções, remoções e trocas de letra para ir de uma à outra.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// tolerance define quantos erros de digitação são aceitos pelo tamanho da
+// palavra; palavras curtas demais não são corrigidas.
+func tolerance(word string) int {
+	switch n := len([]rune(word)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Vocabulary conta as palavras conhecidas do acervo (títulos e autores).
+type Vocabulary map[string]int
+
+func (v Vocabulary) Add(text string) {
+	for _, w := range Words(text) {
+		v[w]++
+	}
+}
+
+type candidate struct {
+	word     string
+	distance int
+	count    int
+}
+
+// closest devolve as palavras do vocabulário mais próximas do termo, da menor
+// distância para a maior e, no empate, das mais frequentes para as menos.
+func (v Vocabulary) closest(term string) []candidate {
+	limit := tolerance(term)
+
+	var found []candidate
+	for word, count := range v {
+		if d := Distance(term, word); d <= limit {
+			found = append(found, candidate{word: word, distance: d, count: count})
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].distance != found[j].distance {
+			return found[i].distance < found[j].distance
+		}
+		if found[i].count != found[j].count {
+			return found[i].count > found[j].count
+		}
+		return found[i].word < found[j].word
+	})
+
+	if len(found) > maxSuggestions {
+		found = found[:maxSuggestions]
+	}
+	return found
+}
+
+// Suggest monta consultas corrigidas trocando cada termo desconhecido pela
+// palavra mais próxima do vocabulário. A primeira sugestão usa o melhor
+// candidato de cada termo; as seguintes, as alternativas. Devolve nil quando
+// todos os termos são conhecidos ou nenhum tem correção.
+func (v Vocabulary) Suggest(query string) []string {
+	terms := Words(query)
+
+	options := make([][]candidate, len(terms))
+	corrected := false
+	for i, term := range terms {
+		if _, ok := v[term]; ok {
+			continue
+		}
+
+		options[i] = v.closest(term)
+		if len(options[i]) > 0 {
+			corrected = true
+		}
+	}
+
+	if !corrected {
+		return nil
+	}
+
+	var suggestions []string
+	seen := make(map[string]bool)
+
+	for k := 0; k < maxSuggestions; k++ {
+		words := make([]string, len(terms))
+		for i, term := range terms {
+			switch {
+			case len(options[i]) == 0:
+				words[i] = term
+			case k < len(options[i]):
+				words[i] = options[i][k].word
+			default:
+				words[i] = options[i][0].word
+			}
+		}
+
+		suggestion := strings.Join(words, " ")
+		if !seen[suggestion] {
+			seen[suggestion] = true
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	return suggestions
+}
@@ -0,0 +1,60 @@
+package textsearch
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Column é uma coluna normalizada com Fold e a coluna de onde ela vem.
+type Column struct {
+	Table  string
+	Source string
+	Target string
+}
+
+// SearchColumns são as colunas normalizadas que os repositórios gravam.
+var SearchColumns = []Column{
+	{Table: "books", Source: "title", Target: "title_search"},
+	{Table: "authors", Source: "name", Target: "name_search"},
+}
+
+// Refold regrava com Fold as linhas em que a coluna normalizada diverge da
+// origem e devolve quantas mudaram. O preenchimento feito em SQL pela
+// migração só conhece uma lista fixa de acentos; rodar Refold depois dela
+// deixa as linhas antigas iguais às gravadas pela API.
+func Refold(ctx context.Context, db *sql.DB, c Column) (int, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT id, %s, %s FROM %s", c.Source, c.Target, c.Table))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	stale := map[int64]string{}
+	for rows.Next() {
+		var (
+			id             int64
+			source, target string
+		)
+		if err := rows.Scan(&id, &source, &target); err != nil {
+			return 0, err
+		}
+
+		if folded := Fold(source); folded != target {
+			stale[id] = folded
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	query := fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?", c.Table, c.Target)
+	for id, folded := range stale {
+		if _, err := db.ExecContext(ctx, query, folded, id); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(stale), nil
+}
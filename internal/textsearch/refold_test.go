@@ -0,0 +1,43 @@
+package textsearch_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/textsearch"
+)
+
+func TestRefold(t *testing.T) {
+	db := database.SetupTestDB()
+	ctx := context.Background()
+
+	// a primeira linha veio do preenchimento em SQL, que não conhece o å
+	_, err := db.Exec(`INSERT INTO authors (name, description, name_search) VALUES
+		('Ångström', '', 'ångström'), ('João', '', 'joao')`)
+	if err != nil {
+		t.Fatalf("authors: %v", err)
+	}
+
+	column := textsearch.Column{Table: "authors", Source: "name", Target: "name_search"}
+
+	n, err := textsearch.Refold(ctx, db, column)
+	if err != nil {
+		t.Fatalf("não esperava erro: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("esperava 1 linha atualizada, recebeu %d", n)
+	}
+
+	var folded string
+	if err := db.QueryRow("SELECT name_search FROM authors WHERE id = 1").Scan(&folded); err != nil {
+		t.Fatalf("erro ao buscar autor: %v", err)
+	}
+	if folded != "angstrom" {
+		t.Errorf("esperava %q, recebeu %q", "angstrom", folded)
+	}
+
+	if n, err := textsearch.Refold(ctx, db, column); err != nil || n != 0 {
+		t.Errorf("segunda passada não deveria mudar nada: %d, %v", n, err)
+	}
+}
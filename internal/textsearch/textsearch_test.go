@@ -0,0 +1,85 @@
+package textsearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"João", "joao"},
+		{"AÇÃO", "acao"},
+		{"Pôr do Sol", "por do sol"},
+		{"já é", "ja e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Fold(tt.in); got != tt.want {
+				t.Errorf("esperava %q, recebeu %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"machado", "machado", 0},
+		{"machdo", "machado", 1},
+		{"asis", "assis", 1},
+		{"lispector", "lispectro", 2},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("esperava %d, recebeu %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVocabulary_Suggest(t *testing.T) {
+	v := Vocabulary{}
+	v.Add("Machado de Assis")
+	v.Add("Clarice Lispector")
+	v.Add("Memórias Póstumas de Brás Cubas")
+	v.Add("Memorial de Aires")
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "corrige nome do autor",
+			query: "Machdo de Asis",
+			want:  []string{"machado de assis"},
+		},
+		{
+			name:  "termo conhecido não gera sugestão",
+			query: "Lispector",
+		},
+		{
+			name:  "alternativas para o mesmo termo",
+			query: "memoria",
+			want:  []string{"memorial", "memorias"},
+		},
+		{
+			name:  "sem palavra próxima",
+			query: "xyzxyz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Suggest(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("esperava %v, recebeu %v", tt.want, got)
+			}
+		})
+	}
+}
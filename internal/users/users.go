@@ -3,7 +3,32 @@ package users
 import (
 	"context"
 	"errors"
+	"net/mail"
+	"os"
 	"strings"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("email ou senha invalidos")
+	ErrWeakPassword       = errors.New("senha deve ter entre 6 e 72 caracteres")
+	ErrWrongPassword      = errors.New("senha atual incorreta")
+	ErrSamePassword       = errors.New("a nova senha deve ser diferente da atual")
+	ErrEmailNotVerified   = errors.New("confirme o email antes de entrar")
+	ErrInvalidEmail       = errors.New("email invalido")
+	ErrUserNotFound       = errors.New("usuario não encontrado")
+	ErrInvalidRole        = errors.New("perfil invalido; use user ou admin")
+	ErrLastAdmin          = errors.New("o último administrador não pode ser rebaixado nem excluído")
+	ErrUserHasFines       = errors.New("usuario com lançamentos de multa não pode ser excluído")
+)
+
+// Política de senha. O bcrypt só considera os primeiros 72 bytes, então uma
+// senha maior seria aceita sem que o final dela valesse nada.
+const (
+	MinPasswordLength = 6
+	MaxPasswordLength = 72
 )
 
 type Roles string
@@ -23,18 +48,43 @@ type Users struct {
 	Role      Roles
 	CreatedAt string
 	UpdatedAt string
+
+	// EmailVerified indica se o usuario já confirmou o email com o token
+	// enviado no cadastro.
+	EmailVerified bool
+}
+
+// RoleChange é uma troca de perfil feita por um administrador. Cada troca
+// fica registrada para auditoria, com quem fez e o perfil anterior.
+type RoleChange struct {
+	ID        int64
+	UserID    int64
+	ChangedBy int64
+	OldRole   Roles
+	NewRole   Roles
+	ChangedAt time.Time
+}
+
+func (r Roles) Valid() bool {
+	return r == User || r == Admin
 }
 
 type UserCreator interface {
 	Create(ctx context.Context, user *Users) error
 	Update(ctx context.Context, user *Users) error
 	Delete(ctx context.Context, id int64) error
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	// ChangeRole grava o novo perfil e a entrada de auditoria na mesma
+	// transação e preenche OldRole. Quando o perfil já é o pedido, nada é
+	// gravado. Devolve ErrLastAdmin em vez de deixar o sistema sem admin.
+	ChangeRole(ctx context.Context, c *RoleChange) error
 }
 
 type UserRead interface {
-	GetAll(ctx context.Context) ([]Users, error)
+	GetAll(ctx context.Context, p pagination.Params) ([]Users, pagination.Meta, error)
 	GetById(ctx context.Context, id int64) (*Users, error)
 	GetUserDetails(ctx context.Context, email string) (*Users, error)
+	GetPasswordHash(ctx context.Context, id int64) (string, error)
 }
 
 type IUsersRepository interface {
@@ -42,6 +92,19 @@ type IUsersRepository interface {
 	UserRead
 }
 
+// EmailVerifier envia ao usuario recém-cadastrado o token para confirmar o
+// email.
+type EmailVerifier interface {
+	Send(ctx context.Context, user *Users) error
+}
+
+// RequireVerifiedLoginFromEnv informa se EMAIL_VERIFICATION_REQUIRED pede
+// email confirmado para entrar ("login"). Com "loans" só os empréstimos
+// ficam bloqueados; vazio não bloqueia nada.
+func RequireVerifiedLoginFromEnv() bool {
+	return os.Getenv("EMAIL_VERIFICATION_REQUIRED") == "login"
+}
+
 func (u *Users) Validate() error {
 	if strings.TrimSpace(u.Name) == "" {
 		return errors.New("nome ou username não podem estar em branco")
@@ -51,8 +114,13 @@ func (u *Users) Validate() error {
 		return errors.New("username não pode esta em branco")
 	}
 
-	if len(u.Password) < 6 {
-		return errors.New("senha deve ser maior que 6 caracteres")
+	// só o endereço, sem nome de exibição ("Fulano <fulano@email.com>")
+	if addr, err := mail.ParseAddress(u.Email); err != nil || addr.Address != u.Email {
+		return ErrInvalidEmail
+	}
+
+	if err := ValidatePassword(u.Password); err != nil {
+		return err
 	}
 
 	if u.Role == "" {
@@ -61,3 +129,12 @@ func (u *Users) Validate() error {
 
 	return nil
 }
+
+// ValidatePassword aplica a política de senha no cadastro e em toda troca de
+// senha.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrWeakPassword
+	}
+	return nil
+}
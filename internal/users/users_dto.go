@@ -6,7 +6,6 @@ type UserRequest struct {
 	Email    string `json:"email" binding:"required" example:"joaquim@email.com"`
 	Password string `json:"password" binding:"required" example:"password123"`
 	Username string `json:"username" binding:"required" example:"joaoquim324"`
-	Role     Roles  `json:"role"`
 }
 
 // @Description Dados necessários para atualizar usuario
@@ -15,22 +14,48 @@ type UserUpdateRequest struct {
 	Bio  string `json:"bio" example:"Meu nome é Joaquim"`
 }
 
+// @Description Novo perfil do usuario
+type RoleRequest struct {
+	Role Roles `json:"role" binding:"required" example:"admin"`
+}
+
+// @Description Senha atual e a nova senha
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"password123"`
+	NewPassword     string `json:"new_password" binding:"required" example:"novaSenha123"`
+}
+
 // @Description Dados necessários para fazer o login
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required" example:"joaquim@email.com"`
 	Password string `json:"password" binding:"required" example:"password123"`
 }
 
+// @Description Refresh token recebido no login ou na última renovação
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// @Description Tokens da sessão. O refresh token só vale uma vez: cada
+// @Description renovação devolve um novo, que substitui o anterior.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"7200"`
+}
+
 type UserResponse struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Password  string `json:"-"`
-	Bio       string `json:"bio"`
-	Username  string `json:"username"`
-	Role      Roles  `json:"role"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Password      string `json:"-"`
+	Bio           string `json:"bio"`
+	Username      string `json:"username"`
+	Role          Roles  `json:"role"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 func ToResponse(u *Users) UserResponse {
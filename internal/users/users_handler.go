@@ -2,25 +2,67 @@ package users
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type UserHandler struct {
-	svc    UserService
-	logApp *zap.Logger
+	svc      UserService
+	sessions sessions.SessionService
+	verifier EmailVerifier
+	logApp   *zap.Logger
 }
 
-func NewUsersHandler(svc UserService, log *zap.Logger) *UserHandler {
-	return &UserHandler{svc: svc, logApp: log}
+func NewUsersHandler(svc UserService, sessionSvc sessions.SessionService, verifier EmailVerifier, log *zap.Logger) *UserHandler {
+	return &UserHandler{svc: svc, sessions: sessionSvc, verifier: verifier, logApp: log}
+}
+
+// sessionError converte os erros de login e renovação nas respostas da API.
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidCredentials), errors.Is(err, ErrEmailNotVerified),
+		errors.Is(err, sessions.ErrInvalidToken), errors.Is(err, sessions.ErrTokenReused):
+		return middleware.Unauthorized.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// deleteError converte os erros da exclusão de usuario nas respostas da API.
+func deleteError(err error) error {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		return middleware.NotFound.Messager(err.Error())
+	case errors.Is(err, ErrUserHasFines), errors.Is(err, ErrLastAdmin):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// roleError converte os erros da troca de perfil nas respostas da API.
+func roleError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidRole):
+		return middleware.BadRequest.Messager(err.Error())
+	case errors.Is(err, ErrUserNotFound):
+		return middleware.NotFound.Messager(err.Error())
+	case errors.Is(err, ErrLastAdmin):
+		return middleware.Conflict.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
 }
 
 // @Summary Cria um novo usuario
-// @Description Recebe um objeto JSON UserRequest e salva o usuario no banco de dados.
+// @Description Recebe um objeto JSON UserRequest, salva o usuario no banco de dados e envia para o email o token de confirmação.
 // @Tags users
 // @Accept  json
 // @Produce json
@@ -46,15 +88,27 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		Email:    dtoReq.Email,
 		Username: dtoReq.Username,
 		Password: dtoReq.Password,
-		Role:     dtoReq.Role,
+		// o cadastro público sempre cria leitores; admin só pela troca de
+		// perfil feita por outro admin
+		Role: User,
 	}
 
-	if err := h.svc.Create(c.Request.Context(), newUser); err != nil {
+	err := h.svc.Create(c.Request.Context(), newUser)
+	if errors.Is(err, ErrInvalidEmail) || errors.Is(err, ErrWeakPassword) {
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+	if err != nil {
 		h.logApp.Error("falha ao criar usuário", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
 		return
 	}
 
+	// a conta já existe; se o envio falhar, o usuario pede outro token
+	if err := h.verifier.Send(c.Request.Context(), newUser); err != nil {
+		h.logApp.Error("falha ao enviar confirmação de email", zap.Error(err))
+	}
+
 	c.JSON(http.StatusCreated, ToResponse(newUser))
 
 }
@@ -64,7 +118,13 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Success 200 {array} UserResponse
+// @Param page query int false "Página, a partir de 1"
+// @Param page_size query int false "Itens por página (padrão 10, máximo 100)"
+// @Param sort query string false "Campo de ordenação" Enums(id, name, username, email, created_at)
+// @Param order query string false "Direção da ordenação" Enums(asc, desc)
+// @Param cursor query string false "Cursor de next_cursor ou prev_cursor; substitui page, sort e order"
+// @Success 200 {object} pagination.Page[UserResponse]
+// @Failure 400 {object} middleware.APIError "Paginação ou ordenação inválida"
 // @Failure 500 {object} middleware.APIError "Erro interno"
 // @Router /public/api/users [get]
 func (h *UserHandler) ReadAllUsers(c *gin.Context) {
@@ -73,7 +133,14 @@ func (h *UserHandler) ReadAllUsers(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*5)
 	defer cancel()
 
-	getUsers, err := h.svc.GetAll(ctx)
+	params, err := pagination.Parse(c.Request.URL.Query(), UserSorts)
+	if err != nil {
+		h.logApp.Error("falha ao ler paginação", zap.Error(err))
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+
+	getUsers, meta, err := h.svc.GetAll(ctx, params)
 	if err != nil {
 		h.logApp.Error("falha ao obter usuários", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
@@ -85,7 +152,7 @@ func (h *UserHandler) ReadAllUsers(c *gin.Context) {
 		response = append(response, ToResponse(&user))
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, pagination.NewPage(response, meta, params))
 }
 
 // @Summary Obter usuario
@@ -166,7 +233,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 }
 
 // @Summary Exclui um usuario pelo ID
-// @Description Exclui um usuario específico do banco de dados.
+// @Description Exclui um usuario específico do banco de dados. Usuarios com lançamentos de multa e o último administrador não podem ser excluídos.
 // @Tags users
 // @Accept  json
 // @Produce json
@@ -174,7 +241,9 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 // @Param   id path int true "ID do usuario a ser excluído"
 // @Success 204 "Nenhum Conteúdo"
 // @Failure 400 {object} middleware.APIError "Requisição Inválida (ID com formato incorreto)"
-// @Failure 404 {object} middleware.APIError "Livro não encontrado"
+// @Failure 404 {object} middleware.APIError "Usuario não encontrado"
+// @Failure 409 {object} middleware.APIError "Usuario com lançamentos de multa ou último administrador"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
 // @Router /api/users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	h.logApp.Info("Rota de apagar usuário")
@@ -188,7 +257,14 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
 		h.logApp.Error("falha ao apagar usuário", zap.Error(err))
-		_ = c.Error(middleware.NotFound)
+		_ = c.Error(deleteError(err))
+		return
+	}
+
+	// os tokens já emitidos não podem continuar valendo para um usuário apagado
+	if err := h.sessions.LogoutAll(c.Request.Context(), id); err != nil {
+		h.logApp.Error("falha ao encerrar sessões do usuário apagado", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
 		return
 	}
 
@@ -196,13 +272,14 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 }
 
 // @Summary Faz o login do usuario
-// @Description Recebe um objeto JSON LoginRequest.
+// @Description Recebe um objeto JSON LoginRequest e devolve o token de acesso e o refresh token da nova sessão.
 // @Tags users
 // @Accept  json
 // @Produce json
 // @Param   user body LoginRequest true "Dados para realizar o login"
-// @Success 200 "token do usuario"
+// @Success 200 {object} TokenResponse
 // @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 401 {object} middleware.APIError "Email ou senha invalidos"
 // @Failure 500 {object} middleware.APIError "Erro interno do servidor"
 // @Router /public/api/users/login [post]
 func (h *UserHandler) LoginUser(c *gin.Context) {
@@ -222,16 +299,263 @@ func (h *UserHandler) LoginUser(c *gin.Context) {
 	user, err := h.svc.Login(ctx, dtoLogin.Email, dtoLogin.Password)
 	if err != nil {
 		h.logApp.Error("falha ao fazer login", zap.Error(err))
+		_ = c.Error(sessionError(err))
+		return
+	}
+
+	grant, err := h.sessions.Issue(ctx, user.ID)
+	if err != nil {
+		h.logApp.Error("falha ao abrir sessão", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	h.respondTokens(c, user, grant)
+}
+
+// @Summary Renova a sessão
+// @Description Troca o refresh token por um novo token de acesso e um novo refresh token. Cada refresh token vale uma vez; reapresentar um já trocado encerra a sessão inteira.
+// @Tags users
+// @Accept  json
+// @Produce json
+// @Param   data body RefreshRequest true "Refresh token atual"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 401 {object} middleware.APIError "Refresh token invalido, expirado ou reutilizado"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /public/api/users/refresh [post]
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	h.logApp.Info("Rota de renovar sessão")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Second*5)
+	defer cancel()
+
+	var dtoReq RefreshRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	grant, err := h.sessions.Refresh(ctx, dtoReq.RefreshToken)
+	if err != nil {
+		h.logApp.Error("falha ao renovar sessão", zap.Error(err))
+		_ = c.Error(sessionError(err))
+		return
+	}
+
+	user, err := h.svc.GetById(ctx, grant.UserID)
+	if err != nil {
+		h.logApp.Error("falha ao obter usuário da sessão", zap.Error(err))
+		_ = c.Error(sessionError(sessions.ErrInvalidToken))
+		return
+	}
+
+	h.respondTokens(c, user, grant)
+}
+
+// @Summary Encerra a sessão atual
+// @Description Revoga o token de acesso usado na requisição e o refresh token da mesma sessão.
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 401 {object} middleware.APIError "Token ausente, invalido ou revogado"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /api/users/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+	h.logApp.Info("Rota de logout")
+
+	claims, ok := middleware.ClaimsFrom(c)
+	if !ok {
+		_ = c.Error(middleware.Unauthorized)
+		return
+	}
+
+	err := h.sessions.Logout(c.Request.Context(), sessions.AccessToken{
+		ID:        claims.ID,
+		UserID:    claims.UserID,
+		SessionID: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+	if err != nil {
+		h.logApp.Error("falha ao encerrar sessão", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Encerra todas as sessões
+// @Description Revoga todos os tokens de acesso e refresh tokens do usuário autenticado, em todos os dispositivos.
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 401 {object} middleware.APIError "Token ausente, invalido ou revogado"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /api/users/logout/all [post]
+func (h *UserHandler) LogoutAll(c *gin.Context) {
+	h.logApp.Info("Rota de logout de todas as sessões")
+
+	claims, ok := middleware.ClaimsFrom(c)
+	if !ok {
+		_ = c.Error(middleware.Unauthorized)
+		return
+	}
+
+	if err := h.sessions.LogoutAll(c.Request.Context(), claims.UserID); err != nil {
+		h.logApp.Error("falha ao encerrar sessões", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Troca a senha do usuario autenticado
+// @Description Confere a senha atual, grava a nova e encerra todas as sessões abertas com a senha antiga. Quem fez a troca recebe tokens novos e continua conectado.
+// @Tags users
+// @Accept  json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param   data body ChangePasswordRequest true "Senha atual e nova senha"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} middleware.APIError "Senha atual incorreta ou nova senha fora da política"
+// @Failure 401 {object} middleware.APIError "Token ausente, invalido ou revogado"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /api/users/me/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	h.logApp.Info("Rota de trocar senha")
+
+	claims, ok := middleware.ClaimsFrom(c)
+	if !ok {
+		_ = c.Error(middleware.Unauthorized)
+		return
+	}
+
+	var dtoReq ChangePasswordRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := h.svc.ChangePassword(ctx, claims.UserID, dtoReq.CurrentPassword, dtoReq.NewPassword)
+	if errors.Is(err, ErrWeakPassword) || errors.Is(err, ErrWrongPassword) || errors.Is(err, ErrSamePassword) {
+		_ = c.Error(middleware.BadRequest.Messager(err.Error()))
+		return
+	}
+	if err != nil {
+		h.logApp.Error("falha ao trocar senha", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	user, err := h.svc.GetById(ctx, claims.UserID)
+	if err != nil {
+		h.logApp.Error("falha ao obter usuário", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	// todas as sessões, inclusive a desta requisição, são encerradas; só a
+	// nova devolvida abaixo continua valendo
+	grant, err := h.sessions.Reissue(ctx, user.ID)
+	if err != nil {
+		h.logApp.Error("falha ao renovar sessões", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
 		return
 	}
 
-	tokenString, err := middleware.GenerateToken(user.Email, string(user.Role))
+	h.respondTokens(c, user, grant)
+}
+
+// @Summary Troca o perfil de um usuario
+// @Description Promove ou rebaixa o usuario (user ou admin). A troca fica registrada para auditoria, e as sessões do usuario são encerradas para que o novo perfil valha na hora. O último administrador não pode ser rebaixado.
+// @Tags users
+// @Accept  json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param   id path int true "ID do usuario"
+// @Param   data body RoleRequest true "Novo perfil"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} middleware.APIError "Perfil invalido"
+// @Failure 403 {object} middleware.APIError "Requer perfil admin"
+// @Failure 404 {object} middleware.APIError "Usuario não encontrado"
+// @Failure 409 {object} middleware.APIError "Último administrador"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /api/users/{id}/role [put]
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	h.logApp.Info("Rota de trocar perfil de usuário")
+
+	id, err := middleware.GetIdParam(c)
+	if err != nil {
+		h.logApp.Error("falha ao verificar id", zap.Error(err))
+		_ = c.Error(err)
+		return
+	}
+
+	claims, ok := middleware.ClaimsFrom(c)
+	if !ok {
+		_ = c.Error(middleware.Unauthorized)
+		return
+	}
+
+	var dtoReq RoleRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	change, err := h.svc.ChangeRole(ctx, id, dtoReq.Role, claims.UserID)
+	if err != nil {
+		h.logApp.Error("falha ao trocar perfil", zap.Error(err))
+		_ = c.Error(roleError(err))
+		return
+	}
+
+	if change.OldRole != change.NewRole {
+		h.logApp.Info("perfil de usuário alterado",
+			zap.Int64("user_id", change.UserID), zap.Int64("changed_by", change.ChangedBy),
+			zap.String("old_role", string(change.OldRole)), zap.String("new_role", string(change.NewRole)))
+
+		// os tokens já emitidos levam o perfil antigo
+		if err := h.sessions.LogoutAll(ctx, id); err != nil {
+			h.logApp.Error("falha ao encerrar sessões", zap.Error(err))
+			_ = c.Error(middleware.InternalErr)
+			return
+		}
+	}
+
+	user, err := h.svc.GetById(ctx, id)
+	if err != nil {
+		h.logApp.Error("falha ao obter usuário", zap.Error(err))
+		_ = c.Error(middleware.InternalErr)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(user))
+}
+
+func (h *UserHandler) respondTokens(c *gin.Context, user *Users, grant *sessions.Grant) {
+	accessToken, err := middleware.GenerateToken(user.ID, user.Email, string(user.Role), grant.SessionID)
 	if err != nil {
 		h.logApp.Error("falha ao gerar token", zap.Error(err))
 		_ = c.Error(middleware.InternalErr)
 		return
 	}
 
-	c.JSON(http.StatusOK, tokenString)
+	c.JSON(http.StatusOK, TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: grant.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(middleware.AccessTokenTTL.Seconds()),
+	})
 }
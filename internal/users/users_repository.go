@@ -3,15 +3,19 @@ package users
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"errors"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 type UserRepository struct {
-	db *sql.DB
+	db        *sql.DB
+	forUpdate string
 }
 
 func NewUsersRepository(db *sql.DB) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: db, forUpdate: database.ForUpdate(db)}
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *Users) error {
@@ -32,26 +36,49 @@ func (r *UserRepository) Create(ctx context.Context, user *Users) error {
 	return nil
 }
 
-func (r *UserRepository) GetAll(ctx context.Context) ([]Users, error) {
-	query := `SELECT id, name, email, username, role, bio, created_at, updated_at FROM users`
+// UserSorts são os campos aceitos em sort na listagem de usuários.
+var UserSorts = pagination.Sorts{
+	Fields: map[string]string{
+		"id": "id", "name": "name", "username": "username", "email": "email", "created_at": "created_at",
+	},
+	Default: "id",
+	Key:     "id",
+}
+
+func (r *UserRepository) GetAll(ctx context.Context, p pagination.Params) ([]Users, pagination.Meta, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&total); err != nil {
+		return nil, pagination.Meta{}, err
+	}
+
+	seek, args := p.Seek(UserSorts)
+	if seek != "" {
+		seek = " WHERE " + seek
+	}
 
-	rows, err := r.db.QueryContext(ctx, query)
+	query := `SELECT id, name, email, username, role, email_verified, bio, created_at, updated_at, ` + UserSorts.Column(p) +
+		` FROM users` + seek + p.OrderBy(UserSorts) + " LIMIT ? OFFSET ?"
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, p.Limit(), p.Offset())...)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
 	}
 	defer rows.Close()
 
 	var getUsers []Users
+	var positions []pagination.Row
 
 	for rows.Next() {
 		var u Users
 		var tempBio sql.NullString
+		var position pagination.Row
 
 		if err := rows.Scan(
-			&u.ID, &u.Name, &u.Email, &u.Username, &u.Role, &tempBio, &u.CreatedAt, &u.UpdatedAt,
+			&u.ID, &u.Name, &u.Email, &u.Username, &u.Role, &u.EmailVerified, &tempBio, &u.CreatedAt, &u.UpdatedAt, &position.Value,
 		); err != nil {
-			return nil, err
+			return nil, pagination.Meta{}, err
 		}
+		position.Key = u.ID
 
 		if tempBio.Valid {
 			u.Bio = tempBio.String
@@ -60,19 +87,24 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]Users, error) {
 		}
 
 		getUsers = append(getUsers, u)
+		positions = append(positions, position)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Meta{}, err
 	}
 
-	return getUsers, nil
+	getUsers, meta := pagination.Window(p, total, getUsers, positions)
+	return getUsers, meta, nil
 }
 
 func (r *UserRepository) GetById(ctx context.Context, id int64) (*Users, error) {
-	query := `SELECT id, name, email, username, role, bio, created_at, updated_at FROM users WHERE id = ?`
+	query := `SELECT id, name, email, username, role, email_verified, bio, created_at, updated_at FROM users WHERE id = ?`
 
 	var user Users
 	var tempBio sql.NullString
 
 	row := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Username, &user.Role, &tempBio, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Name, &user.Email, &user.Username, &user.Role, &user.EmailVerified, &tempBio, &user.CreatedAt, &user.UpdatedAt,
 	)
 
 	if tempBio.Valid {
@@ -104,26 +136,152 @@ func (r *UserRepository) Update(ctx context.Context, user *Users) error {
 	return nil
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id int64) error {
-	query := "DELETE FROM users WHERE id = ?"
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE users SET password = ? WHERE id = ?", passwordHash, id)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
 
 	return nil
 }
 
+// Delete apaga o usuario. O extrato de multas é imutável e precisa continuar
+// apontando para o devedor, então quem já teve lançamentos não é apagado, e o
+// último admin também fica, para que o sistema não perca a administração.
+func (r *UserRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	admins, err := r.lockAdmins(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	var role Roles
+	err = tx.QueryRowContext(ctx, "SELECT role FROM users WHERE id = ?"+r.forUpdate, id).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if role == Admin && admins <= 1 {
+		return ErrLastAdmin
+	}
+
+	var hasFines bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM fine_entries WHERE user_id = ?)", id).Scan(&hasFines)
+	if err != nil {
+		return err
+	}
+
+	if hasFines {
+		return ErrUserHasFines
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockAdmins conta os admins lendo as linhas com bloqueio. Duas remoções
+// simultâneas de admins esperam uma pela outra, e a segunda já conta sem o
+// admin que a primeira removeu.
+func (r *UserRepository) lockAdmins(ctx context.Context, tx *sql.Tx) (int, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM users WHERE role = ?"+r.forUpdate, Admin)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+
+	return count, rows.Err()
+}
+
 func (r *UserRepository) GetUserDetails(ctx context.Context, email string) (*Users, error) {
-	query := "SELECT email, username, password, role FROM users WHERE email = ?"
+	query := "SELECT id, email, username, password, role, email_verified FROM users WHERE email = ?"
 
 	var user Users
-	err := r.db.QueryRowContext(ctx, query, email).Scan(&user.Email, &user.Username, &user.Password, &user.Role)
+	err := r.db.QueryRowContext(ctx, query, email).Scan(
+		&user.ID, &user.Email, &user.Username, &user.Password, &user.Role, &user.EmailVerified,
+	)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println(user)
 
 	return &user, nil
 }
+
+func (r *UserRepository) GetPasswordHash(ctx context.Context, id int64) (string, error) {
+	var hash string
+	err := r.db.QueryRowContext(ctx, "SELECT password FROM users WHERE id = ?", id).Scan(&hash)
+	return hash, err
+}
+
+func (r *UserRepository) ChangeRole(ctx context.Context, c *RoleChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	admins, err := r.lockAdmins(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, "SELECT role FROM users WHERE id = ?"+r.forUpdate, c.UserID).Scan(&c.OldRole)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.OldRole == c.NewRole {
+		return nil
+	}
+
+	if c.OldRole == Admin && admins <= 1 {
+		return ErrLastAdmin
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET role = ? WHERE id = ?", c.NewRole, c.UserID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO role_changes (user_id, changed_by, old_role, new_role, changed_at) VALUES (?, ?, ?, ?, ?)",
+		c.UserID, c.ChangedBy, c.OldRole, c.NewRole, c.ChangedAt)
+	if err != nil {
+		return err
+	}
+
+	if c.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,128 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+func TestUserRepository_ChangeRole(t *testing.T) {
+	db := database.SetupTestDB()
+	repo := users.NewUsersRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for _, u := range []*users.Users{
+		{Name: "Admin", Email: "admin@email.com", Username: "admin", Password: "hash", Role: users.Admin},
+		{Name: "Leitor", Email: "leitor@email.com", Username: "leitor", Password: "hash", Role: users.User},
+	} {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("user: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		userID  int64
+		role    users.Roles
+		oldRole users.Roles
+		wantErr error
+	}{
+		{name: "rebaixar o único admin", userID: 1, role: users.User, oldRole: users.Admin, wantErr: users.ErrLastAdmin},
+		{name: "usuario inexistente", userID: 99, role: users.Admin, wantErr: users.ErrUserNotFound},
+		{name: "promover leitor", userID: 2, role: users.Admin, oldRole: users.User},
+		{name: "perfil igual não grava nada", userID: 2, role: users.Admin, oldRole: users.Admin},
+		{name: "rebaixar com outro admin", userID: 1, role: users.User, oldRole: users.Admin},
+		{name: "rebaixar o novo último admin", userID: 2, role: users.User, oldRole: users.Admin, wantErr: users.ErrLastAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := &users.RoleChange{UserID: tt.userID, ChangedBy: 1, NewRole: tt.role, ChangedAt: now}
+
+			err := repo.ChangeRole(ctx, change)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+			if tt.wantErr == users.ErrUserNotFound {
+				return
+			}
+
+			if change.OldRole != tt.oldRole {
+				t.Errorf("esperava perfil anterior %s, recebeu %s", tt.oldRole, change.OldRole)
+			}
+
+			want := tt.role
+			if tt.wantErr != nil {
+				want = tt.oldRole
+			}
+
+			user, err := repo.GetById(ctx, tt.userID)
+			if err != nil {
+				t.Fatalf("erro ao buscar usuário: %v", err)
+			}
+			if user.Role != want {
+				t.Errorf("esperava perfil %s, recebeu %s", want, user.Role)
+			}
+		})
+	}
+
+	// só as duas trocas efetivas ficam na auditoria
+	var audited int
+	if err := db.QueryRow("SELECT COUNT(*) FROM role_changes").Scan(&audited); err != nil {
+		t.Fatalf("erro ao contar auditoria: %v", err)
+	}
+	if audited != 2 {
+		t.Errorf("esperava 2 entradas de auditoria, recebeu %d", audited)
+	}
+}
+
+func TestUserRepository_Delete(t *testing.T) {
+	db := database.SetupTestDB()
+	repo := users.NewUsersRepository(db)
+	ctx := context.Background()
+
+	for _, u := range []*users.Users{
+		{Name: "Devedor", Email: "devedor@email.com", Username: "devedor", Password: "hash", Role: users.User},
+		{Name: "Leitor", Email: "leitor@email.com", Username: "leitor", Password: "hash", Role: users.User},
+		{Name: "Admin", Email: "admin@email.com", Username: "admin", Password: "hash", Role: users.Admin},
+	} {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("user: %v", err)
+		}
+	}
+
+	_, err := db.Exec(`INSERT INTO fine_entries (user_id, kind, amount_cents, created_by) VALUES (1, 'accrual', 150, 'system')`)
+	if err != nil {
+		t.Fatalf("fine: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  int64
+		wantErr error
+	}{
+		{name: "usuario com multas", userID: 1, wantErr: users.ErrUserHasFines},
+		{name: "usuario sem multas", userID: 2},
+		{name: "usuario inexistente", userID: 99, wantErr: users.ErrUserNotFound},
+		{name: "único admin", userID: 3, wantErr: users.ErrLastAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.Delete(ctx, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("esperava erro %v, recebeu %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := repo.GetById(ctx, 1); err != nil {
+		t.Errorf("esperava o devedor mantido, recebeu %v", err)
+	}
+	if _, err := repo.GetById(ctx, 3); err != nil {
+		t.Errorf("esperava o admin mantido, recebeu %v", err)
+	}
+}
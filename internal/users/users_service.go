@@ -2,10 +2,13 @@ package users
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/pagination"
 )
 
 type userCreator interface {
@@ -13,10 +16,15 @@ type userCreator interface {
 	Update(ctx context.Context, user *Users) error
 	Delete(ctx context.Context, id int64) error
 	Login(ctx context.Context, email, password string) (*Users, error)
+	// ChangePassword troca a senha de quem sabe a atual. Encerrar as sessões
+	// abertas com a senha antiga fica com quem chama.
+	ChangePassword(ctx context.Context, id int64, current, next string) error
+	// ChangeRole troca o perfil do usuario em nome do administrador changedBy.
+	ChangeRole(ctx context.Context, id int64, role Roles, changedBy int64) (*RoleChange, error)
 }
 
 type userRead interface {
-	GetAll(ctx context.Context) ([]Users, error)
+	GetAll(ctx context.Context, p pagination.Params) ([]Users, pagination.Meta, error)
 	GetById(ctx context.Context, id int64) (*Users, error)
 }
 
@@ -27,11 +35,14 @@ type UserService interface {
 
 type serviceUser struct {
 	repo IUsersRepository
+	// requireVerifiedEmail barra o login de quem não confirmou o email.
+	requireVerifiedEmail bool
 }
 
-func NewUsersService(repo IUsersRepository) *serviceUser {
+func NewUsersService(repo IUsersRepository, requireVerifiedEmail bool) *serviceUser {
 	return &serviceUser{
-		repo: repo,
+		repo:                 repo,
+		requireVerifiedEmail: requireVerifiedEmail,
 	}
 }
 
@@ -50,8 +61,8 @@ func (s *serviceUser) Create(ctx context.Context, user *Users) error {
 	return s.repo.Create(ctx, user)
 }
 
-func (s *serviceUser) GetAll(ctx context.Context) ([]Users, error) {
-	return s.repo.GetAll(ctx)
+func (s *serviceUser) GetAll(ctx context.Context, p pagination.Params) ([]Users, pagination.Meta, error) {
+	return s.repo.GetAll(ctx, p)
 }
 
 func (s *serviceUser) GetById(ctx context.Context, id int64) (*Users, error) {
@@ -72,13 +83,66 @@ func (s *serviceUser) Delete(ctx context.Context, id int64) error {
 
 func (s *serviceUser) Login(ctx context.Context, email, password string) (*Users, error) {
 	userDetails, err := s.repo.GetUserDetails(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if !middleware.VerifyPassword(userDetails.Password, password) {
-		return nil, err
+		return nil, ErrInvalidCredentials
+	}
+
+	// conferido depois da senha, para não revelar a quem não a sabe se o
+	// email foi confirmado
+	if s.requireVerifiedEmail && !userDetails.EmailVerified {
+		return nil, ErrEmailNotVerified
 	}
 
 	return userDetails, nil
 }
+
+func (s *serviceUser) ChangePassword(ctx context.Context, id int64, current, next string) error {
+	if err := ValidatePassword(next); err != nil {
+		return err
+	}
+
+	hash, err := s.repo.GetPasswordHash(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !middleware.VerifyPassword(hash, current) {
+		return ErrWrongPassword
+	}
+
+	if current == next {
+		return ErrSamePassword
+	}
+
+	newHash, err := middleware.HashPassowrd(next)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdatePassword(ctx, id, newHash)
+}
+
+func (s *serviceUser) ChangeRole(ctx context.Context, id int64, role Roles, changedBy int64) (*RoleChange, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+
+	change := &RoleChange{
+		UserID:    id,
+		ChangedBy: changedBy,
+		NewRole:   role,
+		ChangedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := s.repo.ChangeRole(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
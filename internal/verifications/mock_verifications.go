@@ -0,0 +1,31 @@
+package verifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVerifyRepo struct {
+	mock.Mock
+}
+
+func (m *MockVerifyRepo) Create(ctx context.Context, t *onetime.Token) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockVerifyRepo) Confirm(ctx context.Context, t *onetime.Token, now time.Time) error {
+	args := m.Called(ctx, t, now)
+	return args.Error(0)
+}
+
+func (m *MockVerifyRepo) GetByHash(ctx context.Context, tokenHash string) (*onetime.Token, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*onetime.Token), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package verifications
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+)
+
+// DefaultVerifyTTL é por quanto tempo o token de confirmação vale. Depois
+// disso o usuario pede outro.
+const DefaultVerifyTTL = 48 * time.Hour
+
+var ErrInvalidToken = errors.New("token de confirmação invalido ou expirado")
+
+// IVerifyRepository guarda os tokens de confirmação de email, tokens de uso
+// único da tabela email_verifications.
+type IVerifyRepository interface {
+	onetime.ITokenStore
+	// Confirm marca o token como usado e o email do dono como confirmado na
+	// mesma transação. Devolve ErrInvalidToken se outro pedido usou o token
+	// antes ou se ele expirou.
+	Confirm(ctx context.Context, t *onetime.Token, now time.Time) error
+}
+
+// VerifyTTLFromEnv lê EMAIL_VERIFICATION_TTL_HOURS, usando o padrão para
+// valores ausentes ou inválidos.
+func VerifyTTLFromEnv() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("EMAIL_VERIFICATION_TTL_HOURS"))
+	if err != nil || hours <= 0 {
+		return DefaultVerifyTTL
+	}
+	return time.Duration(hours) * time.Hour
+}
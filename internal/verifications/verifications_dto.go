@@ -0,0 +1,11 @@
+package verifications
+
+// @Description Token recebido por email no cadastro
+type ConfirmRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// @Description Email da conta que vai receber um novo token de confirmação
+type ResendRequest struct {
+	Email string `json:"email" binding:"required" example:"joaquim@email.com"`
+}
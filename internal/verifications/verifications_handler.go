@@ -0,0 +1,95 @@
+package verifications
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/middleware"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type VerificationHandler struct {
+	svc    VerificationService
+	logApp *zap.Logger
+}
+
+func NewVerificationHandler(svc VerificationService, logApp *zap.Logger) *VerificationHandler {
+	return &VerificationHandler{svc: svc, logApp: logApp}
+}
+
+func verificationError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidToken):
+		return middleware.BadRequest.Messager(err.Error())
+	default:
+		return middleware.InternalErr
+	}
+}
+
+// @Summary Confirma o email
+// @Description Confirma o email do usuario com o token enviado no cadastro.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param data body ConfirmRequest true "Token de confirmação"
+// @Success 204 "Nenhum Conteúdo"
+// @Failure 400 {object} middleware.APIError "Token invalido, expirado ou já usado"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /public/api/users/email/confirm [post]
+func (h *VerificationHandler) Confirm(c *gin.Context) {
+	h.logApp.Info("Rota de confirmar email")
+
+	var dtoReq ConfirmRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	if err := h.svc.Confirm(c.Request.Context(), dtoReq.Token); err != nil {
+		h.logApp.Error("falha ao confirmar email", zap.Error(err))
+		_ = c.Error(verificationError(err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Reenvia a confirmação de email
+// @Description Envia um novo token de confirmação. A resposta é a mesma exista ou não conta pendente com o email.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param data body ResendRequest true "Email da conta"
+// @Success 202 "Pedido aceito"
+// @Failure 400 {object} middleware.APIError "Requisição Inválida (JSON malformado ou campo obrigatório ausente)"
+// @Failure 500 {object} middleware.APIError "Erro interno do servidor"
+// @Router /public/api/users/email/resend [post]
+func (h *VerificationHandler) Resend(c *gin.Context) {
+	h.logApp.Info("Rota de reenviar confirmação de email")
+
+	var dtoReq ResendRequest
+	if err := c.ShouldBindJSON(&dtoReq); err != nil {
+		h.logApp.Error("falha ao ler json", zap.Error(err))
+		_ = c.Error(middleware.BadRequest)
+		return
+	}
+
+	err := h.svc.Resend(c.Request.Context(), dtoReq.Email)
+	if errors.Is(err, onetime.ErrDelivery) {
+		// o envio só acontece para uma conta ainda sem confirmação; um erro
+		// aqui diria isso a quem digitou o email. O token novo já está
+		// gravado, e o usuario pode pedir outro reenvio
+		h.logApp.Error("falha ao enviar email de confirmação", zap.Error(err))
+		err = nil
+	}
+	if err != nil {
+		h.logApp.Error("falha ao reenviar confirmação de email", zap.Error(err))
+		_ = c.Error(verificationError(err))
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
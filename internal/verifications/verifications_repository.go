@@ -0,0 +1,26 @@
+package verifications
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+)
+
+type VerifyRepository struct {
+	*onetime.Store
+}
+
+func NewVerifyRepository(db *sql.DB) *VerifyRepository {
+	return &VerifyRepository{Store: onetime.NewStore(db, "email_verifications", ErrInvalidToken)}
+}
+
+// Confirm gasta o token e, com ele, os outros do usuario, que perdem o
+// sentido com o email confirmado.
+func (r *VerifyRepository) Confirm(ctx context.Context, t *onetime.Token, now time.Time) error {
+	return r.Spend(ctx, t, now, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "UPDATE users SET email_verified = TRUE WHERE id = ?", t.UserID)
+		return err
+	})
+}
@@ -0,0 +1,69 @@
+package verifications_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/database"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/verifications"
+)
+
+func TestVerifyRepository_Confirm(t *testing.T) {
+	db := database.SetupTestDB()
+	repo := verifications.NewVerifyRepository(db)
+	userRepo := users.NewUsersRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if err := userRepo.Create(ctx, &users.Users{Name: "Leitor", Email: "um@email.com", Username: "um", Password: "hash", Role: users.User}); err != nil {
+		t.Fatalf("user: %v", err)
+	}
+
+	first := &onetime.Token{UserID: 1, TokenHash: sessions.HashToken("primeiro"), ExpiresAt: now.Add(time.Hour)}
+	second := &onetime.Token{UserID: 1, TokenHash: sessions.HashToken("segundo"), ExpiresAt: now.Add(time.Hour)}
+	for _, tok := range []*onetime.Token{first, second} {
+		if err := repo.Create(ctx, tok); err != nil {
+			t.Fatalf("erro ao criar token: %v", err)
+		}
+	}
+
+	if _, err := repo.GetByHash(ctx, sessions.HashToken("outro")); !errors.Is(err, verifications.ErrInvalidToken) {
+		t.Errorf("esperava ErrInvalidToken, recebeu %v", err)
+	}
+
+	user, err := userRepo.GetById(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar usuário: %v", err)
+	}
+	if user.EmailVerified {
+		t.Fatal("usuário recém-criado não deveria ter o email confirmado")
+	}
+
+	if err := repo.Confirm(ctx, first, now); err != nil {
+		t.Fatalf("erro ao confirmar email: %v", err)
+	}
+	if err := repo.Confirm(ctx, first, now); !errors.Is(err, verifications.ErrInvalidToken) {
+		t.Errorf("token usado não deveria valer de novo: %v", err)
+	}
+
+	user, err = userRepo.GetById(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar usuário: %v", err)
+	}
+	if !user.EmailVerified {
+		t.Error("esperava email confirmado")
+	}
+
+	pending, err := repo.GetByHash(ctx, second.TokenHash)
+	if err != nil {
+		t.Fatalf("erro ao buscar token: %v", err)
+	}
+	if pending.Usable(now) {
+		t.Errorf("esperava os outros tokens encerrados: %+v", pending)
+	}
+}
@@ -0,0 +1,97 @@
+package verifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/notify"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+)
+
+type VerificationService interface {
+	// Send gera um token para o usuario e o envia ao email dele. Atende
+	// users.EmailVerifier. Falhas no envio voltam como onetime.ErrDelivery.
+	Send(ctx context.Context, user *users.Users) error
+	// Resend envia um token novo. Emails sem conta ou já confirmados não dão
+	// erro, para a rota não revelar quem está cadastrado.
+	Resend(ctx context.Context, email string) error
+	Confirm(ctx context.Context, token string) error
+}
+
+type serviceVerification struct {
+	repo     IVerifyRepository
+	users    onetime.UserAccounts
+	notifier notify.Notifier
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+func NewVerificationService(repo IVerifyRepository, accounts onetime.UserAccounts, notifier notify.Notifier,
+	ttl time.Duration) *serviceVerification {
+	return &serviceVerification{
+		repo:     repo,
+		users:    accounts,
+		notifier: notifier,
+		ttl:      ttl,
+		now:      func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+func (s *serviceVerification) Send(ctx context.Context, user *users.Users) error {
+	token, err := sessions.NewToken()
+	if err != nil {
+		return err
+	}
+
+	t := &onetime.Token{UserID: user.ID, TokenHash: sessions.HashToken(token), ExpiresAt: s.now().Add(s.ttl)}
+	if err := s.repo.Create(ctx, t); err != nil {
+		return err
+	}
+
+	err = s.notifier.Notify(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Confirmação de email",
+		Body: fmt.Sprintf("Use o token abaixo em POST /public/api/users/email/confirm para confirmar seu email. "+
+			"Ele vale por %s. Se você não criou uma conta, ignore esta mensagem.\n\n%s",
+			s.ttl, token),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", onetime.ErrDelivery, err)
+	}
+	return nil
+}
+
+func (s *serviceVerification) Resend(ctx context.Context, email string) error {
+	user, err := s.users.GetUserDetails(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	return s.Send(ctx, user)
+}
+
+func (s *serviceVerification) Confirm(ctx context.Context, token string) error {
+	now := s.now()
+
+	t, err := s.repo.GetByHash(ctx, sessions.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if !t.Usable(now) {
+		return ErrInvalidToken
+	}
+
+	return s.repo.Confirm(ctx, t, now)
+}
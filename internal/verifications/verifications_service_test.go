@@ -0,0 +1,124 @@
+package verifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/notify"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/onetime"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/sessions"
+	"github.com/JoaoGeraldoS/Projeto_API_Biblioteca/internal/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_serviceVerification_Resend(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		user      *users.Users
+		findErr   error
+		send      bool
+		notifyErr error
+		wantErr   error
+	}{
+		{name: "email pendente", user: &users.Users{ID: 7, Email: "um@email.com"}, send: true},
+		{
+			name:      "falha no envio",
+			user:      &users.Users{ID: 7, Email: "um@email.com"},
+			send:      true,
+			notifyErr: errors.New("smtp"),
+			wantErr:   onetime.ErrDelivery,
+		},
+		{name: "email já confirmado", user: &users.Users{ID: 7, Email: "um@email.com", EmailVerified: true}},
+		{name: "email sem conta", findErr: sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockVerifyRepo)
+			mockUsers := new(onetime.MockUserAccounts)
+			mockNotifier := new(notify.MockNotifier)
+
+			mockUsers.On("GetUserDetails", mock.Anything, "um@email.com").Return(tt.user, tt.findErr)
+
+			var created *onetime.Token
+			var sent notify.Message
+			if tt.send {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*onetime.Token")).
+					Run(func(args mock.Arguments) { created = args.Get(1).(*onetime.Token) }).Return(nil)
+				mockNotifier.On("Notify", mock.Anything, mock.AnythingOfType("notify.Message")).
+					Run(func(args mock.Arguments) { sent = args.Get(1).(notify.Message) }).Return(tt.notifyErr)
+			}
+
+			svc := NewVerificationService(mockRepo, mockUsers, mockNotifier, DefaultVerifyTTL)
+			svc.now = func() time.Time { return now }
+
+			assert.ErrorIs(t, svc.Resend(context.Background(), "um@email.com"), tt.wantErr)
+
+			if tt.send {
+				assert.Equal(t, int64(7), created.UserID)
+				assert.True(t, created.ExpiresAt.Equal(now.Add(DefaultVerifyTTL)))
+				assert.Equal(t, "um@email.com", sent.To)
+
+				lines := strings.Split(sent.Body, "\n")
+				assert.Equal(t, sessions.HashToken(lines[len(lines)-1]), created.TokenHash)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockUsers.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_serviceVerification_Confirm(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		current    *onetime.Token
+		findErr    error
+		confirm    bool
+		confirmErr error
+		wantErr    error
+	}{
+		{name: "sucesso", current: &onetime.Token{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Hour)}, confirm: true},
+		{name: "token desconhecido", findErr: ErrInvalidToken, wantErr: ErrInvalidToken},
+		{name: "token expirado", current: &onetime.Token{ID: 1, UserID: 7, ExpiresAt: earlier}, wantErr: ErrInvalidToken},
+		{
+			name:    "token já usado",
+			current: &onetime.Token{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Hour), UsedAt: &earlier},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:       "usado por outro pedido ao mesmo tempo",
+			current:    &onetime.Token{ID: 1, UserID: 7, ExpiresAt: now.Add(time.Hour)},
+			confirm:    true,
+			confirmErr: ErrInvalidToken,
+			wantErr:    ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockVerifyRepo)
+			mockRepo.On("GetByHash", mock.Anything, sessions.HashToken("token")).Return(tt.current, tt.findErr)
+			if tt.confirm {
+				mockRepo.On("Confirm", mock.Anything, tt.current, now).Return(tt.confirmErr)
+			}
+
+			svc := NewVerificationService(mockRepo, new(onetime.MockUserAccounts), new(notify.MockNotifier), DefaultVerifyTTL)
+			svc.now = func() time.Time { return now }
+
+			err := svc.Confirm(context.Background(), "token")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE loans;
\ No newline at end of file
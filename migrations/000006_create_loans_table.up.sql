@@ -0,0 +1,13 @@
+CREATE TABLE loans (
+  id int NOT NULL AUTO_INCREMENT,
+  book_id int NOT NULL,
+  user_id int NOT NULL,
+  loaned_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  due_date timestamp NOT NULL,
+  returned_at timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (id),
+  KEY book_id (book_id),
+  KEY user_id (user_id),
+  CONSTRAINT loans_ibfk_1 FOREIGN KEY (book_id) REFERENCES books (id),
+  CONSTRAINT loans_ibfk_2 FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
\ No newline at end of file
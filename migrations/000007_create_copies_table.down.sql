@@ -0,0 +1 @@
+DROP TABLE copies;
\ No newline at end of file
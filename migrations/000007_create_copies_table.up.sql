@@ -0,0 +1,14 @@
+CREATE TABLE copies (
+  id int NOT NULL AUTO_INCREMENT,
+  book_id int NOT NULL,
+  barcode varchar(50) NOT NULL,
+  shelf_location varchar(100) NOT NULL DEFAULT '',
+  physical_condition enum('new','good','fair','poor','damaged') NOT NULL DEFAULT 'good',
+  status enum('available','on_loan','lost','in_repair') NOT NULL DEFAULT 'available',
+  created_at timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY barcode (barcode),
+  KEY book_id_status (book_id, status),
+  CONSTRAINT copies_ibfk_1 FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
\ No newline at end of file
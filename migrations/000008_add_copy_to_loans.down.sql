@@ -0,0 +1 @@
+ALTER TABLE loans DROP FOREIGN KEY loans_ibfk_3, DROP COLUMN copy_id;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE loans
+  ADD COLUMN copy_id int DEFAULT NULL AFTER book_id,
+  ADD KEY copy_id (copy_id),
+  ADD CONSTRAINT loans_ibfk_3 FOREIGN KEY (copy_id) REFERENCES copies (id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE holds;
+
+UPDATE copies SET status = 'available' WHERE status = 'on_hold';
+ALTER TABLE copies
+  MODIFY status enum('available','on_loan','lost','in_repair') NOT NULL DEFAULT 'available';
\ No newline at end of file
@@ -0,0 +1,20 @@
+ALTER TABLE copies
+  MODIFY status enum('available','on_loan','lost','in_repair','on_hold') NOT NULL DEFAULT 'available';
+
+CREATE TABLE holds (
+  id int NOT NULL AUTO_INCREMENT,
+  book_id int NOT NULL,
+  user_id int NOT NULL,
+  copy_id int DEFAULT NULL,
+  status enum('waiting','ready','fulfilled','cancelled','expired') NOT NULL DEFAULT 'waiting',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ready_at timestamp NULL DEFAULT NULL,
+  expires_at timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (id),
+  KEY book_id_status (book_id, status),
+  KEY user_id (user_id),
+  KEY status_expires_at (status, expires_at),
+  CONSTRAINT holds_ibfk_1 FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+  CONSTRAINT holds_ibfk_2 FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT holds_ibfk_3 FOREIGN KEY (copy_id) REFERENCES copies (id) ON DELETE SET NULL
+);
\ No newline at end of file
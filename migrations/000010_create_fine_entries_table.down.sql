@@ -0,0 +1 @@
+DROP TABLE fine_entries;
\ No newline at end of file
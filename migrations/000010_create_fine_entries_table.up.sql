@@ -0,0 +1,22 @@
+CREATE TABLE fine_entries (
+  id int NOT NULL AUTO_INCREMENT,
+  user_id int NOT NULL,
+  loan_id int DEFAULT NULL,
+  kind enum('accrual','waiver','adjustment','payment') NOT NULL,
+  amount_cents int NOT NULL,
+  note varchar(255) NOT NULL DEFAULT '',
+  created_by varchar(255) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY user_id (user_id),
+  KEY loan_id (loan_id),
+  CONSTRAINT fine_entries_ibfk_1 FOREIGN KEY (user_id) REFERENCES users (id),
+  CONSTRAINT fine_entries_ibfk_2 FOREIGN KEY (loan_id) REFERENCES loans (id),
+  CONSTRAINT fine_entries_chk_1 CHECK (amount_cents <> 0)
+);
+
+CREATE TRIGGER fine_entries_no_update BEFORE UPDATE ON fine_entries
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'lançamentos de multa são imutáveis';
+
+CREATE TRIGGER fine_entries_no_delete BEFORE DELETE ON fine_entries
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'lançamentos de multa são imutáveis';
\ No newline at end of file
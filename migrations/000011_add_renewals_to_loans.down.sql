@@ -0,0 +1 @@
+ALTER TABLE loans DROP COLUMN renewals;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE loans
+  ADD COLUMN renewals int NOT NULL DEFAULT 0 AFTER returned_at;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE books DROP INDEX isbn_13, DROP INDEX isbn_10, DROP COLUMN isbn_13, DROP COLUMN isbn_10;
\ No newline at end of file
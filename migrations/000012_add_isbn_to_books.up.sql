@@ -0,0 +1,5 @@
+ALTER TABLE books
+  ADD COLUMN isbn_10 varchar(10) DEFAULT NULL AFTER author_id,
+  ADD COLUMN isbn_13 varchar(13) DEFAULT NULL AFTER isbn_10,
+  ADD UNIQUE KEY isbn_10 (isbn_10),
+  ADD UNIQUE KEY isbn_13 (isbn_13);
\ No newline at end of file
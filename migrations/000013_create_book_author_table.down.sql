@@ -0,0 +1 @@
+DROP TABLE book_author;
\ No newline at end of file
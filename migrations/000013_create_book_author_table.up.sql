@@ -0,0 +1,15 @@
+CREATE TABLE book_author (
+  id int NOT NULL AUTO_INCREMENT,
+  book_id int NOT NULL,
+  author_id int NOT NULL,
+  role enum('author','editor','translator','illustrator') NOT NULL DEFAULT 'author',
+  position int NOT NULL DEFAULT 1,
+  PRIMARY KEY (id),
+  UNIQUE KEY book_author_role (book_id, author_id, role),
+  KEY author_id (author_id),
+  CONSTRAINT book_author_ibfk_1 FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+  CONSTRAINT book_author_ibfk_2 FOREIGN KEY (author_id) REFERENCES authors (id) ON DELETE CASCADE
+);
+
+INSERT INTO book_author (book_id, author_id, role, position)
+SELECT id, author_id, 'author', 1 FROM books WHERE author_id IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE publishers;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE publishers (
+  id int NOT NULL AUTO_INCREMENT,
+  name varchar(100) NOT NULL,
+  country varchar(100) NOT NULL DEFAULT '',
+  PRIMARY KEY (id)
+);
\ No newline at end of file
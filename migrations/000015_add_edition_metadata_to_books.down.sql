@@ -0,0 +1,8 @@
+ALTER TABLE books
+  DROP FOREIGN KEY books_ibfk_2,
+  DROP COLUMN format,
+  DROP COLUMN pages,
+  DROP COLUMN publication_year,
+  DROP COLUMN language,
+  DROP COLUMN edition,
+  DROP COLUMN publisher_id;
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE books
+  ADD COLUMN publisher_id int DEFAULT NULL AFTER isbn_13,
+  ADD COLUMN edition int DEFAULT NULL AFTER publisher_id,
+  ADD COLUMN language char(2) DEFAULT NULL AFTER edition,
+  ADD COLUMN publication_year smallint DEFAULT NULL AFTER language,
+  ADD COLUMN pages int DEFAULT NULL AFTER publication_year,
+  ADD COLUMN format enum('hardcover','paperback','ebook','audiobook','other') DEFAULT NULL AFTER pages,
+  ADD KEY publisher_id (publisher_id),
+  ADD KEY language (language),
+  ADD KEY publication_year (publication_year),
+  ADD CONSTRAINT books_ibfk_2 FOREIGN KEY (publisher_id) REFERENCES publishers (id) ON DELETE SET NULL;
\ No newline at end of file
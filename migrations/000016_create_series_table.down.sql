@@ -0,0 +1 @@
+DROP TABLE series;
\ No newline at end of file
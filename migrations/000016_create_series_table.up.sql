@@ -0,0 +1,6 @@
+CREATE TABLE series (
+  id int NOT NULL AUTO_INCREMENT,
+  name varchar(100) NOT NULL,
+  description text NOT NULL,
+  PRIMARY KEY (id)
+);
\ No newline at end of file
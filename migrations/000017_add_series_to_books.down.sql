@@ -0,0 +1,5 @@
+ALTER TABLE books
+  DROP FOREIGN KEY books_ibfk_3,
+  DROP INDEX series_volume,
+  DROP COLUMN series_volume,
+  DROP COLUMN series_id;
\ No newline at end of file
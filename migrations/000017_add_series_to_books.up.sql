@@ -0,0 +1,5 @@
+ALTER TABLE books
+  ADD COLUMN series_id int DEFAULT NULL AFTER format,
+  ADD COLUMN series_volume int DEFAULT NULL AFTER series_id,
+  ADD UNIQUE KEY series_volume (series_id, series_volume),
+  ADD CONSTRAINT books_ibfk_3 FOREIGN KEY (series_id) REFERENCES series (id) ON DELETE SET NULL;
\ No newline at end of file
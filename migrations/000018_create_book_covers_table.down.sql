@@ -0,0 +1 @@
+DROP TABLE book_covers;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE book_covers (
+  id int NOT NULL AUTO_INCREMENT,
+  book_id int NOT NULL,
+  size enum('original','small','medium') NOT NULL,
+  storage_key varchar(255) NOT NULL,
+  url varchar(500) NOT NULL,
+  content_type varchar(50) NOT NULL,
+  width int NOT NULL DEFAULT 0,
+  height int NOT NULL DEFAULT 0,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY book_cover_size (book_id, size),
+  CONSTRAINT book_covers_ibfk_1 FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
\ No newline at end of file
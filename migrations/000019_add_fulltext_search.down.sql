@@ -0,0 +1,3 @@
+DROP INDEX ft_authors_name ON authors;
+DROP INDEX ft_books_text ON books;
+DROP INDEX ft_books_title ON books;
\ No newline at end of file
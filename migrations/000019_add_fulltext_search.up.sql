@@ -0,0 +1,3 @@
+CREATE FULLTEXT INDEX ft_books_title ON books (title);
+CREATE FULLTEXT INDEX ft_books_text ON books (title, description, content);
+CREATE FULLTEXT INDEX ft_authors_name ON authors (name);
\ No newline at end of file
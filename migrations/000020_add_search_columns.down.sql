@@ -0,0 +1,6 @@
+ALTER TABLE books
+  DROP INDEX title_search,
+  DROP COLUMN title_search;
+ALTER TABLE authors
+  DROP INDEX name_search,
+  DROP COLUMN name_search;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE book_category
+  DROP INDEX book_category_pair;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DELETE bc1 FROM book_category bc1
+  JOIN book_category bc2 ON bc1.book_id = bc2.book_id AND bc1.category_id = bc2.category_id AND bc1.id > bc2.id;
+ALTER TABLE book_category
+  ADD UNIQUE KEY book_category_pair (book_id, category_id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE categories
+  DROP FOREIGN KEY categories_parent_fk,
+  DROP KEY categories_parent,
+  DROP COLUMN parent_id;
\ No newline at end of file
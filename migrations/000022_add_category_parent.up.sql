@@ -0,0 +1,4 @@
+ALTER TABLE categories
+  ADD COLUMN parent_id int NULL AFTER name,
+  ADD KEY categories_parent (parent_id),
+  ADD CONSTRAINT categories_parent_fk FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE books
+  DROP KEY call_number_key,
+  DROP COLUMN call_number_key,
+  DROP COLUMN call_number;
\ No newline at end of file
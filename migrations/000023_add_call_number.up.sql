@@ -0,0 +1,4 @@
+ALTER TABLE books
+  ADD COLUMN call_number varchar(64) NULL AFTER isbn_13,
+  ADD COLUMN call_number_key varchar(96) CHARACTER SET ascii COLLATE ascii_bin NULL AFTER call_number,
+  ADD KEY call_number_key (call_number_key);
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE refresh_tokens (
+  id int NOT NULL AUTO_INCREMENT,
+  user_id int NOT NULL,
+  family_id char(32) NOT NULL,
+  token_hash char(64) NOT NULL,
+  expires_at timestamp NOT NULL,
+  used_at timestamp NULL DEFAULT NULL,
+  revoked_at timestamp NULL DEFAULT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY token_hash (token_hash),
+  KEY family_id (family_id),
+  KEY user_id (user_id),
+  CONSTRAINT refresh_tokens_ibfk_1 FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE revoked_tokens (
+  jti char(32) NOT NULL,
+  user_id int NOT NULL,
+  expires_at timestamp NOT NULL,
+  PRIMARY KEY (jti),
+  KEY expires_at (expires_at)
+);
+
+CREATE TABLE token_cutoffs (
+  user_id int NOT NULL,
+  revoked_before timestamp NOT NULL,
+  PRIMARY KEY (user_id)
+);
\ No newline at end of file
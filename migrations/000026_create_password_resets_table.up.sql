@@ -0,0 +1,12 @@
+CREATE TABLE password_resets (
+  id int NOT NULL AUTO_INCREMENT,
+  user_id int NOT NULL,
+  token_hash char(64) NOT NULL,
+  expires_at timestamp NOT NULL,
+  used_at timestamp NULL DEFAULT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY token_hash (token_hash),
+  KEY user_id (user_id),
+  CONSTRAINT password_resets_ibfk_1 FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE email_verifications;
+
+ALTER TABLE users
+  DROP COLUMN email_verified;
\ No newline at end of file
@@ -0,0 +1,18 @@
+ALTER TABLE users
+  ADD COLUMN email_verified tinyint(1) NOT NULL DEFAULT 0 AFTER role;
+
+-- contas anteriores à confirmação não são bloqueadas
+UPDATE users SET email_verified = 1;
+
+CREATE TABLE email_verifications (
+  id int NOT NULL AUTO_INCREMENT,
+  user_id int NOT NULL,
+  token_hash char(64) NOT NULL,
+  expires_at timestamp NOT NULL,
+  used_at timestamp NULL DEFAULT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY token_hash (token_hash),
+  KEY user_id (user_id),
+  CONSTRAINT email_verifications_ibfk_1 FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE role_changes;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE role_changes (
+  id int NOT NULL AUTO_INCREMENT,
+  user_id int NOT NULL,
+  changed_by int NOT NULL,
+  old_role enum('user','admin') NOT NULL,
+  new_role enum('user','admin') NOT NULL,
+  changed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY user_id (user_id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE token_cutoffs DROP COLUMN kept_session_id;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE token_cutoffs ADD COLUMN kept_session_id char(32) NOT NULL DEFAULT '';
\ No newline at end of file
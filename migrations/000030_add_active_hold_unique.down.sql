@@ -0,0 +1,3 @@
+ALTER TABLE holds
+  DROP INDEX holds_active_user_book,
+  DROP COLUMN active;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE holds
+  ADD COLUMN active tinyint GENERATED ALWAYS AS (IF(status IN ('waiting', 'ready'), 1, NULL)) VIRTUAL,
+  ADD UNIQUE KEY holds_active_user_book (user_id, book_id, active);
\ No newline at end of file
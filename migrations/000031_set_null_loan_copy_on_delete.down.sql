@@ -0,0 +1,2 @@
+ALTER TABLE loans DROP FOREIGN KEY loans_ibfk_3;ALTER TABLE loans
+  ADD CONSTRAINT loans_ibfk_3 FOREIGN KEY (copy_id) REFERENCES copies (id);
\ No newline at end of file